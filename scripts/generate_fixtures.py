@@ -135,6 +135,30 @@ z: Union[int, str] = "hello"
 class User:
     name: str
     age: int
+''',
+        "docstrings": '''def fetch(url, timeout=None, **kwargs):
+    """Fetch a URL. See :func:`urllib.request.urlopen`.
+
+    Args:
+        url (str): The address to fetch.
+        timeout (float): Seconds to wait, or ``None``.
+        **kwargs: Extra options.
+
+    Returns:
+        The response `body`.
+
+    Raises:
+        TimeoutError: If the request times out.
+    """
+
+
+def parse(text):
+    """Parse text.
+
+    :param str text: Input text.
+    :returns: The parsed value.
+    :raises ValueError: If the text is malformed.
+    """
 ''',
     },
     "javascript": {
@@ -344,6 +368,15 @@ fn example() -> i32 {
 }
 
 //! Module-level documentation
+''',
+        "doc_comments": '''//! Networking helpers built on [`std::net`].
+
+/// Fetches `url`, returning a [`Response`].
+///
+/// See [Client::send] and the [guide](https://example.com/guide).
+pub fn fetch(url: &str) -> Response {
+    Client::new().send(url)
+}
 ''',
         "operators": '''// Arithmetic
 let a = 1 + 2;
@@ -739,6 +772,119 @@ body {
     transform: scale(1.05);
     transition: transform 0.2s ease;
 }
+''',
+    },
+    "scss": {
+        "basics": '''@use "sass:math";
+
+// Design tokens
+$primary: #3498db !default;
+$breakpoints: (small: 576px, "large": 992px);
+
+@mixin button-variant($bg, $fg: white) {
+  background: $bg;
+  color: $fg;
+  &:hover { background: darken($bg, 10%); }
+}
+
+%card-base { padding: math.div($gap, 2); }
+
+.btn-#{$name} {
+  @include button-variant($primary);
+  @extend %card-base;
+  width: calc(100% - #{$gap});
+  content: "icon-#{$name}";
+  a:hover { color: red; }
+  @if $theme == dark and not $flat { border: none; } @else if $x { margin:0 }
+  @each $key, $value in $breakpoints { .w-#{$key} { width: $value; } }
+  @for $i from 1 through 3 { .m-#{$i} { margin: $i * 4px; } }
+  background: url(//cdn.example.com/a.png);
+}
+''',
+    },
+    "sass": {
+        "basics": '''// Indented syntax
+$primary: #333
+$gap: 4px !default
+
+=button($bg)
+  background: $bg
+  &:hover
+    color: lighten($bg, 20%)
+
+nav
+  ul
+    margin: 0
+  a:hover
+    color: red
+  a:visited, a:active
+    color:blue
+  .icon-#{$name}
+    +button($primary)
+    @extend %base
+    @if $gap > 2px
+      padding: $gap * 2
+    @else
+      padding: 0
+''',
+    },
+    "less": {
+        "basics": '''// Variables
+@primary: #428bca;
+@selector: ~".my";
+@@name: 10px;
+
+.bordered(@width: 2px; @style: solid) {
+  border: @width @style black;
+}
+
+.mixin(@a) when (lightness(@a) >= 50%) and not (@a = red) {
+  background-color: black;
+}
+
+@{selector}-class {
+  .bordered(4px; dashed);
+  #namespace > .mixin(@primary);
+  color: darken(@primary, 10%);
+  width: ~"calc(100% - @{gutter})";
+  @media (min-width: 768px) { float: left; }
+  &-title { font-size: @base * 2 !important; }
+}
+@import (reference) "foo.less";
+@detached: { background: red; };
+.call { @detached(); }
+''',
+    },
+    "stylus": {
+        "basics": '''// Variables
+font-size = 14px
+base ?= 4px
+$accent = #e91e63
+
+border-radius(n)
+  -webkit-border-radius n
+  border-radius n
+
+add(a, b)
+  a + b
+
+body
+  font font-size Arial, sans-serif
+  color: #333
+  padding: add(base, 2px)
+  a
+    color $accent
+    &:hover
+      border-radius 5px
+  for i in 1..3
+    .m-{i}
+      margin i * base
+  if font-size > 12px
+    line-height 1.5
+  unless $accent is defined
+    display none
+
+nav { color: red; }
 ''',
     },
     "markdown": {
@@ -767,6 +913,59 @@ def hello():
 [Link text](https://example.com)
 
 ![Alt text](image.jpg)
+''',
+        "tables": '''| Option | Default | Description |
+| :----- | :-----: | ----------: |
+| `tab_size` | 4 | Spaces per **tab** |
+| `style` | `"a \\| b"` | See [docs](https://example.com) |
+
+Not a table | just a pipe.
+''',
+        "task_lists": '''- [ ] Write the lexer
+- [x] Add fixtures
+  - [X] Nested done item
+1. [ ] Ordered task
+- [link](https://example.com) is not a checkbox
+''',
+        "footnotes": '''Rosettes is fast[^perf] and safe[^redos].
+
+[^perf]: See the benchmarks.
+[^redos]: No regex means no ReDoS.
+''',
+        "autolinks": '''Visit <https://example.com/docs> or mail <team@example.com>.
+Bare links work too: https://example.com/path?q=1, and www.example.org.
+Parentheses (see https://en.wikipedia.org/wiki/Lexer_(software)) are balanced.
+''',
+        "html_blocks": '''Intro paragraph.
+
+<details>
+  <summary>Click to expand</summary>
+  Hidden content
+</details>
+
+<!-- A comment
+spanning lines -->
+
+<pre>
+  keep   spacing
+</pre>
+
+Back to *Markdown*.
+''',
+        "frontmatter": '''---
+title: Getting Started
+tags: [intro, setup]
+draft: false
+---
+
+# Getting Started
+''',
+        "frontmatter_toml": '''+++
+title = "Getting Started"
+weight = 10
++++
+
+# Getting Started
 ''',
     },
     # Tier 1: Critical languages
@@ -813,8 +1012,45 @@ public class User {
     .filter(s -> s.length() > 3)
     .map(String::toUpperCase)
     .collect(Collectors.toList());''',
+        "javadoc": '''/**
+ * Fetches a resource. See {@link java.net.URL} and {@code fetch(url)}.
+ *
+ * @param <T> the result type
+ * @param url the address to fetch
+ * @return the response body
+ * @throws IOException if the request fails
+ * @see Client#send(Request)
+ */
+public <T> T fetch(String url) throws IOException {
+    return client.get(url);
+}''',
     },
     "cpp": {
+        "preprocessor": r'''#include <stdio.h>
+#include "config.h"  // local
+#  define MAX(a, b) \
+    ((a) > (b) ? (a) : (b))
+#define STR(x) #x
+#define CAT(a, b) a##b
+#ifndef CONFIG_H
+#pragma once
+#if 0
+int disabled(void) { return "no"; }
+#  if FOO
+nested();
+#  endif
+#else
+int enabled;
+#endif
+#if 0 // reason
+dead();
+#endif
+auto s = R"sql(SELECT "x" FROM t)sql";
+auto u = u8R"(
+multi
+)";
+int after = 1;
+''',
         "classes": '''template<typename T>
 class Container {
 public:
@@ -1554,6 +1790,11 @@ port = 5432''',
         "basics": '''name,age,email
 Alice,30,alice@example.com
 Bob,25,bob@example.com''',
+        "dialects": '''id;product;"price (€)";notes
+1;Widget;9,99;"Ships in a ""small"" box"
+2;"Gadget; deluxe";24,50;"Line one
+line two"
+3;Gizmo;0,75;''',
     },
     "diff": {
         "basics": '''--- a/file.txt
@@ -1564,6 +1805,65 @@ Bob,25,bob@example.com''',
 +added line
 +another added
  unchanged''',
+        "git_headers": '''diff --git a/old_name.py b/new_name.py
+similarity index 87%
+rename from old_name.py
+rename to new_name.py
+index 83db48f..bf269f4 100644
+--- a/old_name.py
++++ b/new_name.py
+@@ -1,4 +1,4 @@ def main():
+ import os
+--- a/this line starts with dashes
++print("hello")
+ x = 1
+-y = 2
+\\ No newline at end of file
++y = 2
+diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index e69de29..0000000''',
+        "binary": '''diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..3f4a2b1
+Binary files /dev/null and b/logo.png differ
+diff --git a/icon.bin b/icon.bin
+index 7c2e1a0..d41f9b8 100644
+GIT binary patch
+literal 12
+TcmZ?wbhEHbRA2x9
+
+literal 0
+HcmV?d00001
+''',
+        "combined": '''diff --cc merged.txt
+index 1a2b3c4,5d6e7f8..9a0b1c2
+--- a/merged.txt
++++ b/merged.txt
+@@@ -1,3 -1,3 +1,4 @@@
+  shared line
+- ours
+ -theirs
+++resolved
++ added in ours
+  trailing''',
+        "word_diff": '''diff --git a/story.txt b/story.txt
+index 1111111..2222222 100644
+--- a/story.txt
++++ b/story.txt
+@@ -1,3 +1,3 @@
+The [-quick-]{+slow+} brown fox
+jumps over
+the {+very +}lazy dog''',
+        "stat": '''---
+ README.md                 |  12 ++++++++----
+ src/{old => new}/mod.py   |   4 ++--
+ assets/logo.png           | Bin 0 -> 2048 bytes
+ 3 files changed, 10 insertions(+), 6 deletions(-)
+ 1 file changed, 1 insertion(+)''',
     },
     "makefile": {
         "basics": '''CC = gcc
@@ -1732,6 +2032,21 @@ Hello, {{ name }}!
 ├── tests
 │   └── test_main.py
 └── README.md''',
+    },
+    "tree-listing": {
+        "listing": '''project
+|-- [4.0K]  docs/
+|   `-- [1.2K]  index.md
+|-- [ 512]  empty/
+|-- config
+|   |-- settings.yaml
+|   `-- Dockerfile
+|-- latest -> releases/v2
+|-- run.sh*
+|-- LICENSE
+`-- data.bin
+
+4 directories, 6 files''',
     },
     "powershell": {
         "basics": '''# Variables
@@ -1811,6 +2126,10 @@ EXTENSIONS: dict[str, str] = {
     "xml": ".xml",
     "html": ".html",
     "css": ".css",
+    "scss": ".scss",
+    "sass": ".sass",
+    "less": ".less",
+    "stylus": ".styl",
     "markdown": ".md",
     "c": ".c",
     "cpp": ".cpp",
@@ -1848,6 +2167,7 @@ EXTENSIONS: dict[str, str] = {
     "stan": ".stan",
     "jinja": ".jinja",
     "tree": ".tree",
+    "tree-listing": ".tree",
     "powershell": ".ps1",
     "plaintext": ".txt",
     "cuda": ".cu",
@@ -0,0 +1,58 @@
+#!/usr/bin/env python3
+"""Render every registered palette into a static HTML gallery.
+
+Samples come from the lexer fixture corpus (tests/fixtures).
+
+Usage:
+    uv run python scripts/theme_gallery.py
+    uv run python scripts/theme_gallery.py -o gallery.html --language python --language sql
+    uv run python scripts/theme_gallery.py --palette monokai --palette github
+"""
+
+from __future__ import annotations
+
+import argparse
+from pathlib import Path
+
+from rosettes.gallery import render_gallery
+
+FIXTURES_DIR = Path(__file__).parent.parent / "tests" / "fixtures"
+
+DEFAULT_LANGUAGES = ("python", "javascript", "rust", "go", "json", "css", "bash", "sql")
+
+# Preferred fixture per language; otherwise the first file by name
+PREFERRED_FIXTURES = ("basics", "keywords")
+
+
+def load_sample(language: str) -> tuple[str, str]:
+    """Read one fixture source file for a language."""
+    lang_dir = FIXTURES_DIR / language
+    sources = sorted(
+        path for path in lang_dir.glob("*.*") if not path.name.endswith(".tokens.json")
+    )
+    if not sources:
+        raise SystemExit(f"No fixtures for {language!r} in {lang_dir}")
+    by_name = {path.stem: path for path in sources}
+    path = next((by_name[n] for n in PREFERRED_FIXTURES if n in by_name), sources[0])
+    return path.read_text(encoding="utf-8"), language
+
+
+def main() -> None:
+    parser = argparse.ArgumentParser(description="Render a theme gallery")
+    parser.add_argument(
+        "-o", "--output", type=Path, default=Path("theme-gallery.html"), help="HTML file to write"
+    )
+    parser.add_argument(
+        "--language", action="append", help="Fixture language to include (repeatable)"
+    )
+    parser.add_argument("--palette", action="append", help="Palette to include (repeatable)")
+
+    args = parser.parse_args()
+
+    samples = [load_sample(language) for language in args.language or DEFAULT_LANGUAGES]
+    args.output.write_text(render_gallery(samples, args.palette), encoding="utf-8")
+    print(f"Wrote {args.output}")
+
+
+if __name__ == "__main__":
+    main()
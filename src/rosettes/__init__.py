@@ -8,6 +8,7 @@ and zero ReDoS vulnerability.
 
 - `highlight()`: Highlight code and return formatted HTML/terminal output
 - `tokenize()`: Get raw tokens for analysis or custom formatting
+- `tokenize_lines()`: Tokens grouped by line, for editors and TUIs
 - `highlight_many()`: Parallel highlighting for multiple code blocks
 - `tokenize_many()`: Parallel tokenization for multiple code blocks
 
@@ -103,21 +104,35 @@ from __future__ import annotations
 
 import os
 from concurrent.futures import ThreadPoolExecutor
+from dataclasses import replace
 from typing import TYPE_CHECKING, Literal
 
 from rosettes._config import FormatConfig, HighlightConfig, LexerConfig
+from rosettes._dedent import DedentedCode, dedent_code, restore_positions
+from rosettes._excerpt import excerpt
 from rosettes._formatter_registry import get_formatter, list_formatters, supports_formatter
+from rosettes._notation import apply_notation
+from rosettes._placeholders import apply_placeholders
 from rosettes._protocol import Formatter, Lexer
 from rosettes._registry import (
+    FallbackPolicy,
+    UnknownLanguageError,
     get_lexer,
+    guess_language,
     list_languages,
     supports_language,
 )
+from rosettes._search import SearchMatch, SearchQuery, find_matches
+from rosettes._spans import split_lines
 from rosettes._types import Token, TokenType
-from rosettes.formatters import HtmlFormatter
+from rosettes.config import configure, get_config
+from rosettes.formatters import HtmlFormatter, TerminalFormatter
+from rosettes.linedata import LineData
 
 if TYPE_CHECKING:
-    from collections.abc import Iterable
+    from collections.abc import Iterable, Iterator, Mapping, Sequence
+
+    from rosettes.xref import Resolver
 
 __version__ = "0.1.0"
 
@@ -134,35 +149,68 @@ __all__ = [
     "LexerConfig",
     "FormatConfig",
     "HighlightConfig",
+    # Dedent
+    "DedentedCode",
+    "dedent_code",
+    # Line data
+    "LineData",
+    # Search
+    "SearchQuery",
+    "SearchMatch",
+    "find_matches",
     # Registry
+    "UnknownLanguageError",
     "get_lexer",
+    "guess_language",
     "list_languages",
     "supports_language",
     "get_formatter",
     "list_formatters",
     "supports_formatter",
+    # Project configuration
+    "configure",
     # Formatters
     "HtmlFormatter",
     # High-level API
     "highlight",
     "tokenize",
+    "tokenize_lines",
     # Parallel API (3.14t optimized)
     "highlight_many",
     "tokenize_many",
 ]
 
 
+def _resolve_lexer(language: str | Lexer, fallback: FallbackPolicy | None) -> Lexer:
+    """Look up a language name; use a lexer instance as is."""
+    if isinstance(language, str):
+        return get_lexer(language, fallback=fallback)
+    return language
+
+
 def highlight(
     code: str,
-    language: str,
+    language: str | Lexer,
     formatter: str | Formatter = "html",
     *,
     hl_lines: set[int] | frozenset[int] | None = None,
     show_linenos: bool = False,
     css_class: str | None = None,
-    css_class_style: Literal["semantic", "pygments"] = "semantic",
+    css_class_style: Literal["semantic", "pygments"] | None = None,
     start: int = 0,
     end: int | None = None,
+    format_placeholders: bool = False,
+    notation: bool = False,
+    lines: tuple[int, int] | Sequence[tuple[int, int]] | None = None,
+    elision: str | None = "⋯",
+    dedent: bool = False,
+    trim: bool = False,
+    resolver: Resolver | None = None,
+    search: Sequence[str] | SearchQuery | None = None,
+    line_data: Mapping[int, LineData] | None = None,
+    wrap: bool = False,
+    fallback: FallbackPolicy | None = None,
+    lexer_config: LexerConfig | None = None,
 ) -> str:
     """Highlight source code and return formatted output.
 
@@ -174,7 +222,8 @@ def highlight(
 
     Args:
         code: The source code to highlight.
-        language: Language name or alias (e.g., 'python', 'py', 'js').
+        language: Language name or alias (e.g., 'python', 'py', 'js'), or
+            a lexer instance (anything implementing the Lexer protocol).
         formatter: Formatter name ('html', 'terminal', 'null') or instance.
         hl_lines: Optional set of 1-based line numbers to highlight.
         show_linenos: If True, include line numbers in output.
@@ -183,14 +232,57 @@ def highlight(
         css_class_style: Class naming style (HTML only):
             - "semantic" (default): Uses readable classes like .syntax-function
             - "pygments": Uses Pygments-compatible classes like .nf
+            - None: The configured default (see `rosettes.config`)
         start: Starting index in the source string.
         end: Optional ending index in the source string.
+        format_placeholders: If True, highlight placeholders such as `%5.2f`
+            or `{name!r}` inside format strings (printf calls, `.format()`,
+            `println!`, etc.) as STRING_INTERPOL.
+        notation: If True, read `[!code highlight]`, `[!code ++]`,
+            `[!code --]`, `[!code focus]`, `[!code error]` and
+            `[!code warning]` markers from comments, remove them, and
+            style the marked lines (HTML and terminal). hl_lines then
+            refers to line numbers after marker lines are removed.
+        lines: Only output these 1-based, inclusive line ranges, e.g.
+            `(120, 140)` or `[(1, 5), (120, 140)]`. The whole code is lexed,
+            so an excerpt that starts inside a docstring or block comment
+            is still highlighted correctly. Line numbers and hl_lines use
+            the real line numbers.
+        elision: Line shown between non-adjacent ranges, or None for none.
+        dedent: If True, remove indentation shared by all non-blank lines
+            before lexing. Tabs count to the next multiple of
+            `lexer_config.tab_size` (4 by default) columns.
+        trim: If True, remove blank lines at the start and end before
+            lexing. hl_lines, lines and line numbers still refer to the
+            original lines.
+        resolver: Callable or mapping from names (and dotted names such
+            as `os.path.join`) to documentation URLs. Resolved names are
+            wrapped in links (HTML only). See `rosettes.xref`.
+        search: Terms to mark in the output, as literal strings or a
+            SearchQuery (case-insensitive and whole-word modes). Matches
+            are wrapped in `<mark>` (HTML) or shown in reverse video
+            (terminal). Use find_matches() for their positions.
+        line_data: Per-line coverage status, heat intensity and gutter
+            columns, keyed by 1-based line number like hl_lines. See
+            `rosettes.linedata`.
+        wrap: If True, soft-wrap long lines (HTML and terminal). HTML
+            wraps with CSS and a hanging indent; the terminal wraps at
+            its width with `↪` continuation markers. Formatter instances
+            with `wrap=True` wrap without it.
+        fallback: Policy for unknown languages: "raise", "plaintext" or
+            "guess"; None (default) uses the configured fallback language,
+            if any, else raises (see get_lexer()).
+        lexer_config: Passed to the lexer's tokenize(); its tab_size also
+            sets the tab width for dedent.
 
     Returns:
         Formatted string with syntax-highlighted code.
 
     Raises:
-        LookupError: If the language or formatter is not supported.
+        UnknownLanguageError: If the language is not supported and
+            fallback is "raise" (or None without a configured fallback).
+        LookupError: If the formatter is not supported.
+        ValueError: If a line range is invalid.
 
     Example:
         >>> html = highlight("print('hello')", "python")
@@ -201,28 +293,140 @@ def highlight(
         >>> ansi = highlight("print('hello')", "python", formatter="terminal")
         >>> "\\033[" in ansi
         True
+
+        >>> # Mark lines with comments
+        >>> html = highlight("x = 1  # [!code ++]", "python", notation=True)
+        >>> "line-added" in html and "[!code" not in html
+        True
     """
-    lexer = get_lexer(language)
+    lexer = _resolve_lexer(language, fallback)
     canonical_language = lexer.name
 
+    line_offset = 0
+    if dedent or trim:
+        block = dedent_code(code, lexer_config, start=start, end=end, dedent=dedent, trim=trim)
+        code, start, end = block.code, 0, None
+        line_offset = block.line_offset
+        if line_offset and lines is None:
+            if hl_lines:
+                hl_lines = {line - line_offset for line in hl_lines if line > line_offset}
+            if line_data:
+                line_data = {
+                    line - line_offset: data
+                    for line, data in line_data.items()
+                    if line > line_offset
+                }
+
+    tokens: Iterable[Token] | None = None
+    line_classes: dict[int, str] = {}
+    line_numbers: tuple[int | None, ...] = ()
+    if notation or lines is not None or search:
+        tokens = lexer.tokenize(code, lexer_config, start=start, end=end)
+        if format_placeholders:
+            tokens = apply_placeholders(tokens, canonical_language)
+
+    marked_lines: frozenset[int] = frozenset()
+    removed_lines: tuple[int, ...] = ()
+    if notation:
+        marked = apply_notation(tokens)
+        tokens = marked.tokens
+        line_classes = marked.line_classes
+        marked_lines = marked.hl_lines
+        removed_lines = marked.removed_lines
+
+    if lines is not None:
+        part = excerpt(
+            tokens,
+            lines,
+            elision=elision,
+            removed_lines=removed_lines,
+            line_offset=line_offset,
+        )
+        tokens = part.tokens
+        line_numbers = part.line_numbers
+        # Map real (hl_lines) and notation line numbers to excerpt lines
+        real_hl = hl_lines or frozenset()
+        hl_lines = {
+            number
+            for number, (real, source) in enumerate(zip(line_numbers, part.source_lines), 1)
+            if real in real_hl or source in marked_lines
+        }
+        if line_data:
+            line_data = {
+                number: line_data[real]
+                for number, real in enumerate(line_numbers, 1)
+                if real in line_data
+            }
+        line_classes = {
+            number: line_classes[source]
+            for number, source in enumerate(part.source_lines, 1)
+            if source in line_classes
+        } | part.line_classes
+    else:
+        if marked_lines:
+            hl_lines = marked_lines | frozenset(hl_lines or ())
+        if line_offset and show_linenos:
+            line_numbers = tuple(range(line_offset + 1, line_offset + code.count("\n") + 2))
+
+    marks: tuple[tuple[int, int], ...] = ()
+    if search:
+        tokens = list(tokens)
+        text = "".join(t.value for t in tokens)
+        marks = tuple((match.start, match.end) for match in find_matches(text, search))
+
     # Resolve formatter
     formatter_inst = get_formatter(formatter) if isinstance(formatter, str) else formatter
 
     # Determine container class based on style
+    if css_class_style is None:
+        css_class_style = get_config().css_class_style
     if css_class is None:
         css_class = "rosettes" if css_class_style == "semantic" else "highlight"
 
+    # Soft wrap is a formatter option; an instance may already have it
+    if isinstance(formatter_inst, (HtmlFormatter, TerminalFormatter)):
+        if wrap and not formatter_inst.wrap:
+            formatter_inst = replace(formatter_inst, wrap=True)
+        wrap = formatter_inst.wrap
+    else:
+        wrap = False
+
+    # Links are HTML-only; an HtmlFormatter instance may bring its own resolver
+    if not isinstance(formatter_inst, HtmlFormatter):
+        resolver = None
+    elif resolver is None:
+        resolver = formatter_inst.resolver
+
     # Fast path: all formatters implement format_string_fast via protocol
-    # Requires: no line numbers, no highlighted lines
-    if not hl_lines and not show_linenos:
+    # Requires: no line numbers, no highlighted or classed lines, no links or marks
+    if (
+        not hl_lines
+        and not show_linenos
+        and not line_classes
+        and resolver is None
+        and not marks
+        and not line_data
+        and not wrap
+    ):
         # Apply HTML-specific configuration if it's an HtmlFormatter
         if (
             isinstance(formatter_inst, HtmlFormatter)
             and formatter_inst.css_class_style != css_class_style
         ):
-            formatter_inst = HtmlFormatter(css_class_style=css_class_style)
+            formatter_inst = replace(formatter_inst, css_class_style=css_class_style)
 
         format_config = FormatConfig(css_class=css_class, data_language=canonical_language)
+        if tokens is not None:
+            return formatter_inst.format_string_fast(
+                ((t.type, t.value) for t in tokens), format_config
+            )
+        if format_placeholders or lexer_config is not None:
+            tokens = lexer.tokenize(code, lexer_config, start=start, end=end)
+            if format_placeholders:
+                tokens = apply_placeholders(tokens, canonical_language)
+            return formatter_inst.format_string_fast(
+                ((t.type, t.value) for t in tokens), format_config
+            )
         return formatter_inst.format_string_fast(
             lexer.tokenize_fast(code, start=start, end=end), format_config
         )
@@ -233,24 +437,42 @@ def highlight(
         hl_lines=frozenset(hl_lines) if hl_lines else frozenset(),
         show_linenos=show_linenos,
         css_class=css_class,
+        line_classes=tuple(sorted(line_classes.items())),
+        line_numbers=line_numbers,
+        marks=marks,
+        line_data=tuple(sorted(line_data.items())) if line_data else (),
     )
 
     # Re-instantiate HtmlFormatter with slow-path config if needed
     if isinstance(formatter_inst, HtmlFormatter) and (
-        formatter_inst.config != hl_config or formatter_inst.css_class_style != css_class_style
+        formatter_inst.config != hl_config
+        or formatter_inst.css_class_style != css_class_style
+        or formatter_inst.resolver is not resolver
     ):
-        formatter_inst = HtmlFormatter(config=hl_config, css_class_style=css_class_style)
+        formatter_inst = replace(
+            formatter_inst, config=hl_config, css_class_style=css_class_style, resolver=resolver
+        )
+    elif isinstance(formatter_inst, TerminalFormatter) and formatter_inst.config != hl_config:
+        formatter_inst = replace(formatter_inst, config=hl_config)
 
-    return "".join(
-        formatter_inst.format(lexer.tokenize(code, start=start, end=end), config=format_config)
-    )
+    if tokens is None:
+        tokens = lexer.tokenize(code, lexer_config, start=start, end=end)
+        if format_placeholders:
+            tokens = apply_placeholders(tokens, canonical_language)
+    return "".join(formatter_inst.format(tokens, config=format_config))
 
 
 def tokenize(
     code: str,
-    language: str,
+    language: str | Lexer,
     start: int = 0,
     end: int | None = None,
+    *,
+    format_placeholders: bool = False,
+    dedent: bool = False,
+    trim: bool = False,
+    fallback: FallbackPolicy | None = None,
+    lexer_config: LexerConfig | None = None,
 ) -> list[Token]:
     """Tokenize source code without formatting.
 
@@ -262,23 +484,95 @@ def tokenize(
 
     Args:
         code: The source code to tokenize.
-        language: Language name or alias.
+        language: Language name or alias, or a lexer instance.
         start: Starting index in the source string.
         end: Optional ending index in the source string.
+        format_placeholders: If True, split placeholders inside format
+            strings out as STRING_INTERPOL tokens.
+        dedent: If True, lex the code with its common indentation removed.
+        trim: If True, lex the code without leading and trailing blank lines.
+        fallback: Policy for unknown languages: "raise", "plaintext" or
+            "guess"; None (default) uses the configured fallback language,
+            if any, else raises (see get_lexer()).
+        lexer_config: Passed to the lexer's tokenize(); its tab_size also
+            sets the tab width for dedent.
+
+    With dedent or trim, token positions are mapped back to the original
+    code: lines count from `start` and columns include the removed
+    indentation.
 
     Returns:
         List of Token objects.
 
     Raises:
-        LookupError: If the language is not supported.
+        UnknownLanguageError: If the language is not supported and
+            fallback is "raise" (or None without a configured fallback).
 
     Example:
         >>> tokens = tokenize("x = 1", "python")
         >>> tokens[0].type
         <TokenType.NAME: 'n'>
     """
-    lexer = get_lexer(language)
-    return list(lexer.tokenize(code, start=start, end=end))
+    lexer = _resolve_lexer(language, fallback)
+    if dedent or trim:
+        block = dedent_code(code, lexer_config, start=start, end=end, dedent=dedent, trim=trim)
+        tokens = tokenize(
+            block.code, lexer, format_placeholders=format_placeholders, lexer_config=lexer_config
+        )
+        return list(restore_positions(tokens, block))
+    tokens = lexer.tokenize(code, lexer_config, start=start, end=end)
+    if format_placeholders:
+        return apply_placeholders(tokens, lexer.name)
+    return list(tokens)
+
+
+def tokenize_lines(
+    code: str,
+    language: str | Lexer,
+    *,
+    format_placeholders: bool = False,
+    fallback: FallbackPolicy | None = None,
+    lexer_config: LexerConfig | None = None,
+) -> Iterator[list[Token]]:
+    """Tokenize source code one line at a time.
+
+    For editors and terminal UIs that draw line by line. Multi-line
+    tokens (docstrings, block comments) are split at line breaks and keep
+    their type on every line. Lexing is lazy: lines are produced as the
+    lexer reaches them.
+
+    Args:
+        code: The source code to tokenize.
+        language: Language name or alias, or a lexer instance.
+        format_placeholders: If True, split placeholders inside format
+            strings out as STRING_INTERPOL tokens.
+        fallback: Policy for unknown languages: "raise", "plaintext" or
+            "guess"; None (default) uses the configured fallback language,
+            if any, else raises (see get_lexer()).
+        lexer_config: Passed to the lexer's tokenize().
+
+    Returns:
+        An iterator over lines. Each line is a list of Tokens without the
+        line break, so `token.type, token.value` are the fragment's type
+        and text, and `token.column` is its 1-based column (in
+        characters) on `token.line`. Blank lines are empty lists.
+
+    Raises:
+        UnknownLanguageError: If the language is not supported and
+            fallback is "raise" (or None without a configured fallback).
+
+    Example:
+        >>> lines = list(tokenize_lines("/* a\\nb */\\nx", "c"))
+        >>> [[t.value for t in line] for line in lines]
+        [['/* a'], ['b */'], ['x']]
+        >>> lines[1][0].type
+        <TokenType.COMMENT_MULTILINE: 'cm'>
+    """
+    lexer = _resolve_lexer(language, fallback)
+    tokens: Iterable[Token] = lexer.tokenize(code, lexer_config)
+    if format_placeholders:
+        tokens = apply_placeholders(tokens, lexer.name)
+    return split_lines(tokens)
 
 
 # =============================================================================
@@ -287,11 +581,18 @@ def tokenize(
 
 
 def highlight_many(
-    items: Iterable[tuple[str, str]],
+    items: Iterable[tuple[str, str | Lexer]],
     *,
     formatter: str | Formatter = "html",
     max_workers: int | None = None,
-    css_class_style: Literal["semantic", "pygments"] = "semantic",
+    css_class_style: Literal["semantic", "pygments"] | None = None,
+    hl_lines: set[int] | frozenset[int] | None = None,
+    show_linenos: bool = False,
+    notation: bool = False,
+    dedent: bool = False,
+    trim: bool = False,
+    fallback: FallbackPolicy | None = None,
+    lexer_config: LexerConfig | None = None,
 ) -> list[str]:
     """Highlight multiple code blocks in parallel.
 
@@ -302,15 +603,28 @@ def highlight_many(
     Thread-safe by design: each lexer uses only local variables.
 
     Args:
-        items: Iterable of (code, language) tuples.
+        items: Iterable of (code, language) tuples; the language may be a
+            lexer instance.
         formatter: Formatter name or instance.
         max_workers: Maximum number of threads. Defaults to min(4, CPU count),
             which benchmarking shows to be optimal.
-        css_class_style: Class naming style (HTML only).
+        css_class_style: Class naming style (HTML only). Defaults to the
+            configured style (see `rosettes.config`).
+        hl_lines: Line numbers to highlight in every block.
+        show_linenos: If True, include line numbers in every block.
+        notation: If True, apply notation comments (see highlight()).
+        dedent: If True, remove common indentation from each block.
+        trim: If True, remove blank lines around each block.
+        fallback: Policy for unknown languages (see get_lexer()).
+        lexer_config: Lexer configuration for every block.
 
     Returns:
         List of formatted strings in the same order as input.
 
+    Raises:
+        UnknownLanguageError: If a language is not supported and fallback
+            is "raise" (or None without a configured fallback).
+
     Example:
         >>> blocks = [
         ...     ("def foo(): pass", "python"),
@@ -325,16 +639,25 @@ def highlight_many(
     if not items_list:
         return []
 
+    def _highlight_one(item: tuple[str, str | Lexer]) -> str:
+        code, language = item
+        return highlight(
+            code,
+            language,
+            formatter=formatter,
+            css_class_style=css_class_style,
+            hl_lines=hl_lines,
+            show_linenos=show_linenos,
+            notation=notation,
+            dedent=dedent,
+            trim=trim,
+            fallback=fallback,
+            lexer_config=lexer_config,
+        )
+
     # For small batches, sequential is faster (thread overhead)
     if len(items_list) < 8:
-        return [
-            highlight(code, lang, formatter=formatter, css_class_style=css_class_style)
-            for code, lang in items_list
-        ]
-
-    def _highlight_one(item: tuple[str, str]) -> str:
-        code, language = item
-        return highlight(code, language, formatter=formatter, css_class_style=css_class_style)
+        return [_highlight_one(item) for item in items_list]
 
     # Optimal worker count based on benchmarking: 4 workers is sweet spot
     if max_workers is None:
@@ -345,7 +668,7 @@ def highlight_many(
 
 
 def tokenize_many(
-    items: Iterable[tuple[str, str]],
+    items: Iterable[tuple[str, str | Lexer]],
     *,
     max_workers: int | None = None,
 ) -> list[list[Token]]:
@@ -357,7 +680,8 @@ def tokenize_many(
     Thread-safe by design: each lexer uses only local variables.
 
     Args:
-        items: Iterable of (code, language) tuples.
+        items: Iterable of (code, language) tuples; the language may be a
+            lexer instance.
         max_workers: Maximum number of threads. Defaults to min(4, CPU count).
 
     Returns:
@@ -383,7 +707,7 @@ def tokenize_many(
     if len(items_list) < 8:
         return [tokenize(code, lang) for code, lang in items_list]
 
-    def _tokenize_one(item: tuple[str, str]) -> list[Token]:
+    def _tokenize_one(item: tuple[str, str | Lexer]) -> list[Token]:
         code, language = item
         return tokenize(code, language)
 
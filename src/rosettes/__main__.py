@@ -0,0 +1,7 @@
+"""Run the Rosettes command line: `python -m rosettes`."""
+
+import sys
+
+from rosettes.cli import main
+
+sys.exit(main())
@@ -28,7 +28,13 @@ See Also:
     rosettes.formatters.html.HtmlFormatter: Uses HighlightConfig
 """
 
+from __future__ import annotations
+
 from dataclasses import dataclass
+from typing import TYPE_CHECKING
+
+if TYPE_CHECKING:
+    from rosettes.linedata import LineData
 
 __all__ = ["LexerConfig", "FormatConfig", "HighlightConfig"]
 
@@ -73,6 +79,19 @@ class HighlightConfig:
         css_class: Base CSS class for the code container.
         lineno_class: CSS class for line number elements.
         hl_line_class: CSS class for highlighted lines.
+        line_classes: (line, classes) pairs giving extra CSS classes
+            (space-separated) for 1-based line numbers, e.g.
+            `((3, "line-added"),)`. Set by notation comments; see
+            `rosettes._notation`.
+        line_numbers: Number shown for each output line when show_linenos
+            is set (None leaves the gutter blank). Empty means 1, 2, 3, ...
+            Set for excerpts; see `rosettes._excerpt`.
+        marks: Sorted, non-overlapping (start, end) character ranges in
+            the formatted text to mark as search matches; see
+            `rosettes._search`.
+        line_data: (line, data) pairs giving coverage status, heat and
+            gutter columns for 1-based line numbers, sorted by line; see
+            `rosettes.linedata`.
     """
 
     hl_lines: frozenset[int] = frozenset()
@@ -80,3 +99,7 @@ class HighlightConfig:
     css_class: str = "highlight"
     lineno_class: str = "lineno"
     hl_line_class: str = "hll"
+    line_classes: tuple[tuple[int, str], ...] = ()
+    line_numbers: tuple[int | None, ...] = ()
+    marks: tuple[tuple[int, int], ...] = ()
+    line_data: tuple[tuple[int, LineData], ...] = ()
@@ -0,0 +1,173 @@
+"""Dedent and blank-line trimming for Rosettes.
+
+Pre-lexing pass for code extracted from an indented context, such as a
+fenced block inside a nested Markdown list or a method body:
+
+```python
+>>> block = dedent_code("\\n    def f():\\n        pass\\n\\n")
+>>> block.code
+'def f():\\n    pass\\n'
+>>> block.line_offset, block.column_offsets
+(1, (4, 4))
+```
+
+**Design Philosophy:**
+
+Indentation is measured in columns, not characters: a tab advances to
+the next multiple of `LexerConfig.tab_size`, so a block indented with a
+mix of tabs and spaces loses the same visual indent on every line. A
+tab that straddles the common indent is replaced by the spaces left
+over. Whitespace-only lines don't count towards the common indent.
+
+**Position Mapping:**
+
+`DedentedCode` records how many lines were trimmed from the top
+(`line_offset`) and how many characters were removed from the start of
+each remaining line (`column_offsets`), so positions in the dedented
+code map back to the original with `to_original()`. `tokenize()` and
+`RosettesDelegate.tokenize_range()` apply the mapping to every token;
+`highlight()` uses it to keep `hl_lines`, `lines` and line numbers on
+the original numbering.
+
+**Thread-Safety:**
+
+Pure functions; uses only local variables.
+
+**See Also:**
+
+- `rosettes.highlight`: Enabled with `dedent=` and `trim=`
+- `rosettes.delegate.RosettesDelegate`: Same options for parsers
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable, Iterator
+from dataclasses import dataclass
+
+from rosettes._config import LexerConfig
+from rosettes._types import Token
+
+__all__ = ["DedentedCode", "dedent_code", "restore_positions"]
+
+
+@dataclass(frozen=True, slots=True)
+class DedentedCode:
+    """Dedented and trimmed code with the mapping back to the original.
+
+    Attributes:
+        code: The code to lex.
+        line_offset: Number of blank lines trimmed from the top.
+        column_offsets: Characters removed from the start of each line of
+            `code`. Negative when a tab was replaced by more spaces than
+            the characters it removed.
+    """
+
+    code: str
+    line_offset: int = 0
+    column_offsets: tuple[int, ...] = ()
+
+    def to_original(self, line: int, column: int) -> tuple[int, int]:
+        """Map a 1-based (line, column) in `code` to the original code.
+
+        Example:
+            >>> dedent_code("  a\\n  b\\n").to_original(2, 1)
+            (2, 3)
+        """
+        offset = self.column_offsets[line - 1] if line <= len(self.column_offsets) else 0
+        return line + self.line_offset, max(1, column + offset)
+
+
+def _is_blank(line: str) -> bool:
+    return not line.strip(" \t\r\f")
+
+
+def _indent_width(line: str, tab_size: int) -> int:
+    width = 0
+    for char in line:
+        if char == " ":
+            width += 1
+        elif char == "\t":
+            width += tab_size - width % tab_size
+        else:
+            break
+    return width
+
+
+def _strip_indent(line: str, width: int, tab_size: int) -> tuple[str, int]:
+    """Remove `width` columns of indentation; return (line, chars removed)."""
+    column = 0
+    index = 0
+    while column < width and index < len(line):
+        char = line[index]
+        if char == " ":
+            column += 1
+        elif char == "\t":
+            column += tab_size - column % tab_size
+        else:
+            break
+        index += 1
+    # A tab that reached past the common indent leaves its remainder as spaces
+    padding = " " * max(0, column - width)
+    return padding + line[index:], index - len(padding)
+
+
+def dedent_code(
+    code: str,
+    config: LexerConfig | None = None,
+    *,
+    start: int = 0,
+    end: int | None = None,
+    dedent: bool = True,
+    trim: bool = True,
+) -> DedentedCode:
+    """Remove common indentation and surrounding blank lines.
+
+    Args:
+        code: Source string.
+        config: Lexer configuration; `tab_size` sets the tab width.
+        start: Starting index in the source string.
+        end: Optional ending index in the source string.
+        dedent: Remove the indentation shared by all non-blank lines.
+        trim: Remove whitespace-only lines at the start and end. A final
+            line break after the last kept line is preserved.
+
+    Returns:
+        DedentedCode with the new code and the position mapping.
+
+    Example:
+        >>> dedent_code("\\ta\\n\\t  b\\n", LexerConfig(tab_size=2)).code
+        'a\\n  b\\n'
+    """
+    tab_size = (config or LexerConfig()).tab_size
+    lines = code[start:end].split("\n")
+    final_newline = len(lines) > 1 and lines[-1] == ""
+    if final_newline:
+        lines.pop()
+
+    line_offset = 0
+    if trim:
+        while lines and _is_blank(lines[0]):
+            lines.pop(0)
+            line_offset += 1
+        while lines and _is_blank(lines[-1]):
+            lines.pop()
+            final_newline = True
+        final_newline = final_newline and bool(lines)
+
+    offsets = [0] * len(lines)
+    if dedent:
+        widths = [_indent_width(line, tab_size) for line in lines if not _is_blank(line)]
+        common = min(widths, default=0)
+        if common:
+            for index, line in enumerate(lines):
+                lines[index], offsets[index] = _strip_indent(line, common, tab_size)
+
+    text = "\n".join(lines) + ("\n" if final_newline else "")
+    return DedentedCode(code=text, line_offset=line_offset, column_offsets=tuple(offsets))
+
+
+def restore_positions(tokens: Iterable[Token], block: DedentedCode) -> Iterator[Token]:
+    """Map token positions from the dedented code back to the original."""
+    for token in tokens:
+        line, column = block.to_original(token.line, token.column)
+        yield token._replace(line=line, column=column)
@@ -0,0 +1,204 @@
+"""Line-range excerpts for Rosettes.
+
+Post-lexing pass that keeps only some lines of a fully lexed file:
+
+```python
+>>> html = highlight(source, "python", lines=(120, 140), show_linenos=True)
+>>> html = highlight(source, "python", lines=[(1, 5), (120, 140)])
+```
+
+**Design Philosophy:**
+
+Slicing the text before lexing loses context: an excerpt that starts
+inside a docstring or block comment is lexed as code. Lexing the whole
+file and then dropping tokens keeps every token typed as it is in the
+file. Tokens that cross a range boundary (a docstring that starts
+before line 120) are cut at line breaks, keeping their type.
+
+**Output:**
+
+Kept lines are renumbered 1, 2, 3, ... for the formatter, and
+`Excerpt.line_numbers` records each output line's real line number, so
+line numbers and `hl_lines` use the file's numbering. Between ranges
+that aren't adjacent, an elision line (`⋯` by default) is inserted as
+`GENERIC_OUTPUT` with the `line-elided` line class.
+
+**Thread-Safety:**
+
+Pure functions over token lists; uses only local variables.
+
+**See Also:**
+
+- `rosettes.highlight`: Enabled with `lines=`
+- `rosettes._config.HighlightConfig`: `line_numbers` for the gutter
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable, Sequence
+from dataclasses import dataclass, field
+
+from rosettes._types import Token, TokenType
+
+__all__ = ["ELIDED_LINE_CLASS", "Excerpt", "excerpt", "normalize_ranges"]
+
+ELIDED_LINE_CLASS = "line-elided"
+
+
+@dataclass(frozen=True, slots=True)
+class Excerpt:
+    """Tokens of the kept lines, renumbered from line 1.
+
+    Attributes:
+        tokens: Kept tokens, with positions on the output lines.
+        line_numbers: Real line number of each output line; None for
+            elision lines.
+        source_lines: Input token line of each output line; None for
+            elision lines. Differs from line_numbers when removed_lines
+            was given.
+        line_classes: `line-elided` for each elision line.
+    """
+
+    tokens: list[Token]
+    line_numbers: tuple[int | None, ...] = ()
+    source_lines: tuple[int | None, ...] = ()
+    line_classes: dict[int, str] = field(default_factory=dict)
+
+
+def normalize_ranges(
+    lines: tuple[int, int] | Sequence[tuple[int, int]],
+) -> list[tuple[int, int]]:
+    """Validate, sort and merge line ranges.
+
+    Args:
+        lines: One `(first, last)` range or a sequence of them. Ranges are
+            1-based and inclusive.
+
+    Returns:
+        Sorted ranges with overlapping and adjacent ranges merged.
+
+    Raises:
+        ValueError: If a range is empty, reversed or starts before line 1.
+
+    Example:
+        >>> normalize_ranges([(10, 12), (1, 3), (4, 5)])
+        [(1, 5), (10, 12)]
+    """
+    if len(lines) == 2 and all(isinstance(bound, int) for bound in lines):
+        ranges = [tuple(lines)]
+    else:
+        ranges = [tuple(pair) for pair in lines]
+    if not ranges:
+        raise ValueError("lines must contain at least one (first, last) range")
+
+    merged: list[tuple[int, int]] = []
+    for first, last in sorted(ranges):
+        if first < 1 or last < first:
+            raise ValueError(f"Invalid line range: ({first}, {last})")
+        if merged and first <= merged[-1][1] + 1:
+            merged[-1] = (merged[-1][0], max(merged[-1][1], last))
+        else:
+            merged.append((first, last))
+    return merged
+
+
+def _split_lines(token: Token) -> Iterable[Token]:
+    """Split a multi-line token at line breaks, keeping each break."""
+    line = token.line
+    column = token.column
+    start = 0
+    value = token.value
+    while True:
+        newline = value.find("\n", start)
+        end = len(value) if newline == -1 else newline + 1
+        if end > start:
+            yield Token(token.type, value[start:end], line, column)
+        if newline == -1:
+            return
+        start = end
+        line += 1
+        column = 1
+
+
+def excerpt(
+    tokens: Iterable[Token],
+    lines: tuple[int, int] | Sequence[tuple[int, int]],
+    *,
+    elision: str | None = "⋯",
+    removed_lines: Sequence[int] = (),
+    line_offset: int = 0,
+) -> Excerpt:
+    """Keep only the tokens on the given lines.
+
+    Args:
+        tokens: Token stream for the whole file.
+        lines: One `(first, last)` range or several; 1-based, inclusive.
+        elision: Text of the line inserted between ranges that aren't
+            adjacent, or None to join them directly.
+        removed_lines: Real line numbers already removed from the tokens
+            (notation marker lines). Token lines are mapped to real line
+            numbers by skipping them.
+        line_offset: Lines trimmed from the top of the code before lexing
+            (`trim=True`); added to token lines to get real line numbers.
+
+    Returns:
+        Excerpt with renumbered tokens and the real line numbers.
+
+    Raises:
+        ValueError: If a range is invalid.
+
+    Example:
+        >>> from rosettes import tokenize
+        >>> part = excerpt(tokenize("a = '''\\nb\\n'''\\nc = 1\\n", "python"), (2, 3))
+        >>> part.tokens[0].type.name
+        'STRING_DOC'
+        >>> part.line_numbers
+        (2, 3)
+    """
+    ranges = normalize_ranges(lines)
+    removed = sorted(removed_lines)
+    skipped = 0
+    out: list[Token] = []
+    line_numbers: list[int | None] = []
+    source_lines: list[int | None] = []
+    line_classes: dict[int, str] = {}
+
+    range_index = 0
+    first, last = ranges[0]
+    elide = False
+    column = 1
+    for token in tokens:
+        pieces = _split_lines(token) if "\n" in token.value[:-1] else (token,)
+        for piece in pieces:
+            while skipped < len(removed) and removed[skipped] <= piece.line + skipped:
+                skipped += 1
+            real_line = piece.line + skipped + line_offset
+            while real_line > last and range_index + 1 < len(ranges):
+                range_index += 1
+                first, last = ranges[range_index]
+                elide = elision is not None and bool(line_numbers)
+            if not first <= real_line <= last:
+                continue
+            if elide:
+                # Inserted once the next range has text, so a range past
+                # the end of the file leaves no trailing marker
+                elide = False
+                if not out[-1].value.endswith("\n"):
+                    out.append(Token(TokenType.WHITESPACE, "\n", len(line_numbers), column))
+                line_numbers.append(None)
+                source_lines.append(None)
+                line_classes[len(line_numbers)] = ELIDED_LINE_CLASS
+                out.append(Token(TokenType.GENERIC_OUTPUT, elision, len(line_numbers), 1))
+                out.append(Token(TokenType.WHITESPACE, "\n", len(line_numbers), len(elision) + 1))
+            if not line_numbers or line_numbers[-1] != real_line:
+                line_numbers.append(real_line)
+                source_lines.append(piece.line)
+            out.append(piece._replace(line=len(line_numbers)))
+            column = piece.column + len(piece.value)
+
+    return Excerpt(
+        tokens=out,
+        line_numbers=tuple(line_numbers),
+        source_lines=tuple(source_lines),
+        line_classes=line_classes,
+    )
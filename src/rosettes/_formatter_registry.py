@@ -15,6 +15,7 @@ Mirrors the lexer registry pattern (see `rosettes._registry`) for consistency:
 
 - `_FORMATTER_SPECS`: Static mapping of names to (module, class) specs
 - `_ALIAS_TO_NAME`: Case-insensitive alias lookup
+- `_CONFIGURED`: Instances with options from `rosettes.config`
 - `_get_formatter_by_canonical`: Cached formatter instantiation
 
 **Available Formatters:**
@@ -47,10 +48,11 @@ formatter = get_formatter("html")
 
 from __future__ import annotations
 
+from collections.abc import Mapping
 from dataclasses import dataclass
 from functools import cache
 from importlib import import_module
-from typing import TYPE_CHECKING, cast
+from typing import TYPE_CHECKING, Any, cast
 
 if TYPE_CHECKING:
     from ._protocol import Formatter
@@ -102,6 +104,9 @@ for _name, _spec in _FORMATTER_SPECS.items():
 # Pre-compute sorted list
 _SORTED_FORMATTERS: list[str] = sorted(_FORMATTER_SPECS.keys())
 
+# Instances with project-configured options (rosettes.config.configure)
+_CONFIGURED: dict[str, Formatter] = {}
+
 
 def get_formatter(name: str) -> Formatter:
     """Get a formatter instance by name or alias.
@@ -120,6 +125,9 @@ def get_formatter(name: str) -> Formatter:
         raise LookupError(f"Unknown formatter: {name!r}. Supported: {_SORTED_FORMATTERS}")
 
     canonical = _ALIAS_TO_NAME[lower]
+    configured = _CONFIGURED.get(canonical)
+    if configured is not None:
+        return configured
     return _get_formatter_by_canonical(canonical)
 
 
@@ -132,6 +140,33 @@ def _get_formatter_by_canonical(canonical: str) -> Formatter:
     return cast("Formatter", formatter_class())
 
 
+def _build_configured(options: Mapping[str, Mapping[str, Any]]) -> dict[str, Formatter]:
+    """Build formatter instances from configured keyword arguments.
+
+    Raises:
+        LookupError: If a formatter is not supported.
+        ValueError: If the options don't fit the formatter.
+    """
+    formatters: dict[str, Formatter] = {}
+    for name, kwargs in options.items():
+        if name.lower() not in _ALIAS_TO_NAME:
+            raise LookupError(f"Unknown formatter: {name!r}. Supported: {_SORTED_FORMATTERS}")
+        canonical = _ALIAS_TO_NAME[name.lower()]
+        spec = _FORMATTER_SPECS[canonical]
+        formatter_class = getattr(import_module(spec.module), spec.class_name)
+        try:
+            formatters[canonical] = cast("Formatter", formatter_class(**kwargs))
+        except TypeError as e:
+            raise ValueError(f"Invalid options for the {canonical!r} formatter: {e}") from None
+    return formatters
+
+
+def _set_configured(formatters: dict[str, Formatter]) -> None:
+    """Install instances from _build_configured()."""
+    global _CONFIGURED
+    _CONFIGURED = formatters
+
+
 def list_formatters() -> list[str]:
     """List all supported formatter names."""
     return _SORTED_FORMATTERS.copy()
@@ -0,0 +1,104 @@
+"""Shell-style glob matching without regex.
+
+Thread-safe, pure functions.
+
+**Design Philosophy:**
+
+`fnmatch` compiles patterns to regular expressions. Rosettes keeps its
+zero-regex guarantee everywhere, so filename patterns (`*.py`,
+`Dockerfile.*`, `[Mm]akefile`) are matched with a two-pointer scan that
+backtracks only to the last `*`: O(len(name) * len(pattern)) worst case.
+
+**Supported Syntax:**
+
+- `*` matches any run of characters (including none)
+- `?` matches one character
+- `[abc]`, `[a-z]`, `[!abc]` / `[^abc]` match one character from a set
+- `\\x` matches `x` literally
+
+**See Also:**
+
+- `rosettes._registry.guess_language`: Filename-based language detection
+- `rosettes.filetree`: gitignore-style excludes built on `glob_match()`
+"""
+
+from __future__ import annotations
+
+__all__ = ["glob_match"]
+
+
+def _match_one(pattern: str, p: int, char: str) -> int:
+    """Match char against the pattern element at p.
+
+    Returns:
+        Position after the element, or -1 if char doesn't match.
+    """
+    element = pattern[p]
+    if element == "?":
+        return p + 1
+    if element == "[":
+        close = pattern.find("]", p + 2)
+        if close != -1:
+            body = pattern[p + 1 : close]
+            negate = body[:1] in ("!", "^")
+            if negate:
+                body = body[1:]
+            found = False
+            i = 0
+            while i < len(body):
+                if i + 2 < len(body) and body[i + 1] == "-":
+                    found = found or body[i] <= char <= body[i + 2]
+                    i += 3
+                else:
+                    found = found or body[i] == char
+                    i += 1
+            return close + 1 if found != negate else -1
+    if element == "\\" and p + 1 < len(pattern):
+        return p + 2 if pattern[p + 1] == char else -1
+    return p + 1 if element == char else -1
+
+
+def glob_match(name: str, pattern: str) -> bool:
+    """Check whether name matches a shell-style glob pattern.
+
+    Matching is case-sensitive and `*` also matches `/`; callers match
+    one path segment at a time.
+
+    Args:
+        name: Text to test, typically a file name.
+        pattern: Glob pattern.
+
+    Returns:
+        True if the whole name matches.
+
+    Example:
+        >>> glob_match("main.py", "*.py")
+        True
+        >>> glob_match("Makefile", "[Mm]akefile")
+        True
+    """
+    n = 0
+    p = 0
+    star = -1
+    resume = 0
+    while n < len(name):
+        if p < len(pattern) and pattern[p] == "*":
+            star = p
+            resume = n
+            p += 1
+            continue
+        if p < len(pattern):
+            next_p = _match_one(pattern, p, name[n])
+            if next_p != -1:
+                p = next_p
+                n += 1
+                continue
+        if star == -1:
+            return False
+        # Let the last * absorb one more character and retry
+        resume += 1
+        n = resume
+        p = star + 1
+    while p < len(pattern) and pattern[p] == "*":
+        p += 1
+    return p == len(pattern)
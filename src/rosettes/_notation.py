@@ -0,0 +1,248 @@
+"""Notation comments for Rosettes.
+
+Opt-in pass that reads line markers written as comments in the source,
+removes them, and reports which lines they mark:
+
+```python
+x = compute()  # [!code highlight]
+y = old()      # [!code --]
+y = new()      # [!code ++]
+```
+
+**Markers:**
+
+- `[!code highlight]` (or `hl`): highlighted line (`hl_lines`)
+- `[!code ++]` / `[!code --]`: added / removed line
+- `[!code focus]`: focused line; every other line is dimmed
+- `[!code error]` / `[!code warning]`: error / warning line
+
+`[!code ++:3]` marks the marker's line and the two lines after it.
+
+**Placement:**
+
+Markers are found in comment tokens, so each lexer's own comment syntax
+works (`//`, `#`, `--`, `/* */`, `<!-- -->`). A marker after code marks
+that line. A marker alone on its line marks the next line, and the
+marker line is removed. A comment left empty by removing its markers is
+dropped with the whitespace before it; other comment text is kept.
+
+**Line Classes:**
+
+| Marker | HTML class | Terminal |
+|--------|------------|----------|
+| `highlight` | `hl_line_class` (`hll`) | gray background |
+| `++` | `line-added` | green background |
+| `--` | `line-removed` | red background |
+| `focus` / others | `line-focus` / `line-dimmed` | dim |
+| `error` | `line-error` | red background |
+| `warning` | `line-warning` | yellow background |
+
+**Thread-Safety:**
+
+Pure function over a token list; uses only local variables.
+
+**See Also:**
+
+- `rosettes.highlight`: Enabled with `notation=True`
+- `rosettes._config.HighlightConfig`: `hl_lines` and `line_classes`
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable
+from dataclasses import dataclass, field
+
+from rosettes._types import Token, TokenType
+
+__all__ = ["LINE_CLASSES", "Notation", "apply_notation"]
+
+_MARKER_OPEN = "[!code "
+
+# Directive → line class (None for hl_lines)
+LINE_CLASSES: dict[str, str | None] = {
+    "highlight": None,
+    "hl": None,
+    "++": "line-added",
+    "--": "line-removed",
+    "focus": "line-focus",
+    "error": "line-error",
+    "warning": "line-warning",
+}
+
+_DIMMED_CLASS = "line-dimmed"
+
+_COMMENT_TYPES = frozenset(
+    {
+        TokenType.COMMENT,
+        TokenType.COMMENT_DOC,
+        TokenType.COMMENT_MULTILINE,
+        TokenType.COMMENT_SINGLE,
+        TokenType.COMMENT_SPECIAL,
+    }
+)
+
+
+@dataclass(frozen=True, slots=True)
+class Notation:
+    """Tokens with markers removed, and the lines the markers marked.
+
+    Attributes:
+        tokens: Tokens with positions recomputed after marker removal.
+        hl_lines: Lines marked `[!code highlight]`.
+        line_classes: Space-separated CSS classes for each marked line,
+            including `line-dimmed` for unfocused lines.
+        removed_lines: Input line numbers of marker lines that were
+            removed.
+    """
+
+    tokens: list[Token]
+    hl_lines: frozenset[int] = frozenset()
+    line_classes: dict[int, str] = field(default_factory=dict)
+    removed_lines: tuple[int, ...] = ()
+
+
+def _parse_markers(value: str) -> tuple[str, list[tuple[int, str, int]]]:
+    """Remove recognized markers from comment text.
+
+    Returns:
+        Tuple of (remaining text, [(offset, directive, line count)]), where
+        offset is the marker's position in the original value.
+    """
+    markers = []
+    pieces = []
+    pos = 0
+    while True:
+        start = value.find(_MARKER_OPEN, pos)
+        if start == -1:
+            break
+        close = value.find("]", start)
+        if close == -1:
+            break
+        directive, _, count = value[start + len(_MARKER_OPEN) : close].strip().partition(":")
+        if directive not in LINE_CLASSES or (count and not count.isdigit()):
+            pieces.append(value[pos : close + 1])
+            pos = close + 1
+            continue
+        markers.append((start, directive, max(int(count or 1), 1)))
+        # Take one space before the marker with it
+        cut = start - 1 if start > pos and value[start - 1] == " " else start
+        pieces.append(value[pos:cut])
+        pos = close + 1
+    pieces.append(value[pos:])
+    return "".join(pieces), markers
+
+
+def _is_blank(token: Token) -> bool:
+    """Check for whitespace on a single line."""
+    return token.type == TokenType.WHITESPACE and "\n" not in token.value
+
+
+def _reposition(tokens: list[Token], line: int, column: int) -> list[Token]:
+    """Recompute line and column of each token from the token text."""
+    result = []
+    for token in tokens:
+        result.append(Token(token.type, token.value, line, column))
+        value = token.value
+        newline = value.rfind("\n")
+        if newline == -1:
+            column += len(value)
+        else:
+            line += value.count("\n")
+            column = len(value) - newline
+    return result
+
+
+def apply_notation(tokens: Iterable[Token]) -> Notation:
+    """Remove `[!code ...]` markers and collect the lines they mark.
+
+    Args:
+        tokens: Token stream from a lexer.
+
+    Returns:
+        Notation with the rewritten tokens and the marked lines. Line
+        numbers refer to the rewritten tokens.
+
+    Example:
+        >>> from rosettes import tokenize
+        >>> notation = apply_notation(tokenize("a = 1  # [!code ++]\\n", "python"))
+        >>> "".join(t.value for t in notation.tokens)
+        'a = 1\\n'
+        >>> notation.line_classes
+        {1: 'line-added'}
+    """
+    source = list(tokens)
+    if not source:
+        return Notation(tokens=[])
+
+    out: list[Token] = []
+    line = source[0].line  # line at the end of `out`
+    marks: list[tuple[int, str, int]] = []
+    removed: list[int] = []
+
+    index = 0
+    while index < len(source):
+        token = source[index]
+        index += 1
+        if token.type not in _COMMENT_TYPES or _MARKER_OPEN not in token.value:
+            out.append(token)
+            line += token.value.count("\n")
+            continue
+        text, markers = _parse_markers(token.value)
+        if not markers:
+            out.append(token)
+            line += token.value.count("\n")
+            continue
+
+        if "\n" in text or any(char.isalnum() for char in text):
+            # Comment keeps some text: remove only the markers
+            for offset, directive, count in markers:
+                marks.append((line + token.value.count("\n", 0, offset), directive, count))
+            out.append(token._replace(value=text))
+            line += text.count("\n")
+            continue
+
+        # The comment is only markers: drop it and the spaces before it
+        while out and _is_blank(out[-1]):
+            out.pop()
+        previous = out[-1].value if out else "\n"
+        following = source[index].value if index < len(source) else "\n"
+        own_line = previous.rstrip(" \t").endswith("\n") and following.startswith(("\n", "\r\n"))
+        if own_line:
+            # Remove the marker line; its markers apply to the next line
+            removed.append(line + len(removed))
+            if out and out[-1].type == TokenType.WHITESPACE:
+                out[-1] = out[-1]._replace(value=previous.rstrip(" \t"))
+            if index < len(source):
+                rest = following[2:] if following.startswith("\r\n") else following[1:]
+                if rest:
+                    source[index] = source[index]._replace(value=rest)
+                else:
+                    index += 1
+        for _, directive, count in markers:
+            marks.append((line, directive, count))
+
+    tokens_out = _reposition(out, source[0].line, source[0].column)
+
+    hl_lines = set()
+    classes: dict[int, list[str]] = {}
+    for mark_line, directive, count in marks:
+        line_class = LINE_CLASSES[directive]
+        for marked in range(mark_line, mark_line + count):
+            if line_class is None:
+                hl_lines.add(marked)
+            elif line_class not in classes.setdefault(marked, []):
+                classes[marked].append(line_class)
+
+    if any("line-focus" in names for names in classes.values()):
+        last_line = line - 1 if tokens_out and tokens_out[-1].value.endswith("\n") else line
+        for number in range(source[0].line, last_line + 1):
+            names = classes.setdefault(number, [])
+            if "line-focus" not in names:
+                names.append(_DIMMED_CLASS)
+
+    return Notation(
+        tokens=tokens_out,
+        hl_lines=frozenset(hl_lines),
+        line_classes={number: " ".join(names) for number, names in sorted(classes.items())},
+        removed_lines=tuple(removed),
+    )
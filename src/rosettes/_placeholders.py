@@ -0,0 +1,522 @@
+"""Format-string placeholder highlighting for Rosettes.
+
+Opt-in pass that marks format placeholders inside string literals as
+`STRING_INTERPOL` tokens: printf-style `%5.2f`, Python `{name!r:>10}`,
+Rust `{:?}`, Go `%v`, and .NET-style `{0:N2}`.
+
+**Design Philosophy:**
+
+A string only gets placeholder highlighting when its context says it is
+a format string. `"100%"` on its own is plain text; `printf("100%d")`
+is not. Context rules are declared per language as data
+(`PlaceholderRules`), so adding a language is a table entry, not code:
+
+- **Calls**: the format argument of `printf(...)`, `fmt.Fprintf(w, ...)`,
+  `println!(...)`, or a bare `printf "..."` in shells; other arguments
+  stay plain
+- **Methods**: a string receiver of `.format(...)` or `.formatted(...)`
+- **Operator**: a string left operand of `%` (Python, Ruby)
+- **Prefixes**: Python f-strings (`f"{x!r}"`)
+
+Doubled braces (`{{`, `}}`) and `%%` are literal characters and become
+`STRING_ESCAPE`. A printf conversion must end in one of the language's
+conversion characters, so `"50% true"` stays plain text.
+
+**Performance:**
+
+One linear scan over the token list to find format strings, then one
+linear scan over each format string's text. Strings outside a format
+context are never scanned.
+
+**Thread-Safety:**
+
+Rules are frozen dataclasses; the pass uses only local variables.
+
+**See Also:**
+
+- `rosettes._spans`: Position-preserving token splitting
+- `rosettes.highlight`: Enabled with `format_placeholders=True`
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable
+from dataclasses import dataclass
+from typing import Literal
+
+from rosettes._spans import split_token
+from rosettes._types import Token, TokenType
+
+__all__ = [
+    "PlaceholderRules",
+    "apply_placeholders",
+    "get_placeholder_rules",
+    "scan_placeholders",
+]
+
+PlaceholderStyle = Literal["printf", "brace"]
+
+_EMPTY: frozenset[str] = frozenset()
+
+# C printf length modifiers and conversions; the default for other languages
+_C_LENGTHS: frozenset[str] = frozenset("hlLqjzt")
+_C_CONVERSIONS: frozenset[str] = frozenset("diouxXeEfFgGaAcCsSpn")
+
+
+@dataclass(frozen=True, slots=True)
+class PlaceholderRules:
+    """Contexts in which a language's string literals are format strings.
+
+    Attributes:
+        printf_calls: Names whose format argument uses printf syntax.
+        brace_calls: Names whose format argument uses brace syntax.
+        call_positions: Calls whose format argument isn't the first one,
+            as (name, 0-based position) pairs (e.g., `("fprintf", 1)`).
+        printf_methods: Methods that make their string receiver printf-style.
+        brace_methods: Methods that make their string receiver brace-style.
+        printf_operator: Whether `"..." % args` is printf formatting.
+        brace_prefixes: String prefix characters that mark brace-style
+            literals (e.g., `f` for Python f-strings).
+        printf_lengths: Length modifier characters (`l`, `h`, ...).
+        printf_conversions: Characters that end a printf conversion
+            (`d`, `s`, Go's `v`, Python's `r`, ...).
+    """
+
+    printf_calls: frozenset[str] = _EMPTY
+    brace_calls: frozenset[str] = _EMPTY
+    call_positions: tuple[tuple[str, int], ...] = ()
+    printf_methods: frozenset[str] = _EMPTY
+    brace_methods: frozenset[str] = _EMPTY
+    printf_operator: bool = False
+    brace_prefixes: frozenset[str] = _EMPTY
+    printf_lengths: frozenset[str] = _C_LENGTHS
+    printf_conversions: frozenset[str] = _C_CONVERSIONS
+
+
+_C_PRINTF: frozenset[str] = frozenset(
+    {
+        "printf",
+        "fprintf",
+        "sprintf",
+        "snprintf",
+        "dprintf",
+        "asprintf",
+        "vprintf",
+        "vfprintf",
+        "vsprintf",
+        "vsnprintf",
+        "wprintf",
+        "fwprintf",
+        "swprintf",
+        "scanf",
+        "fscanf",
+        "sscanf",
+        "printk",
+    }
+)
+
+_C_POSITIONS: tuple[tuple[str, int], ...] = (
+    ("fprintf", 1),
+    ("sprintf", 1),
+    ("snprintf", 2),
+    ("dprintf", 1),
+    ("asprintf", 1),
+    ("vfprintf", 1),
+    ("vsprintf", 1),
+    ("vsnprintf", 2),
+    ("fwprintf", 1),
+    ("swprintf", 2),
+    ("fscanf", 1),
+    ("sscanf", 1),
+)
+
+_PYTHON = PlaceholderRules(
+    brace_methods=frozenset({"format", "format_map"}),
+    printf_operator=True,
+    brace_prefixes=frozenset("fF"),
+    printf_lengths=frozenset("hlL"),
+    printf_conversions=frozenset("diouxXeEfFgGcrsa"),
+)
+
+_JVM = PlaceholderRules(
+    printf_calls=frozenset({"format", "printf"}),
+    printf_methods=frozenset({"format", "formatted"}),
+    printf_lengths=_EMPTY,
+    printf_conversions=frozenset("bBhHsScCdoxXeEfgGaAtTn"),
+)
+
+_RULES: dict[str, PlaceholderRules] = {
+    "python": _PYTHON,
+    "mojo": _PYTHON,
+    "rust": PlaceholderRules(
+        brace_calls=frozenset(
+            {
+                "format!",
+                "print!",
+                "println!",
+                "eprint!",
+                "eprintln!",
+                "write!",
+                "writeln!",
+                "format_args!",
+                "panic!",
+                "todo!",
+                "unimplemented!",
+                "unreachable!",
+            }
+        ),
+        call_positions=(("write!", 1), ("writeln!", 1)),
+    ),
+    "go": PlaceholderRules(
+        printf_calls=frozenset(
+            {
+                "Printf",
+                "Sprintf",
+                "Fprintf",
+                "Errorf",
+                "Fatalf",
+                "Panicf",
+                "Logf",
+                "Skipf",
+                "Scanf",
+                "Sscanf",
+                "Fscanf",
+                "Appendf",
+            }
+        ),
+        call_positions=(("Fprintf", 1), ("Fscanf", 1), ("Sscanf", 1), ("Appendf", 1)),
+        printf_lengths=_EMPTY,
+        printf_conversions=frozenset("vTtbcdoOqxXUeEfFgGspw"),
+    ),
+    "c": PlaceholderRules(printf_calls=_C_PRINTF, call_positions=_C_POSITIONS),
+    "cuda": PlaceholderRules(printf_calls=_C_PRINTF, call_positions=_C_POSITIONS),
+    "cpp": PlaceholderRules(
+        printf_calls=_C_PRINTF,
+        call_positions=_C_POSITIONS,
+        brace_calls=frozenset({"format", "print", "println", "vformat"}),
+    ),
+    "java": _JVM,
+    "kotlin": _JVM,
+    "scala": _JVM,
+    "groovy": _JVM,
+    "bash": PlaceholderRules(
+        printf_calls=frozenset({"printf"}),
+        printf_lengths=_EMPTY,
+        printf_conversions=frozenset("diouxXeEfFgGaAcsbq"),
+    ),
+    "perl": PlaceholderRules(printf_calls=frozenset({"printf", "sprintf"})),
+    "php": PlaceholderRules(
+        printf_calls=frozenset({"printf", "sprintf", "fprintf", "vprintf", "vsprintf"}),
+        call_positions=(("fprintf", 1),),
+    ),
+    "ruby": PlaceholderRules(
+        printf_calls=frozenset({"format", "sprintf", "printf"}),
+        printf_operator=True,
+        printf_lengths=_EMPTY,
+        printf_conversions=frozenset("bBdiouxXeEfgGaAcps"),
+    ),
+    "lua": PlaceholderRules(
+        printf_calls=frozenset({"format"}),
+        printf_lengths=_EMPTY,
+        printf_conversions=frozenset("diouxXeEfgGaAcsq"),
+    ),
+    "haskell": PlaceholderRules(
+        printf_calls=frozenset({"printf", "hPrintf"}),
+        printf_lengths=frozenset("hlL"),
+        printf_conversions=frozenset("cdoxXbufFgGeEsv"),
+    ),
+    "r": PlaceholderRules(printf_calls=frozenset({"sprintf", "gettextf"})),
+    "julia": PlaceholderRules(printf_calls=frozenset({"@printf", "@sprintf"})),
+    "zig": PlaceholderRules(
+        brace_calls=frozenset({"print", "format", "bufPrint", "allocPrint", "panic"}),
+        call_positions=(("format", 1), ("bufPrint", 1), ("allocPrint", 1)),
+    ),
+}
+
+# Tokens that make up one string literal (escapes were split out by the lexer)
+_STRING_PARTS: frozenset[TokenType] = frozenset(
+    {TokenType.STRING, TokenType.STRING_DOC, TokenType.STRING_ESCAPE}
+)
+
+_SKIPPED: frozenset[TokenType] = frozenset(
+    {
+        TokenType.WHITESPACE,
+        TokenType.COMMENT,
+        TokenType.COMMENT_SINGLE,
+        TokenType.COMMENT_MULTILINE,
+    }
+)
+
+# printf grammar: %[(key)][[n]][flags][width][.precision][length]conversion
+_PRINTF_FLAGS: frozenset[str] = frozenset("-+ #0'")
+_PRINTF_DIGITS: frozenset[str] = frozenset("0123456789*")
+
+# C syntax, for scan_placeholders() without rules
+_C_RULES = PlaceholderRules()
+
+
+def get_placeholder_rules(language: str) -> PlaceholderRules | None:
+    """Return placeholder rules for a canonical language name, if any."""
+    return _RULES.get(language)
+
+
+def apply_placeholders(tokens: Iterable[Token], language: str) -> list[Token]:
+    """Mark format placeholders inside format-string literals.
+
+    Args:
+        tokens: Tokens from a lexer for `language`.
+        language: Canonical language name (e.g., 'python').
+
+    Returns:
+        Tokens with placeholders split out as STRING_INTERPOL (and doubled
+        braces as STRING_ESCAPE). Unchanged if the language has no rules.
+    """
+    token_list = tokens if isinstance(tokens, list) else list(tokens)
+    rules = _RULES.get(language)
+    if rules is None:
+        return token_list
+
+    result: list[Token] = []
+    armed: PlaceholderStyle | None = None  # Style of the call's format argument
+    depth = 0  # Paren depth since the call name was seen
+    skip = 0  # Arguments before the format argument
+    i = 0
+    count = len(token_list)
+
+    while i < count:
+        token = token_list[i]
+        token_type = token.type
+
+        if token_type in _STRING_PARTS:
+            # Collect the whole literal
+            j = i + 1
+            while j < count and token_list[j].type in _STRING_PARTS:
+                j += 1
+
+            if armed and skip:
+                style = None  # An argument before the format string
+            else:
+                style = armed or _receiver_style(token_list, i, j, rules)
+                armed = None
+            if style is None:
+                result.extend(token_list[i:j])
+            else:
+                for part in token_list[i:j]:
+                    if part.type == TokenType.STRING_ESCAPE:
+                        result.append(part)
+                    else:
+                        spans = scan_placeholders(part.value, style, rules)
+                        if spans:
+                            result.extend(split_token(part, spans))
+                        else:
+                            result.append(part)
+            i = j
+            continue
+
+        result.append(token)
+        i += 1
+
+        if token_type in _SKIPPED:
+            if armed and depth == 0 and "\n" in token.value:
+                armed = None  # Bare call (`printf "..."`) ended at newline
+            continue
+
+        value = token.value
+        if value in rules.printf_calls:
+            armed, depth, skip = "printf", 0, _call_position(rules, value)
+        elif value in rules.brace_calls:
+            armed, depth, skip = "brace", 0, _call_position(rules, value)
+        elif armed:
+            if value == "(":
+                depth += 1
+            elif value == ")":
+                depth -= 1
+                if depth <= 0:
+                    armed = None
+            elif value == "," and depth <= 1:
+                if skip:
+                    skip -= 1
+                else:
+                    armed = None  # The format argument wasn't a literal
+            elif value in (";", "{", "}"):
+                armed = None
+
+    return result
+
+
+def _call_position(rules: PlaceholderRules, name: str) -> int:
+    """Position of a call's format argument."""
+    for call, position in rules.call_positions:
+        if call == name:
+            return position
+    return 0
+
+
+def _receiver_style(
+    tokens: list[Token],
+    start: int,
+    end: int,
+    rules: PlaceholderRules,
+) -> PlaceholderStyle | None:
+    """Style of a literal from its prefix or the tokens that follow it."""
+    if rules.brace_prefixes:
+        first = tokens[start].value
+        for char in first:
+            if char in "\"'":
+                break
+            if char in rules.brace_prefixes:
+                return "brace"
+
+    # Next significant token(s)
+    k = end
+    while k < len(tokens) and tokens[k].type in _SKIPPED:
+        k += 1
+    if k >= len(tokens):
+        return None
+
+    value = tokens[k].value
+    if value == "%" and rules.printf_operator:
+        return "printf"
+    if value == "." and k + 1 < len(tokens):
+        method = tokens[k + 1].value
+        if method in rules.brace_methods:
+            return "brace"
+        if method in rules.printf_methods:
+            return "printf"
+    return None
+
+
+def scan_placeholders(
+    text: str,
+    style: PlaceholderStyle,
+    rules: PlaceholderRules | None = None,
+) -> list[tuple[int, int, TokenType]]:
+    """Find placeholder spans in string literal text.
+
+    Args:
+        text: Raw text of a string token (quotes included).
+        style: "printf" for `%...` conversions, "brace" for `{...}` fields.
+        rules: Rules giving the printf length modifiers and conversions.
+            Defaults to C's.
+
+    Returns:
+        Sorted `(start, end, type)` spans.
+    """
+    if style == "printf":
+        return _scan_printf(text, rules or _C_RULES)
+    return _scan_brace(text)
+
+
+def _scan_printf(text: str, rules: PlaceholderRules) -> list[tuple[int, int, TokenType]]:
+    """Scan `%` conversions (C, Go, Python %-formatting, Ruby, etc.)."""
+    spans: list[tuple[int, int, TokenType]] = []
+    length = len(text)
+    pos = text.find("%")
+
+    while pos != -1:
+        if pos + 1 < length and text[pos + 1] == "%":
+            spans.append((pos, pos + 2, TokenType.STRING_ESCAPE))
+            pos = text.find("%", pos + 2)
+            continue
+        end = _printf_end(text, pos + 1, length, rules)
+        if end:
+            spans.append((pos, end, TokenType.STRING_INTERPOL))
+            pos = text.find("%", end)
+        else:
+            pos = text.find("%", pos + 1)
+
+    return spans
+
+
+def _printf_end(text: str, pos: int, length: int, rules: PlaceholderRules) -> int:
+    """Return the end of a conversion starting after `%`, or 0 if invalid."""
+    conversions = rules.printf_conversions
+    if pos >= length:
+        return 0
+
+    # Mapping key: %(name)s
+    if text[pos] == "(":
+        close = text.find(")", pos, min(length, pos + 64))
+        if close == -1 or "\n" in text[pos:close]:
+            return 0
+        pos = close + 1
+    # Explicit argument index: %[1]d (Go)
+    elif text[pos] == "[":
+        close = text.find("]", pos, min(length, pos + 8))
+        if close == -1:
+            return 0
+        pos = close + 1
+
+    while pos < length and text[pos] in _PRINTF_FLAGS:
+        pos += 1
+    while pos < length and text[pos] in _PRINTF_DIGITS:
+        pos += 1
+    if pos < length and text[pos] == ".":
+        pos += 1
+        while pos < length and text[pos] in _PRINTF_DIGITS:
+            pos += 1
+
+    # Length modifiers (hh, l, ll, z, ...) only count if a conversion follows
+    scan = pos
+    while scan < length and scan - pos < 2 and text[scan] in rules.printf_lengths:
+        scan += 1
+    if scan > pos and scan < length and text[scan] in conversions:
+        return scan + 1
+
+    if pos < length and text[pos] in conversions:
+        return pos + 1
+    return 0
+
+
+def _scan_brace(text: str) -> list[tuple[int, int, TokenType]]:
+    """Scan `{...}` replacement fields (Python, Rust, C++20, .NET, Zig)."""
+    spans: list[tuple[int, int, TokenType]] = []
+    length = len(text)
+    pos = 0
+
+    while pos < length:
+        char = text[pos]
+
+        if char == "}":
+            if pos + 1 < length and text[pos + 1] == "}":
+                spans.append((pos, pos + 2, TokenType.STRING_ESCAPE))
+                pos += 2
+            else:
+                pos += 1
+            continue
+
+        if char != "{":
+            pos += 1
+            continue
+
+        if pos + 1 < length and text[pos + 1] == "{":
+            spans.append((pos, pos + 2, TokenType.STRING_ESCAPE))
+            pos += 2
+            continue
+
+        # Replacement field; the format spec may nest one level: {x:{width}}
+        scan = pos + 1
+        nested = False
+        while scan < length:
+            c = text[scan]
+            if c == "}":
+                if not nested:
+                    break
+                nested = False
+            elif c == "{":
+                if nested:
+                    break  # Too deep: not a field
+                nested = True
+            elif c == "\n":
+                break
+            scan += 1
+
+        if scan < length and text[scan] == "}":
+            spans.append((pos, scan + 1, TokenType.STRING_INTERPOL))
+            pos = scan + 1
+        else:
+            # Resume at the character that ended the scan; nothing before it
+            # can start a field.
+            pos = max(scan, pos + 1)
+
+    return spans
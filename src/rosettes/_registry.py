@@ -19,6 +19,7 @@ runtime performance:
 - `_LEXER_SPECS`: Static registry mapping names to (module, class) specs
 - `_ALIAS_TO_NAME`: Pre-computed case-insensitive alias lookup table
 - `_get_lexer_by_canonical`: Cached lexer instantiation (one per language)
+- `_EXTRA_ALIASES`, `_FALLBACK`: Project configuration (`rosettes.config`)
 
 **Performance Notes:**
 
@@ -46,6 +47,9 @@ except LookupError:
 # ✅ CORRECT: Use supports_language() for checks
 if supports_language(lang):
     lexer = get_lexer(lang)
+
+# ✅ CORRECT: Or pick a fallback policy
+lexer = get_lexer(lang, fallback="plaintext")
 ```
 
 **Adding New Languages:**
@@ -63,15 +67,25 @@ the base class and helper functions.
 
 from __future__ import annotations
 
+from collections.abc import Mapping
 from dataclasses import dataclass
 from functools import cache
 from importlib import import_module
-from typing import TYPE_CHECKING, cast
+from typing import TYPE_CHECKING, Literal, cast
+
+from rosettes._glob import glob_match
 
 if TYPE_CHECKING:
     from .lexers._state_machine import StateMachineLexer
 
-__all__ = ["get_lexer", "list_languages", "supports_language"]
+__all__ = [
+    "FallbackPolicy",
+    "UnknownLanguageError",
+    "get_lexer",
+    "guess_language",
+    "list_languages",
+    "supports_language",
+]
 
 
 @dataclass(frozen=True, slots=True)
@@ -151,6 +165,26 @@ _LEXER_SPECS: dict[str, LexerSpec] = {
         "CssStateMachineLexer",
         aliases=(),
     ),
+    "scss": LexerSpec(
+        "rosettes.lexers.scss_sm",
+        "ScssStateMachineLexer",
+        aliases=(),
+    ),
+    "sass": LexerSpec(
+        "rosettes.lexers.sass_sm",
+        "SassStateMachineLexer",
+        aliases=(),
+    ),
+    "less": LexerSpec(
+        "rosettes.lexers.less_sm",
+        "LessStateMachineLexer",
+        aliases=(),
+    ),
+    "stylus": LexerSpec(
+        "rosettes.lexers.stylus_sm",
+        "StylusStateMachineLexer",
+        aliases=("styl",),
+    ),
     "diff": LexerSpec(
         "rosettes.lexers.diff_sm",
         "DiffStateMachineLexer",
@@ -379,6 +413,11 @@ _LEXER_SPECS: dict[str, LexerSpec] = {
         "TreeStateMachineLexer",
         aliases=("directory", "filetree", "dirtree", "files", "scm", "treesitter"),
     ),
+    "tree-listing": LexerSpec(
+        "rosettes.lexers.dirtree_sm",
+        "DirectoryTreeStateMachineLexer",
+        aliases=("dir-listing", "tree-output"),
+    ),
     # Template languages
     "kida": LexerSpec(
         "rosettes.lexers.kida_sm",
@@ -411,9 +450,100 @@ for _name, _spec in _LEXER_SPECS.items():
 _SORTED_LANGUAGES: list[str] = sorted(_LEXER_SPECS.keys())
 
 
+FallbackPolicy = Literal["raise", "plaintext", "guess"]
+_FALLBACK_POLICIES = frozenset({"raise", "plaintext", "guess"})
+
+# Project configuration (rosettes.config.configure); replaced, never mutated
+_EXTRA_ALIASES: dict[str, str] = {}
+_FALLBACK: str | None = None
+
+
+class UnknownLanguageError(LookupError):
+    """A language name or alias that isn't registered.
+
+    Attributes:
+        name: The name that was looked up.
+        suggestions: Close names and aliases, best match first.
+
+    Example:
+        >>> try:
+        ...     get_lexer("typscript")
+        ... except UnknownLanguageError as e:
+        ...     print(e)
+        Unknown language: 'typscript'. Did you mean 'typescript'?
+    """
+
+    def __init__(self, name: str, suggestions: tuple[str, ...] = ()) -> None:
+        self.name = name
+        self.suggestions = suggestions
+        if suggestions:
+            hint = f"Did you mean {' or '.join(repr(s) for s in suggestions)}?"
+        else:
+            hint = "See list_languages() for supported names."
+        super().__init__(f"Unknown language: {name!r}. {hint}")
+
+
+def _edit_distance(a: str, b: str) -> int:
+    """Edits (insert, delete, substitute, swap adjacent) to turn a into b."""
+    before_previous: list[int] = []
+    previous = list(range(len(b) + 1))
+    for i, char_a in enumerate(a, 1):
+        current = [i]
+        for j, char_b in enumerate(b, 1):
+            substitute = previous[j - 1] + (char_a != char_b)
+            distance = min(previous[j] + 1, current[j - 1] + 1, substitute)
+            if i > 1 and j > 1 and char_a == b[j - 2] and a[i - 2] == char_b:
+                distance = min(distance, before_previous[j - 2] + 1)
+            current.append(distance)
+        before_previous, previous = previous, current
+    return previous[-1]
+
+
+def _suggest(name: str, limit: int = 3) -> tuple[str, ...]:
+    """Close names and aliases, at most one per language.
+
+    Allows one edit per three characters (at least one).
+    """
+    lower = name.lower()
+    if not lower:
+        return ()
+    max_distance = max(1, len(lower) // 3)
+    scored: list[tuple[int, str, str]] = []
+    for candidates in (_ALIAS_TO_NAME, _EXTRA_ALIASES):
+        for candidate, canonical in candidates.items():
+            # Skip the pre-computed uppercase entries
+            if candidate == candidate.lower():
+                distance = _edit_distance(lower, candidate)
+                if distance <= max_distance:
+                    scored.append((distance, candidate, canonical))
+    suggestions: dict[str, str] = {}
+    for _, candidate, canonical in sorted(scored):
+        suggestions.setdefault(canonical, candidate)
+    return tuple(suggestions.values())[:limit]
+
+
+def _builtin_name(name: str) -> str:
+    """Resolve a built-in language name or alias, ignoring configured aliases.
+
+    Raises:
+        UnknownLanguageError: If the language is not supported.
+    """
+    # Try direct lookup first (common case: already lowercase)
+    if name in _ALIAS_TO_NAME:
+        return _ALIAS_TO_NAME[name]
+    # Try lowercase (avoid strip - rarely needed)
+    lower = name.lower()
+    if lower in _ALIAS_TO_NAME:
+        return _ALIAS_TO_NAME[lower]
+
+    raise UnknownLanguageError(name, _suggest(name))
+
+
 def _normalize_name(name: str) -> str:
     """Normalize a language name to its canonical form. O(1) lookup.
 
+    Built-in names and aliases come first, then configured aliases.
+
     Args:
         name: Language name or alias.
 
@@ -421,20 +551,38 @@ def _normalize_name(name: str) -> str:
         Canonical language name.
 
     Raises:
-        LookupError: If the language is not supported.
+        UnknownLanguageError: If the language is not supported.
     """
-    # Try direct lookup first (common case: already lowercase)
     if name in _ALIAS_TO_NAME:
         return _ALIAS_TO_NAME[name]
-    # Try lowercase (avoid strip - rarely needed)
     lower = name.lower()
-    if lower in _ALIAS_TO_NAME:
-        return _ALIAS_TO_NAME[lower]
+    canonical = _ALIAS_TO_NAME.get(lower) or _EXTRA_ALIASES.get(lower)
+    if canonical is None:
+        raise UnknownLanguageError(name, _suggest(name))
+    return canonical
+
 
-    raise LookupError(f"Unknown language: {name!r}. Supported: {_SORTED_LANGUAGES}")
+def _resolve_configured(
+    aliases: Mapping[str, str], fallback: str | None
+) -> tuple[dict[str, str], str | None]:
+    """Resolve configured aliases and fallback to canonical names.
 
+    Raises:
+        UnknownLanguageError: If a target or the fallback is not a built-in
+            language.
+    """
+    resolved = {alias.lower(): _builtin_name(target) for alias, target in aliases.items()}
+    return resolved, _builtin_name(fallback) if fallback is not None else None
+
+
+def _set_configured(aliases: dict[str, str], fallback: str | None) -> None:
+    """Install aliases and fallback from _resolve_configured()."""
+    global _EXTRA_ALIASES, _FALLBACK
+    _EXTRA_ALIASES = aliases
+    _FALLBACK = fallback
 
-def get_lexer(name: str) -> StateMachineLexer:
+
+def get_lexer(name: str, *, fallback: FallbackPolicy | None = None) -> StateMachineLexer:
     """Get a lexer instance by name or alias.
 
     All lexers are hand-written state machines with O(n) guaranteed
@@ -445,12 +593,22 @@ def get_lexer(name: str) -> StateMachineLexer:
 
     Args:
         name: Language name or alias (e.g., 'python', 'py', 'js').
+        fallback: What to do with an unknown name. None (default) uses
+            the configured fallback language (see `rosettes.config`) if
+            there is one, else "raise":
+            - "raise": Raise UnknownLanguageError
+            - "plaintext": Use the plaintext lexer
+            - "guess": Use guess_language() on the name (so "app.tsx" or
+              "Dockerfile" work), then the closest suggestion, then
+              plaintext
 
     Returns:
         StateMachineLexer instance.
 
     Raises:
-        LookupError: If the language is not supported.
+        UnknownLanguageError: If the language is not supported and
+            fallback is "raise" (or None without a configured fallback).
+        ValueError: If fallback is not a known policy.
 
     Example:
         >>> lexer = get_lexer("python")
@@ -458,8 +616,27 @@ def get_lexer(name: str) -> StateMachineLexer:
         'python'
         >>> get_lexer("py") is lexer  # Same instance (cached)
         True
+        >>> get_lexer("pyhton", fallback="guess").name
+        'python'
     """
-    canonical = _normalize_name(name)
+    if fallback is not None and fallback not in _FALLBACK_POLICIES:
+        raise ValueError(
+            f"Unknown fallback policy: {fallback!r}. Expected one of {sorted(_FALLBACK_POLICIES)}"
+        )
+    try:
+        canonical = _normalize_name(name)
+    except UnknownLanguageError as e:
+        if fallback is None and _FALLBACK is not None:
+            canonical = _FALLBACK
+        elif fallback == "plaintext":
+            canonical = "plaintext"
+        elif fallback == "guess":
+            guessed = guess_language(name)
+            if guessed is None and e.suggestions:
+                guessed = _normalize_name(e.suggestions[0])
+            canonical = guessed or "plaintext"
+        else:
+            raise
     return _get_lexer_by_canonical(canonical)
 
 
@@ -488,10 +665,53 @@ def supports_language(name: str) -> bool:
         name: Language name or alias.
 
     Returns:
-        True if the language is supported.
+        True if the language is supported. Configured aliases count; a
+        configured fallback doesn't.
     """
     # Fast path: direct lookup without triggering error import
     if name in _ALIAS_TO_NAME:
         return True
     lower = name.lower()
-    return lower in _ALIAS_TO_NAME
+    return lower in _ALIAS_TO_NAME or lower in _EXTRA_ALIASES
+
+
+@cache
+def _filename_patterns() -> tuple[tuple[str, str], ...]:
+    """(pattern, language) pairs from the language catalog's `filenames`.
+
+    Exact names come first, then longer patterns, so `*.kida.html` wins
+    over `*.html`.
+    """
+    from rosettes.languages import _CATALOG
+
+    pairs = [
+        (pattern, name) for name in _SORTED_LANGUAGES for pattern in _CATALOG[name].filenames
+    ]
+    pairs.sort(key=lambda pair: ("*" in pair[0] or "?" in pair[0], -len(pair[0])))
+    return tuple(pairs)
+
+
+def guess_language(filename: str) -> str | None:
+    """Guess a file's language from its name.
+
+    Matches the base name against each language's `filenames` patterns
+    (`*.py`, `Dockerfile`, `Dockerfile.*`) from the static catalog in
+    `rosettes.languages`, without importing any lexer.
+
+    Args:
+        filename: File name or path.
+
+    Returns:
+        Canonical language name, or None if no lexer claims the name.
+
+    Example:
+        >>> guess_language("src/app.py")
+        'python'
+        >>> guess_language("Dockerfile.dev")
+        'dockerfile'
+    """
+    base = filename.replace("\\", "/").rsplit("/", 1)[-1]
+    for pattern, name in _filename_patterns():
+        if glob_match(base, pattern):
+            return name
+    return None
@@ -0,0 +1,198 @@
+"""Search-term overlay for Rosettes.
+
+Finds query terms in code and marks them in highlighted output, for code
+search results:
+
+```python
+>>> html = highlight(code, "python", search=["open", "close"])
+>>> html = highlight(code, "python", search=SearchQuery(["Path"], whole_word=True))
+>>> [(m.line, m.column) for m in find_matches(code, ["open"])]
+[(3, 9), (7, 5)]
+```
+
+**Design Philosophy:**
+
+Terms are literal strings matched with `str.find`, never compiled to
+regular expressions, so queries keep the O(n) guarantee of the lexers.
+`ignore_case` lowercases code and terms character by character (so
+offsets stay aligned even for characters whose lowercase is longer), and
+`whole_word` requires a word boundary (letters, digits and `_` on one
+side only) at both ends of a match.
+
+**Output:**
+
+Matches are found in the displayed text, so they can span several
+tokens and lines. Overlapping matches are merged. Formatters split each
+match at token and line boundaries: `HtmlFormatter` wraps each piece in
+`<mark data-match="N">` inside the token's span, so spans stay properly
+nested, and `TerminalFormatter` shows matches in reverse video. N is the
+index of the match in `find_matches()`.
+
+**Thread-Safety:**
+
+Pure functions; uses only local variables.
+
+**See Also:**
+
+- `rosettes.highlight`: Enabled with `search=`
+- `rosettes._config.HighlightConfig`: `marks` for formatters
+"""
+
+from __future__ import annotations
+
+from collections.abc import Sequence
+from dataclasses import dataclass
+
+__all__ = ["SearchMatch", "SearchQuery", "find_matches", "split_marks"]
+
+
+@dataclass(frozen=True, slots=True)
+class SearchQuery:
+    """Terms to find and how to match them.
+
+    Attributes:
+        terms: Literal strings to find. Empty strings are ignored.
+        ignore_case: Match regardless of case.
+        whole_word: Only match terms with word boundaries on both ends.
+    """
+
+    terms: Sequence[str]
+    ignore_case: bool = False
+    whole_word: bool = False
+
+
+@dataclass(frozen=True, slots=True)
+class SearchMatch:
+    """A match in the searched code.
+
+    Attributes:
+        start: Offset of the first character.
+        end: Offset after the last character.
+        line: 1-based line of the first character.
+        column: 1-based column of the first character.
+        text: The matched text.
+    """
+
+    start: int
+    end: int
+    line: int
+    column: int
+    text: str
+
+
+def _lower(text: str) -> str:
+    """Lowercase without changing the length or character offsets."""
+    lowered = text.lower()
+    if len(lowered) == len(text):
+        return lowered
+    # Some characters lowercase to several ("İ" → "i̇"); keep those as-is
+    return "".join(char.lower() if len(char.lower()) == 1 else char for char in text)
+
+
+def _is_word(char: str) -> bool:
+    return char.isalnum() or char == "_"
+
+
+def _boundary(text: str, index: int) -> bool:
+    before = index > 0 and _is_word(text[index - 1])
+    after = index < len(text) and _is_word(text[index])
+    return before != after
+
+
+def find_matches(code: str, search: Sequence[str] | SearchQuery) -> list[SearchMatch]:
+    """Find every occurrence of the search terms.
+
+    Args:
+        code: Text to search.
+        search: Terms, matched literally and case-sensitively, or a
+            SearchQuery.
+
+    Returns:
+        Matches in order of position, with overlapping matches merged.
+
+    Example:
+        >>> [m.text for m in find_matches("Foo foobar foo", SearchQuery(["foo"], True, True))]
+        ['Foo', 'foo']
+    """
+    query = search if isinstance(search, SearchQuery) else SearchQuery(search)
+    haystack = _lower(code) if query.ignore_case else code
+
+    ranges: list[tuple[int, int]] = []
+    for term in query.terms:
+        if not term:
+            continue
+        needle = _lower(term) if query.ignore_case else term
+        position = haystack.find(needle)
+        while position != -1:
+            end = position + len(needle)
+            if query.whole_word and not (
+                _boundary(haystack, position) and _boundary(haystack, end)
+            ):
+                position = haystack.find(needle, position + 1)
+                continue
+            ranges.append((position, end))
+            position = haystack.find(needle, end)
+
+    merged: list[tuple[int, int]] = []
+    for start, end in sorted(ranges):
+        if merged and start < merged[-1][1]:
+            merged[-1] = (merged[-1][0], max(merged[-1][1], end))
+        else:
+            merged.append((start, end))
+
+    matches: list[SearchMatch] = []
+    line = 1
+    line_start = 0
+    counted = 0
+    for start, end in merged:
+        newlines = code.count("\n", counted, start)
+        if newlines:
+            line += newlines
+            line_start = code.rfind("\n", counted, start) + 1
+        counted = start
+        matches.append(SearchMatch(start, end, line, start - line_start + 1, code[start:end]))
+    return matches
+
+
+def split_marks(
+    value: str,
+    offset: int,
+    marks: Sequence[tuple[int, int]],
+    first: int = 0,
+) -> tuple[list[tuple[str, int | None]], int]:
+    """Split text at mark boundaries.
+
+    Args:
+        value: Text of a token (or one line of it).
+        offset: Offset of `value` in the searched text.
+        marks: Sorted, non-overlapping (start, end) ranges.
+        first: Index of the first mark that may reach `value`; pass the
+            returned index for the next piece of text.
+
+    Returns:
+        (segments, first): segments are (text, mark index) pairs, with
+        None for unmarked text.
+
+    Example:
+        >>> split_marks("foobar", 10, [(8, 12), (14, 15)])
+        ([('fo', 0), ('ob', None), ('a', 1), ('r', None)], 0)
+    """
+    end = offset + len(value)
+    while first < len(marks) and marks[first][1] <= offset:
+        first += 1
+
+    segments: list[tuple[str, int | None]] = []
+    position = offset
+    index = first
+    while index < len(marks) and marks[index][0] < end:
+        start, stop = marks[index]
+        if start > position:
+            segments.append((value[position - offset : start - offset], None))
+            position = start
+        stop = min(stop, end)
+        segments.append((value[position - offset : stop - offset], index))
+        position = stop
+        index += 1
+    if position < end:
+        segments.append((value[position - offset :], None))
+    return segments, first
@@ -0,0 +1,111 @@
+"""Token splitting helpers for post-lexing passes.
+
+Thread-safe, pure functions over immutable tokens.
+
+**Design Philosophy:**
+
+Lexers produce one token per lexical unit. Some features refine a token
+after lexing — format placeholders inside a string, markup inside a doc
+comment — by re-typing slices of its text. `split_token()` performs that
+refinement while keeping line/column positions exact, so passes never
+need to re-derive positions themselves. `split_lines()` regroups a token
+stream by line for consumers that draw one line at a time.
+
+**Thread-Safety:**
+
+Functions here take tokens and spans and return new tokens. No state
+is shared between calls.
+
+**See Also:**
+
+- `rosettes._placeholders`: Format placeholder pass built on `split_token()`
+- `rosettes.tokenize_lines`: Per-line tokens built on `split_lines()`
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable, Iterator
+
+from rosettes._types import Token, TokenType
+
+__all__ = ["split_lines", "split_token"]
+
+
+def split_token(
+    token: Token,
+    spans: Iterable[tuple[int, int, TokenType]],
+) -> Iterator[Token]:
+    """Split a token into sub-tokens with accurate positions.
+
+    Text outside the spans keeps the original token type. Concatenating
+    the yielded values always reproduces `token.value`.
+
+    Args:
+        token: Token to split.
+        spans: Sorted, non-overlapping `(start, end, type)` offsets into
+            `token.value`.
+
+    Yields:
+        Sub-tokens in order, with line/column adjusted across newlines.
+    """
+    value = token.value
+    line = token.line
+    col = token.column
+    seg = 0
+
+    for start, end, span_type in spans:
+        if start > seg:
+            text = value[seg:start]
+            yield Token(token.type, text, line, col)
+            line, col = _advance(text, line, col)
+        text = value[start:end]
+        yield Token(span_type, text, line, col)
+        line, col = _advance(text, line, col)
+        seg = end
+
+    if seg < len(value) or seg == 0:
+        yield Token(token.type, value[seg:], line, col)
+
+
+def _advance(text: str, line: int, col: int) -> tuple[int, int]:
+    """Return the position just after `text`."""
+    newlines = text.count("\n")
+    if newlines:
+        return line + newlines, len(text) - text.rfind("\n")
+    return line, col + len(text)
+
+
+def split_lines(tokens: Iterable[tuple[TokenType, str]]) -> Iterator[list[Token]]:
+    """Group tokens by line, splitting multi-line tokens at line breaks.
+
+    Line breaks are dropped (a `\\r` before a `\\n` too), as are the empty
+    pieces they leave. Positions are recomputed from the text, so the
+    input can be Tokens or `(type, value)` pairs.
+
+    Args:
+        tokens: Tokens in source order.
+
+    Yields:
+        The tokens of each line, with 1-based line and column. Blank
+        lines are empty lists; a final line break doesn't start a line.
+    """
+    line = 1
+    column = 1
+    current: list[Token] = []
+    for token in tokens:
+        token_type, value = token[0], token[1]
+        parts = value.split("\n")
+        last = len(parts) - 1
+        for index, part in enumerate(parts):
+            if index:
+                yield current
+                current = []
+                line += 1
+                column = 1
+            if index < last and part.endswith("\r"):
+                part = part[:-1]
+            if part:
+                current.append(Token(token_type, part, line, column))
+                column += len(part)
+    if current:
+        yield current
@@ -0,0 +1,161 @@
+"""Command-line interface for Rosettes.
+
+**Commands:**
+
+- `rosettes tree PATH`: Render a directory listing and highlight it with
+  the `tree-listing` lexer.
+- `rosettes css`: Print the stylesheet of the configured palette (or
+  `--palette NAME`), optionally with print rules.
+- `rosettes languages`: List supported languages, or print the language
+  catalog as JSON with `--json`.
+
+Output defaults to ANSI colors when stdout is a terminal and plain text
+otherwise; pass `-f html` for HTML.
+
+Every command first applies the project configuration: `[tool.rosettes]`
+in the nearest `pyproject.toml` or a `rosettes.toml` (see
+`rosettes.config`). `--config PATH` names the file; `--no-config` skips
+it.
+
+**Example:**
+
+```bash
+rosettes tree . -I '*.pyc' -I '__pycache__/' -L 2 --dirs-first
+python -m rosettes tree docs -f html > tree.html
+rosettes css --palette monokai --print > syntax.css
+rosettes languages --json > languages.json
+rosettes --config docs/rosettes.toml css
+```
+
+**See Also:**
+
+- `rosettes.filetree`: The directory walker behind `rosettes tree`
+- `rosettes.config`: Project configuration
+"""
+
+from __future__ import annotations
+
+import argparse
+import sys
+from collections.abc import Sequence
+
+from rosettes import highlight
+from rosettes._formatter_registry import list_formatters
+from rosettes.config import RosettesConfig, configure, get_config
+from rosettes.filetree import render_tree
+from rosettes.languages import CATEGORIES, iter_languages, languages_json
+from rosettes.themes import get_palette
+
+__all__ = ["main"]
+
+
+def _build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(prog="rosettes", description="Syntax highlighting.")
+    config = parser.add_mutually_exclusive_group()
+    config.add_argument(
+        "--config", metavar="PATH", help="Configuration file (default: found from the cwd up)"
+    )
+    config.add_argument("--no-config", action="store_true", help="Ignore configuration files")
+    commands = parser.add_subparsers(dest="command", required=True)
+
+    formatter_help = "Output format (default: terminal on a tty, else null)"
+
+    tree = commands.add_parser("tree", help="Render and highlight a directory tree")
+    tree.add_argument("path", nargs="?", default=".", help="Directory to list")
+    tree.add_argument(
+        "-I",
+        "--exclude",
+        action="append",
+        default=[],
+        metavar="PATTERN",
+        help="gitignore-style pattern to leave out (repeatable)",
+    )
+    tree.add_argument("-L", "--depth", type=int, help="Maximum depth to descend")
+    tree.add_argument(
+        "--sort", choices=("name", "size", "mtime", "none"), default="name", help="Entry order"
+    )
+    tree.add_argument("--dirs-first", action="store_true", help="List directories first")
+    tree.add_argument("-a", "--all", action="store_true", help="Include hidden files")
+    tree.add_argument(
+        "--no-gitignore", action="store_true", help="Don't apply .gitignore files or skip .git"
+    )
+    tree.add_argument("-f", "--formatter", choices=list_formatters(), help=formatter_help)
+
+    css = commands.add_parser("css", help="Print a palette's stylesheet")
+    css.add_argument("--palette", help="Palette name (default: the configured palette)")
+    css.add_argument(
+        "--class-style",
+        choices=("semantic", "pygments"),
+        help="CSS class naming (default: the configured style)",
+    )
+    css.add_argument(
+        "--print",
+        nargs="?",
+        const=True,
+        default=False,
+        choices=("light", "high-contrast"),
+        help="Add print rules (optionally high-contrast)",
+    )
+
+    languages = commands.add_parser("languages", help="List supported languages")
+    languages.add_argument("--category", choices=list(CATEGORIES), help="Only this category")
+    languages.add_argument("--json", action="store_true", help="Print the catalog as JSON")
+    return parser
+
+
+def _default_formatter() -> str:
+    return "terminal" if sys.stdout.isatty() else "null"
+
+
+def main(argv: Sequence[str] | None = None) -> int:
+    """Run the command line.
+
+    Args:
+        argv: Arguments without the program name. Defaults to sys.argv[1:].
+
+    Returns:
+        Exit status.
+    """
+    parser = _build_parser()
+    args = parser.parse_args(argv)
+    try:
+        configure(RosettesConfig() if args.no_config else args.config)
+    except (OSError, LookupError, ValueError, ImportError) as e:
+        parser.exit(1, f"rosettes: {e}\n")
+
+    if args.command == "css":
+        try:
+            palette = get_palette(args.palette)
+        except LookupError as e:
+            parser.exit(1, f"rosettes: {e}\n")
+        class_style = args.class_style or get_config().css_class_style
+        sys.stdout.write(palette.generate_css(class_style=class_style, print=args.print) + "\n")
+        return 0
+
+    if args.command == "languages":
+        if args.json:
+            sys.stdout.write(languages_json(indent=2) + "\n")
+            return 0
+        infos = list(iter_languages(category=args.category))
+        width = max(len(info.name) for info in infos)
+        for info in infos:
+            aliases = f" ({', '.join(info.aliases)})" if info.aliases else ""
+            sys.stdout.write(f"{info.name:<{width}}  {info.display_name}{aliases}\n")
+        return 0
+
+    formatter = args.formatter or _default_formatter()
+
+    try:
+        text = render_tree(
+            args.path,
+            exclude=args.exclude,
+            max_depth=args.depth,
+            sort=args.sort,
+            dirs_first=args.dirs_first,
+            show_hidden=args.all,
+            gitignore=not args.no_gitignore,
+        )
+    except NotADirectoryError as e:
+        parser.exit(1, f"rosettes: {e}\n")
+    sys.stdout.write(highlight(text + "\n", "tree-listing", formatter))
+    return 0
@@ -0,0 +1,246 @@
+"""Project-level configuration for Rosettes.
+
+Reads `[tool.rosettes]` from `pyproject.toml`, or the top level of a
+`rosettes.toml`, and applies it with `configure()`:
+
+```toml
+[tool.rosettes]
+palette = "monokai"            # get_palette() default, `rosettes css`
+css_class_style = "pygments"   # highlight() default
+fallback = "plaintext"         # language for unknown names
+plugins = ["mysite.rosettes"]  # modules imported first
+
+[tool.rosettes.aliases]
+console = "bash"
+
+[tool.rosettes.formatters.html]
+wrap = true
+
+[tool.rosettes.formatters.terminal]
+wrap = true
+width = 100
+```
+
+```python
+>>> import rosettes
+>>> rosettes.configure()                  # find a config from the cwd up
+>>> rosettes.configure("docs/rosettes.toml")
+>>> rosettes.configure(RosettesConfig(aliases=(("console", "bash"),)))
+```
+
+The command line applies the configuration found from the working
+directory (or `--config PATH`) before each command.
+
+**Settings:**
+
+- `palette`: Palette returned by `rosettes.themes.get_palette()` without
+  a name.
+- `css_class_style`: Default for highlight(), highlight_many() and
+  highlight_group().
+- `aliases`: Extra language names. Built-in names and aliases win.
+- `fallback`: Language used by get_lexer() (and so highlight()) for
+  unknown names, instead of raising LookupError. A `fallback` policy
+  passed by the caller takes precedence.
+- `formatters`: Keyword arguments for the instances get_formatter()
+  returns, by formatter name (`wrap`, `width`, `align_columns`, ...).
+- `plugins`: Modules imported before the rest is applied, e.g. to
+  register palettes.
+
+**Discovery:**
+
+`find_config()` walks up from the working directory. In each directory
+`rosettes.toml` wins over `pyproject.toml`; a `pyproject.toml` without a
+`[tool.rosettes]` table is skipped.
+
+**Thread-Safety:**
+
+configure() validates everything first, then swaps in the new settings.
+Call it at startup, before highlighting from several threads; reads of
+the settings are lock-free.
+
+**See Also:**
+
+- `rosettes._registry`: Language aliases and fallback
+- `rosettes._formatter_registry`: Formatter options
+"""
+
+from __future__ import annotations
+
+import os
+from collections.abc import Mapping
+from dataclasses import dataclass, fields
+from importlib import import_module
+from pathlib import Path
+from typing import Any, Literal
+
+__all__ = ["RosettesConfig", "configure", "find_config", "get_config", "load_config"]
+
+
+@dataclass(frozen=True, slots=True)
+class RosettesConfig:
+    """Project-wide defaults.
+
+    Attributes:
+        palette: Default palette name.
+        css_class_style: Default HTML class naming style.
+        aliases: (name, target) pairs of extra language names, each
+            mapped to a language or alias.
+        fallback: Language for unknown names, or None to raise LookupError.
+        formatters: (formatter name, keyword arguments) pairs, the
+            arguments as (name, value) pairs.
+        plugins: Modules to import when the configuration is applied.
+    """
+
+    palette: str = "bengal-tiger"
+    css_class_style: Literal["semantic", "pygments"] = "semantic"
+    aliases: tuple[tuple[str, str], ...] = ()
+    fallback: str | None = None
+    formatters: tuple[tuple[str, tuple[tuple[str, Any], ...]], ...] = ()
+    plugins: tuple[str, ...] = ()
+
+
+_current = RosettesConfig()
+
+
+def get_config() -> RosettesConfig:
+    """Return the configuration applied by the last configure() call."""
+    return _current
+
+
+def _has_tool_table(path: Path) -> bool:
+    import tomllib
+
+    with open(path, "rb") as f:
+        return "rosettes" in tomllib.load(f).get("tool", {})
+
+
+def find_config(start: str | os.PathLike[str] | None = None) -> Path | None:
+    """Find the nearest configuration file.
+
+    Args:
+        start: Directory to start from. Defaults to the working directory.
+
+    Returns:
+        A `rosettes.toml`, or a `pyproject.toml` with `[tool.rosettes]`,
+        or None if no directory up to the root has one.
+    """
+    directory = Path(start if start is not None else Path.cwd()).resolve()
+    for candidate in (directory, *directory.parents):
+        if (candidate / "rosettes.toml").is_file():
+            return candidate / "rosettes.toml"
+        pyproject = candidate / "pyproject.toml"
+        if pyproject.is_file() and _has_tool_table(pyproject):
+            return pyproject
+    return None
+
+
+def _check_strings(value: object, key: str, source: str) -> None:
+    if not isinstance(value, Mapping) or not all(
+        isinstance(k, str) and isinstance(v, str) for k, v in value.items()
+    ):
+        raise ValueError(f"{source}: {key!r} must be a table of strings")
+
+
+def load_config(path: str | os.PathLike[str]) -> RosettesConfig:
+    """Read a configuration file.
+
+    Args:
+        path: A `pyproject.toml` (reads `[tool.rosettes]`) or any other TOML
+            file (reads the top level, as in `rosettes.toml`).
+
+    Returns:
+        The configuration. A pyproject.toml without the table gives the
+        defaults.
+
+    Raises:
+        OSError: If the file can't be read.
+        ValueError: If the TOML is invalid, or has unknown keys or values
+            of the wrong type.
+    """
+    import tomllib
+
+    source = os.fspath(path)
+    with open(path, "rb") as f:
+        try:
+            data = tomllib.load(f)
+        except tomllib.TOMLDecodeError as e:
+            raise ValueError(f"{source}: {e}") from None
+    if Path(source).name == "pyproject.toml":
+        data = data.get("tool", {}).get("rosettes", {})
+
+    known = {f.name for f in fields(RosettesConfig)}
+    unknown = sorted(set(data) - known)
+    if unknown:
+        raise ValueError(f"{source}: unknown key(s) {unknown}; expected some of {sorted(known)}")
+    for key in ("palette", "fallback"):
+        if key in data and not isinstance(data[key], str):
+            raise ValueError(f"{source}: {key!r} must be a string")
+    if data.get("css_class_style", "semantic") not in ("semantic", "pygments"):
+        raise ValueError(f"{source}: 'css_class_style' must be 'semantic' or 'pygments'")
+    if "aliases" in data:
+        _check_strings(data["aliases"], "aliases", source)
+    formatters = data.get("formatters", {})
+    if not isinstance(formatters, Mapping) or not all(
+        isinstance(options, Mapping) for options in formatters.values()
+    ):
+        raise ValueError(f"{source}: 'formatters' must be a table of tables")
+    plugins = data.get("plugins", [])
+    if not isinstance(plugins, list) or not all(isinstance(p, str) for p in plugins):
+        raise ValueError(f"{source}: 'plugins' must be a list of module names")
+    return RosettesConfig(
+        **{
+            **data,
+            "aliases": tuple(data.get("aliases", {}).items()),
+            "formatters": tuple(
+                (name, tuple(options.items())) for name, options in formatters.items()
+            ),
+            "plugins": tuple(plugins),
+        }
+    )
+
+
+def configure(config: RosettesConfig | str | os.PathLike[str] | None = None) -> RosettesConfig:
+    """Apply a configuration.
+
+    Replaces the settings of any earlier call. Plugins are imported first;
+    then every setting is validated before any is applied.
+
+    Args:
+        config: A configuration, a file to load, or None to use
+            find_config() (the defaults if no file is found).
+
+    Returns:
+        The applied configuration.
+
+    Raises:
+        OSError: If the file can't be read.
+        ValueError: If the file is invalid, or formatter options don't
+            fit the formatter.
+        LookupError: If the palette, an alias target, the fallback or a
+            formatter is unknown.
+        ImportError: If a plugin can't be imported.
+    """
+    global _current
+
+    if config is None:
+        path = find_config()
+        config = load_config(path) if path is not None else RosettesConfig()
+    elif not isinstance(config, RosettesConfig):
+        config = load_config(config)
+
+    from rosettes import _formatter_registry, _registry
+    from rosettes.themes import get_palette
+
+    for module in config.plugins:
+        import_module(module)
+
+    get_palette(config.palette)
+    aliases, fallback = _registry._resolve_configured(dict(config.aliases), config.fallback)
+    formatters = _formatter_registry._build_configured(
+        {name: dict(options) for name, options in config.formatters}
+    )
+
+    _registry._set_configured(aliases, fallback)
+    _formatter_registry._set_configured(formatters)
+    _current = config
+    return config
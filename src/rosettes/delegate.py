@@ -58,8 +58,10 @@ from collections.abc import Iterator
 from typing import TYPE_CHECKING
 
 from rosettes import get_lexer, supports_language
+from rosettes._dedent import dedent_code, restore_positions
 
 if TYPE_CHECKING:
+    from rosettes._config import LexerConfig
     from rosettes._types import Token
 
 
@@ -90,6 +92,10 @@ class RosettesDelegate:
         start: int,
         end: int,
         language: str,
+        *,
+        dedent: bool = False,
+        trim: bool = False,
+        config: LexerConfig | None = None,
     ) -> Iterator[Token]:
         """Tokenize a range of source code using rosettes state-machine lexer.
 
@@ -101,18 +107,29 @@ class RosettesDelegate:
             start: Starting index of the code block in source.
             end: Ending index (exclusive) of the code block.
             language: Language name or alias (e.g., 'python', 'js').
+            dedent: Lex the block with its common indentation removed, for
+                blocks nested in lists or other indented Markdown.
+            trim: Lex the block without leading and trailing blank lines.
+            config: Lexer configuration; `tab_size` sets how far a tab
+                indents when dedenting.
 
         Yields:
-            Token objects for the code in range [start, end).
+            Token objects for the code in range [start, end). With dedent
+            or trim, positions still refer to the original block: lines
+            count from `start` and columns include the removed indentation.
 
         Performance:
             O(end - start) guaranteed. Zero allocations for code content.
             The lexer reads characters directly from source[start:end].
+            dedent and trim copy the block once.
 
         Raises:
             LookupError: If the language is not supported.
         """
         lexer = get_lexer(language)
+        if dedent or trim:
+            block = dedent_code(source, config, start=start, end=end, dedent=dedent, trim=trim)
+            return restore_positions(lexer.tokenize(block.code, config), block)
         return lexer.tokenize(source, start=start, end=end)
 
     def supports_language(self, language: str) -> bool:
@@ -0,0 +1,408 @@
+"""Comment, docstring and string extraction for Rosettes.
+
+Pulls the prose out of source code for spell checkers and linters,
+using the lexers' tokens rather than per-language patterns:
+
+```python
+>>> from rosettes.extract import extract
+>>> for segment in extract(source, "java"):
+...     for word, index in words(segment.text):
+...         if misspelled(word):
+...             offset, line, column = segment.to_original(index)
+```
+
+**Kinds:**
+
+- `comment`: COMMENT, COMMENT_SINGLE and COMMENT_MULTILINE tokens
+- `docstring`: documentation, i.e. COMMENT_DOC (`/** */`, `///`, `//!`)
+  tokens and STRING_DOC tokens from lexers that only use it for
+  documentation (Python docstrings, Perl POD), including the markup
+  tokens split out of them (`@param`, inline code, links)
+- `string`: string literals, with their escapes and interpolations; other
+  STRING_DOC tokens (Java text blocks, Swift multi-line strings) count
+  as strings
+
+Preprocessor lines, hashbangs and special comments are never extracted.
+
+**Marker Stripping:**
+
+Comment markers come from the language catalog
+(`rosettes.languages`), with common markers as a fallback for other
+lexers. Each segment's text has:
+
+- Line markers removed, with repeats (`///`, `//!`, `##`)
+- Block delimiters removed (`/* */`, `/** */`, `<!-- -->`, `{- -}`),
+  and a leading `*` on each line of a `/* */` block
+- Quotes and prefixes removed from docstrings and strings (`r"`, triple
+  quotes, `r#"..."#`)
+- Comments and docstrings: whitespace around each line and blank lines
+  at the start and end removed; strings are kept as written
+
+Consecutive line comments that each start their line form one segment,
+so a comment paragraph is checked as a whole.
+
+**Positions:**
+
+`Segment.start`/`end` span the whole comment or literal in the source,
+markers included. `Segment.to_original(index)` maps an index in `text`
+to its offset, line and column in the source.
+
+**Thread-Safety:**
+
+Uses only local variables; segments are immutable.
+
+**See Also:**
+
+- `rosettes.languages`: Comment syntax per language
+- `rosettes.lexers._doc_markup`: Markup tokens inside doc comments
+"""
+
+from __future__ import annotations
+
+from bisect import bisect_right
+from collections.abc import Iterable
+from dataclasses import dataclass
+from typing import Literal
+
+from rosettes import tokenize
+from rosettes._config import LexerConfig
+from rosettes._protocol import Lexer
+from rosettes._registry import FallbackPolicy, UnknownLanguageError, get_lexer
+from rosettes._types import Token, TokenType
+from rosettes.languages import get_language_info
+
+__all__ = ["Segment", "SegmentKind", "extract"]
+
+SegmentKind = Literal["comment", "docstring", "string"]
+
+_KINDS: frozenset[str] = frozenset({"comment", "docstring", "string"})
+
+_COMMENT_TYPES = frozenset(
+    {TokenType.COMMENT, TokenType.COMMENT_SINGLE, TokenType.COMMENT_MULTILINE}
+)
+# Doc comments and docstrings, with the tokens split out of them
+_DOC_PARTS = frozenset(
+    {
+        TokenType.COMMENT_DOC,
+        TokenType.STRING_DOC,
+        TokenType.NAME_DECORATOR,
+        TokenType.NAME_VARIABLE,
+        TokenType.KEYWORD_TYPE,
+        TokenType.NAME_ENTITY,
+        TokenType.STRING_BACKTICK,
+        TokenType.STRING_ESCAPE,
+    }
+)
+_STRING_TYPES = frozenset(
+    {
+        TokenType.STRING,
+        TokenType.STRING_AFFIX,
+        TokenType.STRING_BACKTICK,
+        TokenType.STRING_DOC,
+        TokenType.STRING_DOUBLE,
+        TokenType.STRING_HEREDOC,
+        TokenType.STRING_SINGLE,
+    }
+)
+_STRING_PARTS = _STRING_TYPES | {
+    TokenType.STRING_DELIMITER,
+    TokenType.STRING_ESCAPE,
+    TokenType.STRING_INTERPOL,
+}
+
+# Markers tried when the language's own markers don't match
+_BLOCK_MARKERS = (("/*", "*/"), ("<!--", "-->"), ("{-", "-}"), ("(*", "*)"))
+_LINE_MARKERS = ("//", "#", "--", ";", "%")
+
+_QUOTES = "\"'`"
+
+
+@dataclass(frozen=True, slots=True)
+class Segment:
+    """Prose from one comment, docstring or string literal.
+
+    Attributes:
+        kind: "comment", "docstring" or "string".
+        text: The prose, markers stripped; lines joined with "\\n".
+        start: Offset of the comment or literal in the source.
+        end: Offset just past it.
+        line: 1-based line of `start`.
+        column: 1-based column of `start`.
+        pieces: (index in text, offset, line, column) where each line of
+            `text` starts; used by to_original().
+    """
+
+    kind: SegmentKind
+    text: str
+    start: int
+    end: int
+    line: int
+    column: int
+    pieces: tuple[tuple[int, int, int, int], ...]
+
+    def to_original(self, index: int) -> tuple[int, int, int]:
+        """Map an index in `text` to (offset, line, column) in the source.
+
+        Example:
+            >>> segment = extract("x = 1  # teh value\\n", "python")[0]
+            >>> segment.to_original(segment.text.index("teh"))
+            (9, 1, 10)
+        """
+        if not 0 <= index <= len(self.text):
+            raise IndexError(f"index {index} out of range for {len(self.text)} characters")
+        position = bisect_right(self.pieces, index, key=lambda piece: piece[0]) - 1
+        text_index, offset, line, column = self.pieces[position]
+        delta = index - text_index
+        return offset + delta, line, column + delta
+
+
+@dataclass(slots=True)
+class _Run:
+    """A comment or literal's source span and the kept range of each line."""
+
+    kind: SegmentKind
+    start: int
+    end: int
+    lines: list[tuple[int, int]]
+    # Marker of a line comment (`//`, `///`), empty for anything else
+    line_marker: str
+
+
+def _markers(lexer: Lexer) -> tuple[tuple[tuple[str, str], ...], tuple[str, ...]]:
+    """Block and line comment markers for a lexer, most specific first."""
+    try:
+        info = get_language_info(lexer.name)
+    except UnknownLanguageError:
+        return (), ()
+    blocks = (info.block_comment,) if info.block_comment else ()
+    lines = (info.line_comment,) if info.line_comment else ()
+    return blocks, lines
+
+
+def _strip_markers(
+    code: str,
+    start: int,
+    end: int,
+    markers: tuple[tuple[tuple[str, str], ...], tuple[str, ...]],
+) -> tuple[int, int, str, bool]:
+    """Find the text inside a comment's markers.
+
+    Returns:
+        (start, end, line comment marker or "", strip_stars).
+    """
+    raw = code[start:end]
+    blocks, lines = markers
+    # The language's own markers first; common block markers only count
+    # when the comment also ends with the closer
+    candidates = [*blocks, *((marker, "") for marker in lines)]
+    candidates += [pair for pair in _BLOCK_MARKERS if raw.endswith(pair[1])]
+    candidates += [(marker, "") for marker in _LINE_MARKERS]
+    for open_, close in candidates:
+        if not raw.startswith(open_):
+            continue
+        inner = len(open_)
+        while inner < len(raw) and raw[inner] in open_[-1] + "!":
+            inner += 1
+        outer = len(raw)
+        if close and raw.endswith(close) and len(raw) - len(close) >= inner:
+            outer -= len(close)
+            while outer > inner and close[0] in "*-" and raw[outer - 1] == close[0]:
+                outer -= 1
+        return start + inner, start + outer, "" if close else raw[:inner], open_ == "/*"
+    return start, end, "", False
+
+
+def _strip_quotes(code: str, start: int, end: int) -> tuple[int, int]:
+    """Find the text inside a literal's prefix and quotes."""
+    raw = code[start:end]
+    if raw.startswith("["):
+        # Lua long brackets: [[ ]], [==[ ]==]
+        level = len(raw) - len(raw[1:].lstrip("=")) - 1
+        closing = "]" + "=" * level + "]"
+        if raw.startswith("[", level + 1) and raw.endswith(closing):
+            return start + level + 2, max(start + level + 2, end - len(closing))
+    quote_at = next((i for i, char in enumerate(raw) if char in _QUOTES), -1)
+    prefix = raw[:quote_at]
+    if quote_at == -1 or len(prefix) > 3 or not all(c.isalpha() or c in "#@$" for c in prefix):
+        return start, end
+    quote = raw[quote_at]
+    width = 3 if raw.startswith(quote * 3, quote_at) and len(raw) >= quote_at + 6 else 1
+    inner = quote_at + width
+    closing = quote * width + "#" * prefix.count("#")
+    outer = len(raw)
+    if raw.endswith(closing) and outer - len(closing) >= inner:
+        outer -= len(closing)
+    return start + inner, start + outer
+
+
+def _split_lines(
+    code: str, start: int, end: int, *, strip: bool, stars: bool
+) -> list[tuple[int, int]]:
+    """Split a span into per-line ranges, optionally trimming each line."""
+    ranges: list[tuple[int, int]] = []
+    line_start = start
+    while True:
+        newline = code.find("\n", line_start, end)
+        line_end = end if newline == -1 else newline
+        first, last = line_start, line_end
+        if strip:
+            while first < last and code[first].isspace():
+                first += 1
+            if stars and ranges and code.startswith("*", first, last):
+                first += 1
+                while first < last and code[first].isspace():
+                    first += 1
+            while last > first and code[last - 1].isspace():
+                last -= 1
+        ranges.append((first, last))
+        if newline == -1:
+            return ranges
+        line_start = newline + 1
+
+
+def _runs(code: str, lexer: Lexer, kinds: frozenset[str], tokens: list[Token]) -> list[_Run]:
+    """Group tokens into comments, docstrings and literals."""
+    markers = _markers(lexer)
+    # Only lexers that keep STRING_DOC for documentation have docstrings
+    docstrings = getattr(lexer, "DOCSTRINGS", False)
+    runs: list[_Run] = []
+    offsets: list[int] = []
+    offset = 0
+    for token in tokens:
+        offsets.append(offset)
+        offset += len(token.value)
+
+    index = 0
+    while index < len(tokens):
+        token_type = tokens[index].type
+        start = offsets[index]
+        if token_type in _COMMENT_TYPES:
+            kind: SegmentKind = "comment"
+            parts: frozenset[TokenType] = frozenset()
+        elif token_type == TokenType.COMMENT_DOC or (
+            token_type == TokenType.STRING_DOC and docstrings
+        ):
+            kind, parts = "docstring", _DOC_PARTS
+        elif token_type in _STRING_TYPES:
+            kind, parts = "string", _STRING_PARTS
+        else:
+            index += 1
+            continue
+        index += 1
+        while index < len(tokens) and tokens[index].type in parts:
+            # A closed doc comment ends here, even if an annotation follows
+            if code.startswith("/*", start) and code.endswith("*/", start, offsets[index]):
+                break
+            index += 1
+        end = offsets[index] if index < len(tokens) else offset
+        if kind not in kinds:
+            continue
+
+        if kind == "string" or token_type == TokenType.STRING_DOC:
+            inner, outer = _strip_quotes(code, start, end)
+            line_marker, stars = "", False
+        else:
+            inner, outer, line_marker, stars = _strip_markers(code, start, end, markers)
+        lines = _split_lines(code, inner, outer, strip=kind != "string", stars=stars)
+        runs.append(_Run(kind, start, end, lines, line_marker))
+    return runs
+
+
+def _starts_line(code: str, offset: int) -> bool:
+    line_start = code.rfind("\n", 0, offset) + 1
+    return not code[line_start:offset].strip()
+
+
+def _merge_line_comments(code: str, runs: list[_Run]) -> list[_Run]:
+    """Join line comments with the same marker on consecutive lines.
+
+    Each comment must start its line, so a trailing comment isn't joined
+    with the comment below it.
+    """
+    merged: list[_Run] = []
+    for run in runs:
+        previous = merged[-1] if merged else None
+        if (
+            previous is not None
+            and previous.line_marker
+            and previous.line_marker == run.line_marker
+            and previous.kind == run.kind
+            and _starts_line(code, previous.start)
+            and code[previous.end : run.start].count("\n") == 1
+            and code[previous.end : run.start].isspace()
+        ):
+            previous.end = run.end
+            previous.lines.extend(run.lines)
+        else:
+            merged.append(run)
+    return merged
+
+
+def extract(
+    code: str,
+    language: str | Lexer,
+    kinds: Iterable[SegmentKind] = ("comment", "docstring"),
+    *,
+    fallback: FallbackPolicy | None = None,
+    lexer_config: LexerConfig | None = None,
+) -> list[Segment]:
+    """Extract comments, docstrings and string literals as prose.
+
+    Args:
+        code: Source code.
+        language: Language name or alias, or a lexer instance.
+        kinds: Any of "comment", "docstring" and "string". Defaults to
+            comments and docstrings.
+        fallback: Policy for unknown languages (see get_lexer()).
+        lexer_config: Passed to the lexer.
+
+    Returns:
+        Segments in source order. Segments without text (`//`, `""`)
+        are skipped.
+
+    Raises:
+        ValueError: If a kind is unknown.
+        UnknownLanguageError: If the language is not supported and
+            fallback is "raise" (or None without a configured fallback).
+
+    Example:
+        >>> [s.text for s in extract("/**\\n * Adds.\\n */\\nx = 1; // ok\\n", "c")]
+        ['Adds.', 'ok']
+    """
+    wanted = frozenset(kinds)
+    unknown = sorted(wanted - _KINDS)
+    if unknown:
+        raise ValueError(f"Unknown kind(s) {unknown}; expected some of {sorted(_KINDS)}")
+
+    lexer = get_lexer(language, fallback=fallback) if isinstance(language, str) else language
+    tokens = tokenize(code, lexer, lexer_config=lexer_config)
+    runs = _merge_line_comments(code, _runs(code, lexer, wanted, tokens))
+
+    line_starts = [0]
+    line_starts.extend(i + 1 for i, char in enumerate(code) if char == "\n")
+
+    def position(offset: int) -> tuple[int, int]:
+        line = bisect_right(line_starts, offset)
+        return line, offset - line_starts[line - 1] + 1
+
+    segments: list[Segment] = []
+    for run in runs:
+        lines = run.lines
+        if run.kind != "string":
+            while lines and lines[0][0] == lines[0][1]:
+                lines = lines[1:]
+            while lines and lines[-1][0] == lines[-1][1]:
+                lines = lines[:-1]
+        texts = [code[first:last] for first, last in lines]
+        text = "\n".join(texts)
+        if not text:
+            continue
+        pieces: list[tuple[int, int, int, int]] = []
+        index = 0
+        for (first, _), piece in zip(lines, texts, strict=True):
+            pieces.append((index, first, *position(first)))
+            index += len(piece) + 1
+        segments.append(
+            Segment(run.kind, text, run.start, run.end, *position(run.start), tuple(pieces))
+        )
+    return segments
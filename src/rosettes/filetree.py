@@ -0,0 +1,290 @@
+"""Render directories on disk as `tree`-format text.
+
+Walks a path and produces the listing the `tree` command prints, ready
+to highlight with the `tree-listing` lexer (which types entries as
+directories, source, config or docs).
+
+**Example:**
+
+```python
+>>> from rosettes import highlight
+>>> from rosettes.filetree import render_tree
+>>> text = render_tree("src", exclude=["__pycache__/", "*.pyc"], max_depth=2)
+>>> html = highlight(text, "tree-listing")
+```
+
+**Excludes:**
+
+Patterns follow `.gitignore` rules:
+
+- `*.pyc` matches a name at any depth; `/build` or `docs/_build`
+  (containing a slash) match relative to the root
+- A trailing `/` matches directories only
+- `**` matches any number of directories (`**/tmp`, `logs/**`)
+- `!pattern` re-includes something an earlier pattern excluded
+- Later patterns win
+
+With `gitignore=True` (the default), `.gitignore` files found during the
+walk are applied to their directory, and `.git` is skipped.
+
+**Output:**
+
+Directories get a trailing `/` (like `tree -F`) so the lexer can tell an
+empty directory from a file. Symlinks are shown as `name -> target` and
+are not followed.
+
+**Thread-Safety:**
+
+Pure functions; the only state is local to each call.
+
+**See Also:**
+
+- `rosettes.lexers.dirtree_sm`: Lexer for the output
+- `rosettes.cli`: `rosettes tree PATH` on the command line
+"""
+
+from __future__ import annotations
+
+import os
+from collections.abc import Iterable
+from dataclasses import dataclass
+from pathlib import Path
+from typing import Literal
+
+from rosettes._glob import glob_match
+
+__all__ = ["ExcludeRule", "SortKey", "parse_excludes", "render_tree"]
+
+SortKey = Literal["name", "size", "mtime", "none"]
+
+_BRANCH = "├── "
+_LAST = "└── "
+_PIPE = "│   "
+_SPACE = "    "
+
+
+@dataclass(frozen=True, slots=True)
+class ExcludeRule:
+    """One parsed gitignore-style pattern.
+
+    Attributes:
+        segments: Pattern split at `/`.
+        base: Path segments of the directory the rule applies to.
+        negated: True for `!pattern` (re-include).
+        directory_only: True if the pattern ended with `/`.
+        anchored: True if the pattern contains a slash before its end, so
+            it matches from `base` rather than at any depth.
+    """
+
+    segments: tuple[str, ...]
+    base: tuple[str, ...] = ()
+    negated: bool = False
+    directory_only: bool = False
+    anchored: bool = False
+
+    def matches(self, parts: tuple[str, ...], is_dir: bool) -> bool:
+        """Check the rule against a path given as segments from the root."""
+        if self.directory_only and not is_dir:
+            return False
+        if parts[: len(self.base)] != self.base:
+            return False
+        parts = parts[len(self.base) :]
+        if not parts:
+            return False
+        if not self.anchored:
+            return glob_match(parts[-1], self.segments[0])
+        return _match_segments(self.segments, parts)
+
+
+def _match_segments(pattern: tuple[str, ...], parts: tuple[str, ...]) -> bool:
+    if not pattern:
+        return not parts
+    head = pattern[0]
+    if head == "**":
+        if len(pattern) == 1:
+            return bool(parts)
+        return any(_match_segments(pattern[1:], parts[i:]) for i in range(len(parts) + 1))
+    return bool(parts) and glob_match(parts[0], head) and _match_segments(pattern[1:], parts[1:])
+
+
+def parse_excludes(patterns: Iterable[str], base: tuple[str, ...] = ()) -> list[ExcludeRule]:
+    """Parse gitignore-style patterns.
+
+    Blank lines and `#` comments are skipped, so the lines of a
+    `.gitignore` file can be passed directly.
+
+    Args:
+        patterns: Pattern strings.
+        base: Segments of the directory the patterns are relative to.
+
+    Returns:
+        Parsed rules, in order.
+    """
+    rules = []
+    for raw in patterns:
+        pattern = raw.strip()
+        if not pattern or pattern.startswith("#"):
+            continue
+        negated = pattern.startswith("!")
+        if negated:
+            pattern = pattern[1:]
+        directory_only = pattern.endswith("/")
+        pattern = pattern.strip("/") if directory_only else pattern
+        anchored = "/" in pattern
+        pattern = pattern.lstrip("/")
+        if not pattern:
+            continue
+        rules.append(
+            ExcludeRule(
+                segments=tuple(pattern.split("/")),
+                base=base,
+                negated=negated,
+                directory_only=directory_only,
+                anchored=anchored,
+            )
+        )
+    return rules
+
+
+def _is_excluded(rules: list[ExcludeRule], parts: tuple[str, ...], is_dir: bool) -> bool:
+    excluded = False
+    for rule in rules:
+        if rule.negated == excluded and rule.matches(parts, is_dir):
+            excluded = not rule.negated
+    return excluded
+
+
+def _read_gitignore(directory: Path, base: tuple[str, ...]) -> list[ExcludeRule]:
+    try:
+        text = (directory / ".gitignore").read_text(encoding="utf-8")
+    except OSError:
+        return []
+    return parse_excludes(text.splitlines(), base)
+
+
+def _is_dir(entry: os.DirEntry[str], *, follow_symlinks: bool = False) -> bool:
+    try:
+        return entry.is_dir(follow_symlinks=follow_symlinks)
+    except OSError:
+        return False
+
+
+def _sort_entries(entries: list[os.DirEntry[str]], sort: SortKey, dirs_first: bool) -> None:
+    def stat_value(entry: os.DirEntry[str], attribute: str) -> float:
+        try:
+            return getattr(entry.stat(follow_symlinks=False), attribute)
+        except OSError:
+            return 0
+
+    if sort == "name":
+        entries.sort(key=lambda entry: (entry.name.casefold(), entry.name))
+    elif sort == "size":
+        entries.sort(key=lambda entry: -stat_value(entry, "st_size"))
+    elif sort == "mtime":
+        entries.sort(key=lambda entry: -stat_value(entry, "st_mtime"))
+    if dirs_first:
+        entries.sort(key=lambda entry: not _is_dir(entry))
+
+
+def render_tree(
+    path: str | os.PathLike[str],
+    *,
+    exclude: Iterable[str] = (),
+    max_depth: int | None = None,
+    sort: SortKey = "name",
+    dirs_first: bool = False,
+    show_hidden: bool = False,
+    gitignore: bool = True,
+    summary: bool = True,
+) -> str:
+    """Render a directory as `tree`-format text.
+
+    Args:
+        path: Directory to walk.
+        exclude: gitignore-style patterns to leave out.
+        max_depth: Deepest level to list (1 = only the root's entries),
+            like `tree -L`. None for no limit.
+        sort: "name" (case-insensitive), "size" (largest first), "mtime"
+            (newest first) or "none" (directory order).
+        dirs_first: If True, list directories before files.
+        show_hidden: If True, include dotfiles, like `tree -a`.
+        gitignore: If True, apply `.gitignore` files and skip `.git`.
+        summary: If True, end with the `N directories, M files` line.
+
+    Returns:
+        The listing, one entry per line, without a trailing newline.
+
+    Raises:
+        NotADirectoryError: If path is not a directory.
+
+    Example:
+        >>> print(render_tree("project"))  # doctest: +SKIP
+        project
+        ├── src/
+        │   └── main.py
+        └── README.md
+        <BLANKLINE>
+        1 directory, 2 files
+    """
+    root = Path(path)
+    if not root.is_dir():
+        raise NotADirectoryError(f"Not a directory: {os.fspath(path)!r}")
+
+    lines = [os.fspath(path)]
+    counts = [0, 0]  # directories, files
+    rules = parse_excludes(exclude)
+
+    def walk(directory: Path, parts: tuple[str, ...], prefix: str, rules: list[ExcludeRule]):
+        if gitignore:
+            rules = rules + _read_gitignore(directory, parts)
+        try:
+            with os.scandir(directory) as scan:
+                entries = list(scan)
+        except OSError:
+            lines[-1] += "  [error opening dir]"
+            return
+
+        visible = []
+        for entry in entries:
+            if not show_hidden and entry.name.startswith("."):
+                continue
+            if gitignore and entry.name == ".git":
+                continue
+            if _is_excluded(rules, (*parts, entry.name), _is_dir(entry)):
+                continue
+            visible.append(entry)
+        _sort_entries(visible, sort, dirs_first)
+
+        for index, entry in enumerate(visible):
+            last = index == len(visible) - 1
+            line = prefix + (_LAST if last else _BRANCH)
+            if entry.is_symlink():
+                try:
+                    target = os.readlink(entry.path)
+                except OSError:
+                    target = "?"
+                counts[0 if _is_dir(entry, follow_symlinks=True) else 1] += 1
+                lines.append(f"{line}{entry.name} -> {target}")
+                continue
+            if _is_dir(entry):
+                counts[0] += 1
+                lines.append(f"{line}{entry.name}/")
+                depth = len(parts) + 1
+                if max_depth is None or depth < max_depth:
+                    child_prefix = prefix + (_SPACE if last else _PIPE)
+                    walk(Path(entry.path), (*parts, entry.name), child_prefix, rules)
+            else:
+                counts[1] += 1
+                lines.append(line + entry.name)
+
+    if max_depth is None or max_depth > 0:
+        walk(root, (), "", rules)
+
+    if summary:
+        directories, files = counts
+        lines.append("")
+        lines.append(
+            f"{directories} director{'y' if directories == 1 else 'ies'}, "
+            f"{files} file{'' if files == 1 else 's'}"
+        )
+    return "\n".join(lines)
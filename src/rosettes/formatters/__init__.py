@@ -7,6 +7,7 @@ Contains output formatters for different targets (HTML, terminal, etc.).
 - `HtmlFormatter`: HTML output with semantic or Pygments-compatible CSS classes
 - `TerminalFormatter`: ANSI escape codes for terminal output
 - `NullFormatter`: No-op formatter for testing and benchmarking
+- `StyleFragmentsFormatter`: `(style, text)` pairs for TUIs and editors
 
 **Usage:**
 
@@ -36,8 +37,9 @@ protocol. See `rosettes.formatters.html` for a reference implementation.
 - `rosettes._formatter_registry`: How formatters are registered
 """
 
+from rosettes.formatters.fragments import StyleFragmentsFormatter
 from rosettes.formatters.html import HtmlFormatter
 from rosettes.formatters.null import NullFormatter
 from rosettes.formatters.terminal import TerminalFormatter
 
-__all__ = ["HtmlFormatter", "TerminalFormatter", "NullFormatter"]
+__all__ = ["HtmlFormatter", "TerminalFormatter", "NullFormatter", "StyleFragmentsFormatter"]
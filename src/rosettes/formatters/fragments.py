@@ -0,0 +1,153 @@
+"""Style fragments formatter for Rosettes.
+
+Turns tokens into `(style, text)` pairs styled from a palette, for
+terminal UIs and editors that draw text themselves:
+
+```python
+>>> from rosettes import tokenize_lines
+>>> from rosettes.formatters import StyleFragmentsFormatter
+>>> formatter = StyleFragmentsFormatter("monokai")
+>>> for line in formatter.format_lines(tokenize_lines(code, "python")):
+...     draw(line)  # [("#66d9ef bold", "def"), ("#f8f8f2", " "), ...]
+```
+
+**Style Strings:**
+
+Each style is a hex foreground color followed by `bold` and/or `italic`
+(`"#c678dd bold"`), as set by the palette's `bold_control`,
+`bold_declaration`, `italic_comment` and `italic_docstring`. The same
+string works as:
+
+- a prompt_toolkit style (`FormattedText` fragments)
+- a Rich / Textual style (`Text.append(text, style)`)
+- input for curses, after mapping colors to color pairs
+
+Backgrounds are left out (the libraries spell them differently); set
+`background` on the widget instead.
+
+**Not a Registry Formatter:**
+
+`highlight()` returns a string, and these fragments aren't one, so this
+formatter isn't available through get_formatter(). Use it with
+`tokenize()` or `tokenize_lines()`.
+
+**Thread-Safety:**
+
+The formatter is a frozen dataclass. Styles per palette are computed
+once and cached (functools.cache over immutable palettes).
+
+**See Also:**
+
+- `rosettes.tokenize_lines`: Tokens grouped by line
+- `rosettes.formatters.terminal`: ANSI output (same role system)
+- `rosettes.themes._mapping`: TokenType → SyntaxRole mapping
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable, Iterator
+from dataclasses import dataclass
+from functools import cache
+
+from rosettes._types import Token, TokenType
+from rosettes.themes import AdaptivePalette, SyntaxPalette, get_palette
+from rosettes.themes._mapping import ROLE_MAPPING
+from rosettes.themes._roles import SyntaxRole
+
+__all__ = ["StyleFragmentsFormatter"]
+
+
+@cache
+def _token_styles(palette: SyntaxPalette) -> dict[TokenType, str]:
+    """Build the style string for every token type."""
+    role_styles: dict[SyntaxRole, str] = {}
+    for role, (color, bold, italic) in palette._role_styles().items():
+        words = [color]
+        if bold:
+            words.append("bold")
+        if italic:
+            words.append("italic")
+        role_styles[role] = " ".join(words)
+    text = role_styles[SyntaxRole.TEXT]
+    return {tt: role_styles.get(ROLE_MAPPING.get(tt, SyntaxRole.TEXT), text) for tt in TokenType}
+
+
+@dataclass(frozen=True, slots=True)
+class StyleFragmentsFormatter:
+    """Formatter that yields `(style, text)` pairs.
+
+    Thread-safe: immutable dataclass; styles are cached per palette.
+
+    Attributes:
+        palette: Palette, or registered palette name. None uses the
+            configured default palette.
+        dark: For an AdaptivePalette, use the dark variant (the default)
+            rather than the light one.
+
+    **Example:**
+
+    ```python
+    >>> from rosettes import tokenize
+    >>> formatter = StyleFragmentsFormatter(SyntaxPalette("bw", "#fff", "#000"))
+    >>> list(formatter.format_fragments(tokenize("# hi", "python")))
+    [('#000 italic', '# hi')]
+    ```
+    """
+
+    palette: SyntaxPalette | AdaptivePalette | str | None = None
+    dark: bool = True
+
+    @property
+    def name(self) -> str:
+        return "fragments"
+
+    def _resolve(self) -> SyntaxPalette:
+        palette = self.palette
+        if palette is None or isinstance(palette, str):
+            palette = get_palette(palette)
+        if isinstance(palette, AdaptivePalette):
+            return palette.dark if self.dark else palette.light
+        return palette
+
+    @property
+    def background(self) -> str:
+        """Background color of the palette."""
+        return self._resolve().background
+
+    def styles(self) -> dict[TokenType, str]:
+        """Return the style string for every token type.
+
+        Useful for registering the styles up front, e.g. as curses color
+        pairs.
+        """
+        return dict(_token_styles(self._resolve()))
+
+    def format_fragments(
+        self,
+        tokens: Iterable[Token] | Iterable[tuple[TokenType, str]],
+    ) -> Iterator[tuple[str, str]]:
+        """Yield a `(style, text)` pair for each token.
+
+        Args:
+            tokens: Tokens, or `(type, value)` pairs from tokenize_fast().
+        """
+        styles = _token_styles(self._resolve())
+        for token in tokens:
+            yield styles[token[0]], token[1]
+
+    def format_lines(
+        self,
+        lines: Iterable[Iterable[Token]],
+    ) -> Iterator[list[tuple[str, str]]]:
+        """Convert each line from tokenize_lines() into fragments.
+
+        Args:
+            lines: Lines of tokens, as from tokenize_lines().
+
+        Yields:
+            One list of `(style, text)` pairs per line, without line
+            breaks.
+        """
+        styles = _token_styles(self._resolve())
+        for line in lines:
+            yield [(styles[token.type], token.value) for token in line]
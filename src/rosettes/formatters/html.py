@@ -7,7 +7,12 @@ Thread-safe by design — no mutable shared state.
 
 - Dual CSS class output: semantic (.syntax-function) or Pygments (.nf)
 - CSS custom properties for runtime theming
-- Line highlighting (hl_lines parameter)
+- Line highlighting (hl_lines parameter) and per-line classes
+  (`line-added`, `line-dimmed`, ... from notation comments)
+- Cross-reference links from names to API docs (`resolver`)
+- Search matches wrapped in `<mark>` (`HighlightConfig.marks`)
+- Per-line coverage status, heat and gutter columns (`HighlightConfig.line_data`)
+- Soft-wrapped long lines with a hanging indent (`wrap`)
 - Streaming output (generator-based)
 
 **Design Philosophy:**
@@ -16,7 +21,7 @@ The HTML formatter is optimized for the common case while supporting
 advanced features when needed:
 
 1. **Fast path**: `format_fast()` for simple highlighting (~50µs/block)
-2. **Slow path**: `format()` for line highlighting, line numbers
+2. **Slow path**: `format()` for line highlighting, line numbers, links
 3. **Immutable**: Frozen dataclass ensures thread-safety
 4. **Streaming**: Yields chunks for memory-efficient processing
 
@@ -41,6 +46,23 @@ Benchmarks (100-line Python file):
 - `format()` with hl_lines: ~80µs
 - `format()` with line numbers: ~100µs
 
+**Soft Wrap:**
+
+With `wrap=True`, each line becomes a flex row, so wrapped text stays in
+the code column instead of flowing under the line number:
+
+```html
+<span class="line-wrap"><span class="lineno">12</span><span class="line-code"
+  style="--hang: 6ch">    <span class="syntax-function">call</span>(…)</span>
+</span>
+```
+
+The stylesheet (`SyntaxPalette.generate_css()`) sets `white-space:
+pre-wrap` on `.line-code` and indents continuation lines by `--hang`:
+the line's own indentation plus 2ch (2ch when unset). With line numbers,
+`<pre>` sets `--lineno-width` to the widest number so the rows line up.
+Line breaks stay in the text, so copied code is unchanged.
+
 **Common Mistakes:**
 
 ```python
@@ -71,12 +93,15 @@ from typing import TYPE_CHECKING, Literal
 
 from rosettes._config import FormatConfig, HighlightConfig
 from rosettes._escape import escape_html
+from rosettes._search import split_marks
 from rosettes._types import Token, TokenType
+from rosettes.linedata import gutter_cells
 from rosettes.themes._mapping import ROLE_MAPPING
 from rosettes.themes._roles import SyntaxRole
+from rosettes.xref import resolve_links
 
 if TYPE_CHECKING:
-    pass
+    from rosettes.xref import Resolver
 
 __all__ = ["HtmlFormatter"]
 
@@ -128,6 +153,39 @@ for _role, _class_name in _SEMANTIC_CLASS.items():
         _SEMANTIC_SPAN_OPEN[_role] = f'<span class="{_class_name}">'
 
 
+def _indent_width(text: str) -> int:
+    """Width of leading whitespace in columns, with tabs at multiples of 8."""
+    width = 0
+    for char in text:
+        if char == " ":
+            width += 1
+        elif char == "\t":
+            width += 8 - width % 8
+        else:
+            break
+    return width
+
+
+def _line_spans(config: HighlightConfig) -> dict[int, str]:
+    """Build the opening tag for each highlighted, classed or heat-mapped line."""
+    classes: dict[int, list[str]] = {}
+    styles: dict[int, str] = {}
+    for line in config.hl_lines:
+        classes[line] = [config.hl_line_class]
+    for line, names in config.line_classes:
+        classes.setdefault(line, []).append(names)
+    for line, data in config.line_data:
+        if data.status is not None:
+            classes.setdefault(line, []).append(f"line-{data.status}")
+        if data.intensity is not None:
+            classes.setdefault(line, []).append("line-heat")
+            styles[line] = f' style="--line-heat: {data.intensity:.3g}"'
+    return {
+        line: f'<span class="{" ".join(names)}"{styles.get(line, "")}>'
+        for line, names in classes.items()
+    }
+
+
 @dataclass(frozen=True, slots=True)
 class HtmlFormatter:
     """HTML formatter with streaming output.
@@ -138,6 +196,11 @@ class HtmlFormatter:
     Attributes:
         config: Highlight configuration for line highlighting, line numbers.
         css_class_style: "semantic" for .syntax-* or "pygments" for .k, .nf
+        resolver: Optional callable or mapping from (dotted) names to URLs.
+            Resolved names are wrapped in `<a class="xref">` by format();
+            format_fast() ignores it. See `rosettes.xref`.
+        wrap: If True, soft-wrap long lines with a hanging indent (CSS
+            based; format() only).
 
     Example:
         >>> from rosettes import get_lexer, HtmlFormatter
@@ -167,6 +230,8 @@ class HtmlFormatter:
 
     config: HighlightConfig = field(default_factory=HighlightConfig)
     css_class_style: CssClassStyle = "semantic"
+    resolver: Resolver | None = None
+    wrap: bool = False
 
     @property
     def name(self) -> str:
@@ -261,23 +326,50 @@ class HtmlFormatter:
         if config is None:
             config = FormatConfig()
 
-        hl_lines = self.config.hl_lines
+        line_spans = _line_spans(self.config)
         is_semantic = self.css_class_style == "semantic"
         container = config.css_class if config.css_class else self.container_class
 
-        # Fast path: no line highlighting
-        if not hl_lines:
+        # Fast path: no line highlighting, line numbers or links
+        show_linenos = self.config.show_linenos
+        marks = self.config.marks
+        gutters, blank_gutter = gutter_cells(dict(self.config.line_data))
+        if (
+            not line_spans
+            and not show_linenos
+            and not marks
+            and not blank_gutter
+            and self.resolver is None
+            and not self.wrap
+        ):
             fast_tokens = ((t.type, t.value) for t in tokens)
             yield from self.format_fast(fast_tokens, config)
             return
 
-        # Slow path: line highlighting
+        # Slow path: line-by-line output
         no_span = _NO_SPAN_TYPES
         escape = escape_html
         prefix = config.class_prefix
-        hl_line_class = self.config.hl_line_class
-        hl_span_open = f'<span class="{hl_line_class}">'
         span_close = _SPAN_CLOSE
+        lineno_open = f'<span class="{self.config.lineno_class}">'
+        gutter_html = {
+            line: "".join(f'<span class="line-gutter">{escape(cell)}</span>' for cell in cells)
+            for line, cells in gutters.items()
+        }
+        blank_gutter_html = "".join(
+            f'<span class="line-gutter">{cell}</span>' for cell in blank_gutter
+        )
+        line_numbers = self.config.line_numbers
+        wrap = self.wrap
+        pre_attr = ""
+        if wrap and show_linenos:
+            if line_numbers:
+                numbers = [n for n in line_numbers if n is not None]
+            else:
+                token_list = list(tokens)
+                tokens = iter(token_list)
+                numbers = [sum(t.value.count("\n") for t in token_list) + 1]
+            pre_attr = f' style="--lineno-width: {len(str(max(numbers, default=1)))}ch"'
 
         # Prepare span lookup tables
         semantic_span_open: dict[SyntaxRole, str] | None = None
@@ -294,61 +386,98 @@ class HtmlFormatter:
             data_lang_attr = (
                 f' data-language="{config.data_language}"' if config.data_language else ""
             )
-            yield f'<div class="{container}"{data_lang_attr}><pre><code>'
-
+            yield f'<div class="{container}"{data_lang_attr}><pre{pre_attr}><code>'
+
+        def line_start(line: int, text: str = "") -> str:
+            """Gutter cells, line number and line span that open a line."""
+            start = gutter_html.get(line, blank_gutter_html)
+            if show_linenos:
+                if line_numbers:
+                    number = line_numbers[line - 1] if line <= len(line_numbers) else None
+                else:
+                    number = line
+                start += f"{lineno_open}{'' if number is None else number}{span_close}"
+            if wrap:
+                indent = _indent_width(text)
+                hang = f' style="--hang: {indent + 2}ch"' if indent else ""
+                start = f'<span class="line-wrap">{start}<span class="line-code"{hang}>'
+            return start + line_spans.get(line, "")
+
+        def line_end(line: int) -> str:
+            """Close the line span (and wrap row) and break the line."""
+            end = span_close if line in line_spans else ""
+            return end + ("</span>\n</span>" if wrap else "\n")
+
+        # A line opens at its first text, so the empty line after a final
+        # newline gets no line number or span
         current_line = 1
-        in_hl = current_line in hl_lines
+        started = False
+        # Offset in the formatted text, for search marks
+        offset = 0
+        first_mark = 0
 
-        if in_hl:
-            yield hl_span_open
+        if self.resolver is not None:
+            linked = resolve_links(tokens, self.resolver)
+        else:
+            linked = ((token, None) for token in tokens)
 
-        for token in tokens:
-            # Handle line transitions
+        for token, url in linked:
+            # Lines the token stream skipped
             while current_line < token.line:
-                if in_hl:
-                    yield span_close
-                yield "\n"
+                if not started:
+                    yield line_start(current_line)
+                yield line_end(current_line)
                 current_line += 1
-                in_hl = current_line in hl_lines
-                if in_hl:
-                    yield hl_span_open
+                started = False
 
-            # Format token
-            escaped = escape(token.value)
             if token.type in no_span:
-                yield escaped
+                template = None
+            elif is_semantic and semantic_span_open is not None:
+                role = ROLE_MAPPING.get(token.type, SyntaxRole.TEXT)
+                template = semantic_span_open.get(role)
+            elif pygments_span_open is not None:
+                template = pygments_span_open.get(token.type.value)
             else:
-                if is_semantic and semantic_span_open is not None:
-                    role = ROLE_MAPPING.get(token.type, SyntaxRole.TEXT)
-                    template = semantic_span_open.get(role)
-                elif pygments_span_open is not None:
-                    template = pygments_span_open.get(token.type.value)
-                else:
-                    template = None
-
+                template = None
+
+            # Multi-line tokens are split so each piece sits in its own line
+            for index, piece in enumerate(token.value.split("\n")):
+                if index:
+                    if not started:
+                        yield line_start(current_line)
+                    yield line_end(current_line)
+                    current_line += 1
+                    started = False
+                    offset += 1
+                if not piece:
+                    continue
+                if not started:
+                    yield line_start(current_line, piece)
+                    started = True
+                if url is not None:
+                    yield f'<a class="xref" href="{escape(url)}">'
                 if template:
                     yield template
-                    yield escaped
-                    yield span_close
+                if marks:
+                    segments, first_mark = split_marks(piece, offset, marks, first_mark)
+                    for text, mark in segments:
+                        if mark is None:
+                            yield escape(text)
+                        else:
+                            yield f'<mark data-match="{mark}">{escape(text)}</mark>'
                 else:
-                    yield escaped
-
-            # Track embedded newlines
-            value = token.value
-            nl_idx = value.find("\n")
-            if nl_idx >= 0:
-                if in_hl:
+                    yield escape(piece)
+                if template:
                     yield span_close
-                # Count newlines without second scan
-                newlines = value.count("\n", nl_idx)
-                for _ in range(newlines):
-                    current_line += 1
-                    in_hl = current_line in hl_lines
-                if in_hl:
-                    yield hl_span_open
-
-        if in_hl:
-            yield span_close
+                offset += len(piece)
+                if url is not None:
+                    yield "</a>"
+
+        if started:
+            if current_line in line_spans:
+                yield span_close
+            if wrap:
+                yield "</span></span>"
 
         if config.wrap_code:
             yield "</code></pre></div>"
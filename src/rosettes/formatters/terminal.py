@@ -24,6 +24,34 @@ visual consistency between terminal and web output.
 - O(1) color lookup per token
 - Streaming output (yields chunks, no intermediate list)
 
+**Column Alignment:**
+
+`TerminalFormatter(align_columns=True)` pads delimiter-separated data
+(CSV/TSV tokens, where PUNCTUATION is the delimiter) so columns line up.
+It buffers the whole token stream to measure column widths.
+
+**Line Styles:**
+
+With `config=HighlightConfig(...)`, highlighted lines and notation line
+classes (`line-added`, `line-removed`, `line-error`, `line-warning`) get
+a 256-color background, and `line-dimmed` lines are faint. With
+`show_linenos`, lines get a gray `12 │` gutter. Search matches
+(`HighlightConfig.marks`) are shown in reverse video.
+
+**Line Data:**
+
+`HighlightConfig.line_data` adds coverage backgrounds (covered green,
+missed red, partial yellow), a five-step red heat ramp for `intensity`,
+and gray gutter columns before the line number.
+
+**Soft Wrap:**
+
+`TerminalFormatter(wrap=True)` breaks lines that don't fit in `width`
+columns (the terminal width by default). Widths are measured in cells:
+East Asian wide characters take two, combining marks none. Continuation
+lines start with a gray `↪` after a blank gutter, and colors and line
+backgrounds are closed before each break and reopened after it.
+
 Benchmarks: ~30µs per 100-line file (vs ~50µs for HTML)
 
 **Terminal Compatibility:**
@@ -53,11 +81,16 @@ Color mappings are module-level constants (immutable dicts).
 
 from __future__ import annotations
 
-from collections.abc import Iterator
-from dataclasses import dataclass
+import shutil
+import unicodedata
+from collections.abc import Iterable, Iterator
+from dataclasses import dataclass, field
 from typing import TYPE_CHECKING
 
+from rosettes._config import HighlightConfig
+from rosettes._search import split_marks
 from rosettes._types import Token, TokenType
+from rosettes.linedata import gutter_cells
 from rosettes.themes._mapping import ROLE_MAPPING
 from rosettes.themes._roles import SyntaxRole
 
@@ -107,6 +140,130 @@ for _tt in TokenType:
 
 _NO_COLOR_TYPES = {TokenType.TEXT, TokenType.WHITESPACE}
 
+_LINENO_COLOR = "\033[90m"
+_MARK_STYLE = "\033[7m"
+
+# Line styles for hl_lines and notation line classes (256-color backgrounds)
+_HL_LINE_STYLE = "\033[48;5;236m"
+_LINE_STYLES: dict[str, str] = {
+    "line-added": "\033[48;5;22m",
+    "line-removed": "\033[48;5;52m",
+    "line-error": "\033[48;5;88m",
+    "line-warning": "\033[48;5;58m",
+    "line-dimmed": "\033[2m",
+    "line-covered": "\033[48;5;22m",
+    "line-missed": "\033[48;5;52m",
+    "line-partial": "\033[48;5;58m",
+}
+
+# Heat ramp for LineData.intensity, coolest first
+_HEAT_STYLES = tuple(f"\033[48;5;{code}m" for code in (235, 52, 88, 124, 160))
+
+# Soft wrap: marker at the start of continuation lines, and its width in cells
+_CONTINUATION = f"{_LINENO_COLOR}↪{_RESET} "
+_CONTINUATION_WIDTH = 2
+_TAB_SIZE = 8
+
+
+def _char_width(char: str) -> int:
+    """Terminal cells taken by a character."""
+    if unicodedata.combining(char) or unicodedata.category(char) in ("Me", "Cf"):
+        return 0
+    return 2 if unicodedata.east_asian_width(char) in ("W", "F") else 1
+
+
+def _split_width(text: str, column: int, limit: int) -> tuple[list[str], int]:
+    """Split text into chunks that fit lines of `limit` cells.
+
+    Args:
+        text: Text without line breaks.
+        column: Cells already used on the current line.
+        limit: Cells per line; continuation lines start after the marker.
+
+    Returns:
+        (chunks, column): one chunk per line, and the cells used on the last.
+    """
+    chunks: list[str] = []
+    start = 0
+    for index, char in enumerate(text):
+        if char == "\t":
+            width = _TAB_SIZE - column % _TAB_SIZE
+        else:
+            width = _char_width(char)
+        if column + width > limit and column > _CONTINUATION_WIDTH and width:
+            chunks.append(text[start:index])
+            start = index
+            column = _CONTINUATION_WIDTH
+        column += width
+    chunks.append(text[start:])
+    return chunks, column
+
+
+def _line_styles(config: HighlightConfig) -> dict[int, str]:
+    """Build the SGR prefix for each highlighted or classed line."""
+    styles: dict[int, str] = {}
+    for line in config.hl_lines:
+        styles[line] = _HL_LINE_STYLE
+    for line, names in config.line_classes:
+        style = "".join(_LINE_STYLES.get(name, "") for name in names.split())
+        if style:
+            styles[line] = styles.get(line, "") + style
+    for line, data in config.line_data:
+        style = ""
+        if data.status is not None:
+            style = _LINE_STYLES[f"line-{data.status}"]
+        if data.intensity is not None:
+            step = min(int(data.intensity * len(_HEAT_STYLES)), len(_HEAT_STYLES) - 1)
+            style = _HEAT_STYLES[step]
+        if style:
+            styles[line] = styles.get(line, "") + style
+    return styles
+
+
+def _align_columns(
+    tokens: Iterable[tuple[TokenType, str]],
+) -> Iterator[tuple[TokenType, str]]:
+    """Pad cells of delimiter-separated tokens so columns line up.
+
+    Rows end at whitespace tokens containing a line break; cells end at
+    PUNCTUATION tokens. Padding goes before each delimiter, and the last
+    cell of a row is never padded.
+    """
+    rows: list[tuple[list[list[tuple[TokenType, str]]], list[tuple[TokenType, str]]]] = []
+    cells: list[list[tuple[TokenType, str]]] = [[]]
+    delimiters: list[tuple[TokenType, str]] = []
+    for tt, value in tokens:
+        if tt == TokenType.PUNCTUATION:
+            delimiters.append((tt, value))
+            cells.append([])
+        elif tt == TokenType.WHITESPACE and ("\n" in value or "\r" in value):
+            cells[-1].append((tt, value))
+            rows.append((cells, delimiters))
+            cells, delimiters = [[]], []
+        else:
+            cells[-1].append((tt, value))
+    rows.append((cells, delimiters))
+
+    def width(cell: list[tuple[TokenType, str]]) -> int:
+        text = "".join(value for _, value in cell)
+        return len(text) - text.rfind("\n") - 1
+
+    widths: list[int] = []
+    for cells, _ in rows:
+        for index, cell in enumerate(cells[:-1]):
+            if index == len(widths):
+                widths.append(0)
+            widths[index] = max(widths[index], width(cell))
+
+    for cells, delimiters in rows:
+        for index, delimiter in enumerate(delimiters):
+            yield from cells[index]
+            padding = widths[index] - width(cells[index])
+            if padding:
+                yield TokenType.WHITESPACE, " " * padding
+            yield delimiter
+        yield from cells[-1]
+
 
 @dataclass(frozen=True, slots=True)
 class TerminalFormatter:
@@ -127,11 +284,40 @@ class TerminalFormatter:
         >>> formatter = TerminalFormatter()
         >>> output = formatter.format_string(lexer.tokenize("x = 1"))
 
+    Example (aligned CSV):
+        >>> formatter = TerminalFormatter(align_columns=True)
+        >>> output = highlight("a,bb\\nccc,d\\n", "csv", formatter=formatter)
+
+    Example (soft wrap):
+        >>> formatter = TerminalFormatter(wrap=True, width=80)
+
+    Example (line styles):
+        >>> from rosettes._config import HighlightConfig
+        >>> config = HighlightConfig(hl_lines=frozenset({2}), line_classes=((3, "line-added"),))
+        >>> formatter = TerminalFormatter(config=config)
+
+    Attributes:
+        align_columns: If True, pad delimiter-separated data (CSV/TSV) so
+            columns line up.
+        config: Line highlighting. Highlighted, added, removed, error and
+            warning lines get a background color; `line-dimmed` lines are
+            faint. Only `format()` applies it.
+        wrap: If True, break lines longer than `width`, with a `↪` marker
+            on continuation lines. Only `format()` applies it, and not
+            with align_columns.
+        width: Columns to wrap at. Defaults to the terminal width
+            (`shutil.get_terminal_size()`, 80 when not a terminal).
+
     Note:
         For most use cases, use rosettes.highlight() with formatter="terminal"
         instead of instantiating TerminalFormatter directly.
     """
 
+    align_columns: bool = False
+    config: HighlightConfig = field(default_factory=HighlightConfig)
+    wrap: bool = False
+    width: int | None = None
+
     @property
     def name(self) -> str:
         return "terminal"
@@ -142,6 +328,8 @@ class TerminalFormatter:
         config: FormatConfig | None = None,
     ) -> Iterator[str]:
         """Fast ANSI formatting using pre-computed color maps."""
+        if self.align_columns:
+            tokens = _align_columns(tokens)
         ansi_start = _TOKEN_ANSI_START
         no_color = _NO_COLOR_TYPES
         reset = _RESET
@@ -164,6 +352,19 @@ class TerminalFormatter:
         config: FormatConfig | None = None,
     ) -> Iterator[str]:
         """Format tokens as ANSI-colored strings."""
+        if self.align_columns:
+            yield from self.format_fast(((t.type, t.value) for t in tokens), config)
+            return
+        line_styles = _line_styles(self.config)
+        if (
+            line_styles
+            or self.config.show_linenos
+            or self.config.marks
+            or self.config.line_data
+            or self.wrap
+        ):
+            yield from self._format_lines(tokens, line_styles)
+            return
         ansi_start = _TOKEN_ANSI_START
         no_color = _NO_COLOR_TYPES
         reset = _RESET
@@ -181,6 +382,100 @@ class TerminalFormatter:
                 else:
                     yield token.value
 
+    def _format_lines(self, tokens: Iterator[Token], line_styles: dict[int, str]) -> Iterator[str]:
+        """Format tokens line by line, with line styles, line numbers, marks and wrapping.
+
+        The line style is re-applied after each token's reset and cleared
+        before each line break, so backgrounds don't bleed into the next line.
+        """
+        ansi_start = _TOKEN_ANSI_START
+        no_color = _NO_COLOR_TYPES
+        reset = _RESET
+
+        gutters: dict[int, str] = {}
+        cells, blank_cells = gutter_cells(dict(self.config.line_data))
+        blank_gutter = "".join(f"{cell} " for cell in blank_cells)
+        gutter_width = len(blank_gutter)
+        if blank_gutter:
+            blank_gutter = f"{_LINENO_COLOR}{blank_gutter}{reset}"
+            for line, line_cells in cells.items():
+                gutters[line] = f"{_LINENO_COLOR}{''.join(f'{c} ' for c in line_cells)}{reset}"
+        continuation = blank_gutter
+        if self.config.show_linenos:
+            token_list = list(tokens)
+            tokens = iter(token_list)
+            numbers: list[int | None] = list(self.config.line_numbers)
+            if not numbers:
+                numbers = list(range(1, sum(t.value.count("\n") for t in token_list) + 2))
+            width = max((len(str(n)) for n in numbers if n is not None), default=1)
+            for line, number in enumerate(numbers, 1):
+                label = "" if number is None else str(number)
+                gutters[line] = (
+                    f"{gutters.get(line, blank_gutter)}{_LINENO_COLOR}{label:>{width}} │{reset} "
+                )
+            continuation += f"{_LINENO_COLOR}{'':>{width}} │{reset} "
+            gutter_width += width + 3
+        continuation += _CONTINUATION
+        limit = 0
+        if self.wrap:
+            columns = self.width or shutil.get_terminal_size().columns
+            limit = max(columns - gutter_width, _CONTINUATION_WIDTH + 1)
+
+        # A line opens at its first text, so the empty line after a final
+        # newline gets no line number or style
+        line = 1
+        style = ""
+        started = False
+        marks = self.config.marks
+        offset = 0
+        first_mark = 0
+        column = 0
+        for token in tokens:
+            color = None if token.type in no_color else ansi_start.get(token.type)
+            for index, piece in enumerate(token.value.split("\n")):
+                if index:
+                    if not started:
+                        yield gutters.get(line, blank_gutter)
+                    elif style:
+                        yield reset
+                    yield "\n"
+                    line += 1
+                    started = False
+                    offset += 1
+                    column = 0
+                if not piece:
+                    continue
+                if not started:
+                    started = True
+                    yield gutters.get(line, blank_gutter)
+                    style = line_styles.get(line, "")
+                    if style:
+                        yield style
+                if marks:
+                    segments, first_mark = split_marks(piece, offset, marks, first_mark)
+                else:
+                    segments = [(piece, None)]
+                offset += len(piece)
+                for text, mark in segments:
+                    sgr = f"{color or ''}{_MARK_STYLE}" if mark is not None else color
+                    if limit:
+                        chunks, column = _split_width(text, column, limit)
+                        if len(chunks) > 1:
+                            # Close colors before each break, reopen after the marker
+                            close = reset if sgr or style else ""
+                            reopen = f"{style}{sgr or ''}"
+                            text = f"{close}\n{continuation}{reopen}".join(chunks)
+                    if sgr:
+                        yield sgr
+                        yield text
+                        yield reset
+                        if style:
+                            yield style
+                    else:
+                        yield text
+        if started and style:
+            yield reset
+
     def format_string(
         self,
         tokens: Iterator[Token],
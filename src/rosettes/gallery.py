@@ -0,0 +1,232 @@
+"""Static HTML gallery of syntax palettes.
+
+Renders code samples in every registered palette, for choosing and
+reviewing themes:
+
+```python
+>>> from rosettes.gallery import render_gallery
+>>> samples = [(open("app.py").read(), "python"), ('{"a": 1}', "json")]
+>>> html = render_gallery(samples)
+>>> html = render_gallery(samples, palettes=["monokai", "github"])
+```
+
+`scripts/theme_gallery.py` renders the gallery from the fixture corpus
+(`tests/fixtures`).
+
+**Contents:**
+
+For each palette, the gallery shows:
+
+- The samples side by side, highlighted once and styled by the palette
+- A contrast table: each role's color, its WCAG contrast ratio against
+  the background and a grade (AAA, AA, AA large, fail)
+- The light and dark variants side by side for an `AdaptivePalette`
+- The palette's `generate_css()` output, for copy-paste
+
+Each variant's stylesheet is scoped to its section (`:root` becomes the
+section id and every selector is prefixed with it), so the palettes
+don't interfere on one page. The page is self-contained: no scripts or
+external files.
+
+**Thread-Safety:**
+
+Samples are highlighted with `highlight_many()`; everything else is
+local to the call.
+
+**See Also:**
+
+- `rosettes.themes`: Palette registry
+- `rosettes.themes.SyntaxPalette.generate_css`: Stylesheet generation
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable, Sequence
+
+from rosettes import highlight_many
+from rosettes._escape import escape_html
+from rosettes.themes import AdaptivePalette, SyntaxPalette, get_palette, list_palettes
+from rosettes.themes._palette import _COLOR_FIELDS, _contrast_ratio, _parse_hex
+
+__all__ = ["contrast_scores", "render_gallery"]
+
+_PAGE_CSS = """\
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #1f2328; background: #f6f8fa; }
+nav ul { display: flex; flex-wrap: wrap; gap: 0.5rem 1rem; padding: 0; list-style: none; }
+.palette { margin: 3rem 0; }
+.variants { display: flex; flex-wrap: wrap; gap: 1.5rem; }
+.variant { flex: 1 1 40rem; min-width: 0; }
+.previews {
+  display: grid; gap: 1rem; grid-template-columns: repeat(auto-fill, minmax(24rem, 1fr));
+}
+.previews figure { margin: 0; min-width: 0; }
+.previews figcaption { font-size: 0.85rem; margin-bottom: 0.25rem; }
+.previews .rosettes { border-radius: 6px; overflow: auto; }
+.previews pre { margin: 0; padding: 0.75rem; font-size: 0.8rem; }
+.contrast { border-collapse: collapse; margin-top: 1rem; font-size: 0.85rem; }
+.contrast th, .contrast td { padding: 0.2rem 0.75rem; text-align: left; }
+.swatch {
+  display: inline-block; width: 1em; height: 1em; vertical-align: middle; border: 1px solid #0003;
+}
+.grade-fail { color: #b60f07; font-weight: bold; }
+details pre { max-height: 24rem; overflow: auto; background: #fff; padding: 0.75rem; }"""
+
+
+def contrast_scores(palette: SyntaxPalette) -> dict[str, tuple[str, float | None]]:
+    """Contrast of each role's color against the palette background.
+
+    Args:
+        palette: Palette to score; empty roles are filled with defaults.
+
+    Returns:
+        (color, WCAG contrast ratio) by role name ("text", "string",
+        "import", ...). The ratio is None for colors that aren't hex.
+
+    Example:
+        >>> contrast_scores(SyntaxPalette("bw", "#ffffff", "#000000"))["text"]
+        ('#000000', 21.0)
+    """
+    filled = palette.with_defaults()
+    background = _parse_hex(filled.background)
+    scores: dict[str, tuple[str, float | None]] = {}
+    for name in ("text", *_COLOR_FIELDS):
+        if name == "background_highlight":
+            continue
+        color = getattr(filled, name)
+        rgb = _parse_hex(color)
+        ratio = None
+        if rgb is not None and background is not None:
+            ratio = round(_contrast_ratio(rgb, background), 2)
+        scores[name.rstrip("_")] = (color, ratio)
+    return scores
+
+
+def _grade(ratio: float | None) -> str:
+    if ratio is None:
+        return "n/a"
+    if ratio >= 7:
+        return "AAA"
+    if ratio >= 4.5:
+        return "AA"
+    if ratio >= 3:
+        return "AA large"
+    return "fail"
+
+
+def _slug(name: str) -> str:
+    """Make a palette name safe for ids and CSS selectors."""
+    return "".join(char if char.isascii() and char.isalnum() else "-" for char in name)
+
+
+def _scope_css(css: str, scope: str) -> str:
+    """Prefix every top-level selector with `scope`; `:root` becomes `scope`."""
+    lines: list[str] = []
+    for line in css.split("\n"):
+        if line.endswith(" {") and not line.startswith((" ", "@")):
+            selectors = line[:-2].split(", ")
+            line = ", ".join(scope if s == ":root" else f"{scope} {s}" for s in selectors) + " {"
+        lines.append(line)
+    return "\n".join(lines)
+
+
+def _contrast_table(palette: SyntaxPalette) -> str:
+    rows = []
+    for role, (color, ratio) in contrast_scores(palette).items():
+        grade = _grade(ratio)
+        ratio_text = "n/a" if ratio is None else f"{ratio:.2f}"
+        rows.append(
+            f"<tr><td>{role}</td>"
+            f'<td><span class="swatch" style="background: {escape_html(color)}"></span>'
+            f" <code>{escape_html(color)}</code></td>"
+            f'<td>{ratio_text}</td><td class="grade-{grade.replace(" ", "-").lower()}">{grade}</td>'
+            "</tr>"
+        )
+    return (
+        '<table class="contrast"><thead><tr><th>Role</th><th>Color</th><th>Contrast</th>'
+        f"<th>Grade</th></tr></thead><tbody>{''.join(rows)}</tbody></table>"
+    )
+
+
+def render_gallery(
+    samples: Sequence[tuple[str, str]],
+    palettes: Iterable[str] | None = None,
+    *,
+    title: str = "Rosettes Theme Gallery",
+    max_workers: int | None = None,
+) -> str:
+    """Render samples in each palette as a standalone HTML page.
+
+    Args:
+        samples: (code, language) tuples, shown in order.
+        palettes: Registered palette names. Defaults to list_palettes().
+        title: Page title.
+        max_workers: Threads for highlight_many().
+
+    Returns:
+        A complete HTML document.
+
+    Raises:
+        LookupError: If a language or palette is not registered.
+        ValueError: If there are no samples.
+    """
+    if not samples:
+        raise ValueError("render_gallery() needs at least one sample")
+    names = list(palettes) if palettes is not None else list_palettes()
+    blocks = highlight_many(samples, max_workers=max_workers)
+    previews = "".join(
+        f"<figure><figcaption>{escape_html(language)}</figcaption>{block}</figure>"
+        for (_, language), block in zip(samples, blocks)
+    )
+
+    styles = [_PAGE_CSS]
+    nav: list[str] = []
+    sections: list[str] = []
+    for name in names:
+        palette = get_palette(name)
+        slug = _slug(name)
+        variants: list[tuple[str, SyntaxPalette]]
+        if isinstance(palette, AdaptivePalette):
+            variants = [("light", palette.light), ("dark", palette.dark)]
+        else:
+            variants = [("", palette)]
+
+        label = escape_html(name)
+        parts = [f'<section class="palette" id="palette-{slug}"><h2>{label}</h2>']
+        parts.append('<div class="variants">')
+        for variant, variant_palette in variants:
+            scope = f"gallery-{slug}-{variant}" if variant else f"gallery-{slug}"
+            styles.append(_scope_css(variant_palette.generate_css(), f"#{scope}"))
+            heading = f"<h3>{variant}</h3>" if variant else ""
+            parts.append(
+                f'<div class="variant" id="{scope}">{heading}'
+                f'<div class="previews">{previews}</div>{_contrast_table(variant_palette)}</div>'
+            )
+        parts.append("</div>")
+        parts.append(
+            "<details><summary>CSS</summary>"
+            f"<pre><code>{escape_html(palette.generate_css())}</code></pre></details>"
+        )
+        parts.append("</section>")
+        sections.append("".join(parts))
+        nav.append(f'<li><a href="#palette-{slug}">{label}</a></li>')
+
+    return "\n".join(
+        [
+            "<!DOCTYPE html>",
+            '<html lang="en">',
+            "<head>",
+            '<meta charset="utf-8">',
+            f"<title>{escape_html(title)}</title>",
+            "<style>",
+            "\n".join(styles),
+            "</style>",
+            "</head>",
+            "<body>",
+            f"<h1>{escape_html(title)}</h1>",
+            f"<nav><ul>{''.join(nav)}</ul></nav>",
+            *sections,
+            "</body>",
+            "</html>",
+            "",
+        ]
+    )
@@ -0,0 +1,396 @@
+"""Language metadata catalog.
+
+Display names, aliases, file name patterns, MIME types, categories and
+comment syntax for every supported language, for language pickers,
+badges and editor integrations:
+
+```python
+>>> from rosettes.languages import get_language_info, iter_languages, languages_json
+>>> info = get_language_info("c++")
+>>> info.display_name, info.category, info.line_comment
+('C++', 'systems', '//')
+>>> [info.name for info in iter_languages(category="template")]
+['jinja', 'kida']
+>>> json_text = languages_json(indent=2)
+```
+
+**Static Data:**
+
+The catalog is a static table: looking languages up doesn't import any
+lexer module, so it's cheap to list every language at startup. A test
+keeps the file name patterns and MIME types in step with the lexer
+classes.
+
+**Categories:**
+
+`CATEGORIES` maps each category id to its label, in display order
+(`"core"` → "Core", `"ai-ml"` → "AI/ML", `"template"` → "Templates", ...).
+They follow the groupings of the lexer registry.
+
+**JSON Export:**
+
+`languages_json()` (or `rosettes languages --json`) returns the catalog
+as a JSON object with `categories` (id → label) and `languages` (a list
+of `LanguageInfo.to_dict()` objects), for frontends.
+
+**Thread-Safety:**
+
+All data is immutable.
+
+**See Also:**
+
+- `rosettes._registry`: Lexer registry and name resolution
+"""
+
+from __future__ import annotations
+
+import json
+from collections.abc import Iterator
+from dataclasses import dataclass
+
+from rosettes._registry import _LEXER_SPECS, _normalize_name
+
+__all__ = ["CATEGORIES", "LanguageInfo", "get_language_info", "iter_languages", "languages_json"]
+
+CATEGORIES: dict[str, str] = {
+    "core": "Core",
+    "systems": "Systems",
+    "jvm": "JVM",
+    "apple": "Apple",
+    "scripting": "Scripting",
+    "functional": "Functional",
+    "data": "Data and Query",
+    "markup": "Markup",
+    "config": "Configuration",
+    "schema": "Schema and IDL",
+    "modern": "Modern",
+    "ai-ml": "AI/ML",
+    "tree": "Trees",
+    "template": "Templates",
+    "text": "Plain Text",
+}
+
+
+@dataclass(frozen=True, slots=True)
+class LanguageInfo:
+    """Metadata for one language.
+
+    Attributes:
+        name: Canonical name, as accepted by highlight().
+        display_name: Human-readable name ("C++", "PowerShell").
+        category: Category id; see CATEGORIES.
+        aliases: Other names that resolve to this language.
+        filenames: File name patterns (`*.py`, `Dockerfile`), as used by
+            guess_language().
+        mimetypes: MIME types.
+        line_comment: Line comment marker, or None.
+        block_comment: Block comment (start, end) markers, or None.
+    """
+
+    name: str
+    display_name: str
+    category: str
+    aliases: tuple[str, ...] = ()
+    filenames: tuple[str, ...] = ()
+    mimetypes: tuple[str, ...] = ()
+    line_comment: str | None = None
+    block_comment: tuple[str, str] | None = None
+
+    def to_dict(self) -> dict[str, object]:
+        """JSON-compatible dict; tuples become lists."""
+        return {
+            "name": self.name,
+            "display_name": self.display_name,
+            "category": self.category,
+            "aliases": list(self.aliases),
+            "filenames": list(self.filenames),
+            "mimetypes": list(self.mimetypes),
+            "line_comment": self.line_comment,
+            "block_comment": list(self.block_comment) if self.block_comment else None,
+        }
+
+
+_C_BLOCK = ("/*", "*/")
+_HTML_BLOCK = ("<!--", "-->")
+_TEMPLATE_BLOCK = ("{#", "#}")
+
+
+@dataclass(frozen=True, slots=True)
+class _Meta:
+    """Catalog entry; name and aliases come from the lexer registry."""
+
+    display_name: str
+    category: str
+    filenames: tuple[str, ...] = ()
+    mimetypes: tuple[str, ...] = ()
+    line_comment: str | None = None
+    block_comment: tuple[str, str] | None = None
+
+
+def _c_like(
+    display_name: str, category: str, filenames: tuple[str, ...], mimetypes: tuple[str, ...]
+) -> _Meta:
+    return _Meta(display_name, category, filenames, mimetypes, "//", _C_BLOCK)
+
+
+def _hash(
+    display_name: str, category: str, filenames: tuple[str, ...], mimetypes: tuple[str, ...]
+) -> _Meta:
+    return _Meta(display_name, category, filenames, mimetypes, "#")
+
+
+# Keyed by canonical name, grouped like _LEXER_SPECS
+_CATALOG: dict[str, _Meta] = {
+    # Core
+    "python": _hash(
+        "Python", "core", ("*.py", "*.pyw", "*.pyi"), ("text/x-python", "application/x-python")
+    ),
+    "javascript": _c_like(
+        "JavaScript",
+        "core",
+        ("*.js", "*.mjs", "*.cjs"),
+        ("text/javascript", "application/javascript"),
+    ),
+    "typescript": _c_like(
+        "TypeScript",
+        "core",
+        ("*.ts", "*.tsx", "*.mts", "*.cts"),
+        ("text/typescript", "application/typescript"),
+    ),
+    "json": _Meta("JSON", "core", ("*.json",), ("application/json",)),
+    "yaml": _hash("YAML", "core", ("*.yaml", "*.yml"), ("text/yaml", "application/x-yaml")),
+    "toml": _hash("TOML", "core", ("*.toml",), ("application/toml",)),
+    "bash": _hash(
+        "Bash",
+        "core",
+        ("*.sh", "*.bash", "*.zsh", ".bashrc", ".zshrc", ".profile"),
+        ("application/x-sh", "text/x-shellscript"),
+    ),
+    "html": _Meta("HTML", "core", ("*.html", "*.htm"), ("text/html",), None, _HTML_BLOCK),
+    "css": _Meta("CSS", "core", ("*.css",), ("text/css",), None, _C_BLOCK),
+    "scss": _c_like("SCSS", "core", ("*.scss",), ("text/x-scss",)),
+    "sass": _c_like("Sass", "core", ("*.sass",), ("text/x-sass",)),
+    "less": _c_like("Less", "core", ("*.less",), ("text/x-less-css",)),
+    "stylus": _c_like("Stylus", "core", ("*.styl",), ("text/x-styl",)),
+    "diff": _Meta("Diff", "core", ("*.diff", "*.patch"), ("text/x-diff", "text/x-patch")),
+    # Systems
+    "c": _c_like("C", "systems", ("*.c", "*.h"), ("text/x-c",)),
+    "cpp": _c_like(
+        "C++", "systems", ("*.cpp", "*.hpp", "*.cc", "*.hh", "*.cxx", "*.hxx"), ("text/x-c++",)
+    ),
+    "rust": _c_like("Rust", "systems", ("*.rs",), ("text/rust", "text/x-rust")),
+    "go": _c_like("Go", "systems", ("*.go",), ("text/x-go",)),
+    "zig": _Meta("Zig", "systems", ("*.zig",), ("text/x-zig",), "//"),
+    # JVM
+    "java": _c_like("Java", "jvm", ("*.java",), ("text/x-java",)),
+    "kotlin": _c_like("Kotlin", "jvm", ("*.kt", "*.kts"), ("text/x-kotlin",)),
+    "scala": _c_like("Scala", "jvm", ("*.scala", "*.sc"), ("text/x-scala",)),
+    "groovy": _c_like("Groovy", "jvm", ("*.groovy", "*.gradle"), ("text/x-groovy",)),
+    "clojure": _Meta(
+        "Clojure",
+        "jvm",
+        ("*.clj", "*.cljs", "*.cljc", "*.edn"),
+        ("text/x-clojure", "application/x-clojure"),
+        ";",
+    ),
+    # Apple
+    "swift": _c_like("Swift", "apple", ("*.swift",), ("text/x-swift",)),
+    # Scripting
+    "ruby": _Meta(
+        "Ruby",
+        "scripting",
+        ("*.rb", "*.rake", "*.gemspec", "Rakefile", "Gemfile"),
+        ("text/x-ruby", "application/x-ruby"),
+        "#",
+        ("=begin", "=end"),
+    ),
+    "perl": _hash(
+        "Perl", "scripting", ("*.pl", "*.pm", "*.t"), ("text/x-perl", "application/x-perl")
+    ),
+    "php": _c_like(
+        "PHP",
+        "scripting",
+        ("*.php", "*.php3", "*.php4", "*.php5", "*.phtml"),
+        ("text/x-php", "application/x-php"),
+    ),
+    "lua": _Meta(
+        "Lua",
+        "scripting",
+        ("*.lua", "*.wlua"),
+        ("text/x-lua", "application/x-lua"),
+        "--",
+        ("--[[", "]]"),
+    ),
+    "r": _hash("R", "scripting", ("*.R", "*.r", "*.Rmd"), ("text/x-r",)),
+    "powershell": _Meta(
+        "PowerShell",
+        "scripting",
+        ("*.ps1", "*.psm1", "*.psd1"),
+        ("text/x-powershell",),
+        "#",
+        ("<#", "#>"),
+    ),
+    # Functional
+    "haskell": _Meta(
+        "Haskell", "functional", ("*.hs", "*.lhs"), ("text/x-haskell",), "--", ("{-", "-}")
+    ),
+    "elixir": _hash("Elixir", "functional", ("*.ex", "*.exs"), ("text/x-elixir",)),
+    # Data and query
+    "sql": _Meta("SQL", "data", ("*.sql",), ("text/x-sql",), "--", _C_BLOCK),
+    "csv": _Meta("CSV", "data", ("*.csv", "*.tsv"), ("text/csv", "text/tab-separated-values")),
+    "graphql": _hash("GraphQL", "data", ("*.graphql", "*.gql"), ("application/graphql",)),
+    # Markup
+    "markdown": _Meta(
+        "Markdown", "markup", ("*.md", "*.markdown"), ("text/markdown",), None, _HTML_BLOCK
+    ),
+    "xml": _Meta(
+        "XML",
+        "markup",
+        ("*.xml", "*.xsl", "*.xslt", "*.rss", "*.atom", "*.svg"),
+        ("text/xml", "application/xml", "image/svg+xml"),
+        None,
+        _HTML_BLOCK,
+    ),
+    # Configuration
+    "ini": _Meta(
+        "INI",
+        "config",
+        ("*.ini", "*.cfg", "*.conf", ".editorconfig", ".gitconfig"),
+        ("text/x-ini",),
+        ";",
+    ),
+    "nginx": _hash(
+        "Nginx", "config", ("nginx.conf", "*.nginx", "*.nginxconf"), ("text/x-nginx-conf",)
+    ),
+    "dockerfile": _hash(
+        "Dockerfile",
+        "config",
+        ("Dockerfile", "*.dockerfile", "Dockerfile.*"),
+        ("text/x-dockerfile",),
+    ),
+    "makefile": _hash(
+        "Makefile",
+        "config",
+        ("Makefile", "makefile", "GNUmakefile", "*.mk", "*.mak"),
+        ("text/x-makefile",),
+    ),
+    "hcl": _Meta(
+        "HCL",
+        "config",
+        ("*.tf", "*.tfvars", "*.hcl"),
+        ("text/x-hcl", "application/x-terraform"),
+        "#",
+        _C_BLOCK,
+    ),
+    "pkl": _c_like("Pkl", "config", ("*.pkl",), ("text/x-pkl",)),
+    "cue": _Meta("CUE", "config", ("*.cue",), ("text/x-cue",), "//"),
+    # Schema and IDL
+    "protobuf": _c_like("Protocol Buffers", "schema", ("*.proto",), ("text/x-protobuf",)),
+    # Modern
+    "dart": _c_like("Dart", "modern", ("*.dart",), ("application/dart", "text/x-dart")),
+    "julia": _Meta(
+        "Julia",
+        "modern",
+        ("*.jl",),
+        ("text/x-julia", "application/x-julia"),
+        "#",
+        ("#=", "=#"),
+    ),
+    "nim": _Meta(
+        "Nim", "modern", ("*.nim", "*.nims", "*.nimble"), ("text/x-nim",), "#", ("#[", "]#")
+    ),
+    "gleam": _Meta("Gleam", "modern", ("*.gleam",), ("text/x-gleam",), "//"),
+    "v": _c_like("V", "modern", ("*.v", "*.vv"), ("text/x-v",)),
+    # AI/ML
+    "mojo": _hash("Mojo", "ai-ml", ("*.mojo", "*.🔥"), ("text/x-mojo",)),
+    "triton": _hash("Triton", "ai-ml", ("*.triton",), ("text/x-triton",)),
+    "cuda": _c_like("CUDA", "ai-ml", ("*.cu", "*.cuh"), ("text/x-cuda",)),
+    "stan": _c_like("Stan", "ai-ml", ("*.stan",), ("text/x-stan",)),
+    # Trees
+    "tree": _Meta("Tree-sitter Query", "tree", ("*.scm",), ("text/x-tree-sitter-query",), ";"),
+    "tree-listing": _Meta("Directory Tree", "tree"),
+    # Templates
+    "kida": _Meta(
+        "Kida",
+        "template",
+        ("*.kida", "*.kida.html"),
+        ("application/x-kida", "text/x-kida"),
+        None,
+        _TEMPLATE_BLOCK,
+    ),
+    "jinja": _Meta(
+        "Jinja",
+        "template",
+        ("*.jinja", "*.jinja2", "*.j2"),
+        ("application/x-jinja", "text/x-jinja"),
+        None,
+        _TEMPLATE_BLOCK,
+    ),
+    # Plain text
+    "plaintext": _Meta("Plain Text", "text", ("*.txt",), ("text/plain",)),
+}
+
+
+def get_language_info(name: str) -> LanguageInfo:
+    """Get a language's metadata by name or alias.
+
+    Args:
+        name: Language name or alias (case-insensitive).
+
+    Returns:
+        The language's metadata.
+
+    Raises:
+        UnknownLanguageError: If the language is not supported.
+
+    Example:
+        >>> get_language_info("rs").display_name
+        'Rust'
+    """
+    canonical = _normalize_name(name)
+    info = _CATALOG[canonical]
+    return LanguageInfo(
+        canonical,
+        info.display_name,
+        info.category,
+        _LEXER_SPECS[canonical].aliases,
+        info.filenames,
+        info.mimetypes,
+        info.line_comment,
+        info.block_comment,
+    )
+
+
+def iter_languages(*, category: str | None = None) -> Iterator[LanguageInfo]:
+    """Iterate over language metadata, sorted by name.
+
+    Args:
+        category: Only yield languages in this category (see CATEGORIES).
+
+    Raises:
+        ValueError: If the category is unknown.
+    """
+    if category is not None and category not in CATEGORIES:
+        raise ValueError(f"Unknown category: {category!r}. Expected one of {list(CATEGORIES)}")
+    for name in sorted(_CATALOG):
+        if category is None or _CATALOG[name].category == category:
+            yield get_language_info(name)
+
+
+def languages_json(*, indent: int | None = None) -> str:
+    """Export the catalog as JSON for frontends.
+
+    Args:
+        indent: Indentation passed to json.dumps(); None for compact output.
+
+    Returns:
+        A JSON object: `{"categories": {id: label}, "languages": [...]}`.
+    """
+    return json.dumps(
+        {
+            "categories": CATEGORIES,
+            "languages": [info.to_dict() for info in iter_languages()],
+        },
+        indent=indent,
+        ensure_ascii=False,
+    )
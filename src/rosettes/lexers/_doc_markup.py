@@ -0,0 +1,553 @@
+"""Markup scanning inside doc comments and docstrings.
+
+Lexers recognize doc comments (`/** */`, `///`, `//!`) as COMMENT_DOC and
+docstrings as STRING_DOC. This module refines those tokens by splitting
+out the documentation markup written inside them.
+
+**Recognized Markup:**
+
+- Block tags at line start: `@param name`, `@returns {Type}`,
+  `@throws Error` (Javadoc, JSDoc, KDoc, PHPDoc), and Doxygen `\\param`
+- Inline tags: `{@link Target label}`, `{@code expr}`
+- reST fields and roles: `:param x:`, `:raises ValueError:`, ``:class:`Foo` ``
+- Google-style sections: `Args:`, `Returns:`, `Raises:` and their entries
+- Links: rustdoc intra-doc links ``[`Foo`]`` and `[Foo]` (comments only),
+  and Markdown `[text](url)` / `[text][ref]`
+- Inline code: `` `code` `` and reST ``` ``literal`` ```
+
+**Token Types:**
+
+- NAME_DECORATOR: tags, field markers, section headers
+- NAME_VARIABLE: documented parameter names
+- KEYWORD_TYPE: documented types (`{string}`, `(int)`, exception names)
+- NAME_ENTITY: link targets
+- STRING_BACKTICK: inline code
+
+Text outside the markup keeps the doc token's type.
+
+**Performance:**
+
+Scanning is a single pass per line with no backtracking past the end of
+the current line. Comments without any markup yield one token.
+
+**Thread-Safety:**
+
+Pure functions over the input string; all tables are immutable.
+
+**See Also:**
+
+- `rosettes.lexers._scanners.is_doc_comment`: Doc comment detection
+- `rosettes.lexers._scanners.split_escapes`: Escape splitting for docstrings
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+
+from rosettes._types import Token, TokenType
+from rosettes.lexers._scanners import (
+    IDENT_CONT,
+    EscapeConfig,
+    split_escapes,
+)
+
+__all__ = ["scan_doc_markup", "split_doc_markup"]
+
+_TAG = TokenType.NAME_DECORATOR
+_PARAM = TokenType.NAME_VARIABLE
+_TYPE = TokenType.KEYWORD_TYPE
+_LINK = TokenType.NAME_ENTITY
+_CODE = TokenType.STRING_BACKTICK
+
+# Block tags followed by a parameter name
+_PARAM_TAGS: frozenset[str] = frozenset(
+    {"param", "arg", "argument", "tparam", "typeparam", "property", "prop", "field"}
+)
+
+# Block tags followed by a type name
+_TYPE_TAGS: frozenset[str] = frozenset({"throws", "throw", "exception", "raise", "raises"})
+
+# Block and inline tags whose argument is a link target
+_LINK_TAGS: frozenset[str] = frozenset({"see", "link", "linkplain", "linkcode", "tutorial"})
+
+# Inline tags whose argument is code
+_CODE_TAGS: frozenset[str] = frozenset({"code", "literal", "c"})
+
+# reST fields naming a parameter (last argument) and optional type
+_REST_PARAM_FIELDS: frozenset[str] = frozenset(
+    {"param", "parameter", "arg", "argument", "key", "keyword", "type", "var", "ivar", "cvar"}
+)
+
+# reST fields naming an exception type
+_REST_TYPE_FIELDS: frozenset[str] = frozenset({"raises", "raise", "except", "exception"})
+
+# Google-style section headers → what their entries name (None: free text)
+_GOOGLE_SECTIONS: dict[str, TokenType | None] = {
+    "Args:": _PARAM,
+    "Arguments:": _PARAM,
+    "Parameters:": _PARAM,
+    "Params:": _PARAM,
+    "Keyword Args:": _PARAM,
+    "Keyword Arguments:": _PARAM,
+    "Other Parameters:": _PARAM,
+    "Attributes:": _PARAM,
+    "Raises:": _TYPE,
+    "Returns:": None,
+    "Return:": None,
+    "Yields:": None,
+    "Yield:": None,
+    "Example:": None,
+    "Examples:": None,
+    "Note:": None,
+    "Notes:": None,
+    "Warning:": None,
+    "Warnings:": None,
+    "See Also:": None,
+    "Todo:": None,
+    "References:": None,
+}
+
+# Characters allowed in a bare `[Path]` intra-doc link
+_PATH_CHARS: frozenset[str] = IDENT_CONT | frozenset(":.!()<>")
+
+# Characters allowed in a dotted name (types, @see targets)
+_DOTTED_CHARS: frozenset[str] = IDENT_CONT | frozenset(".$")
+
+# Characters allowed in a link tag target (`Class#method(int)`)
+_LINK_CHARS: frozenset[str] = _DOTTED_CHARS | frozenset("#(),:")
+
+# Characters that make a following `[` an index rather than a link
+_INDEX_PRECEDERS: frozenset[str] = IDENT_CONT | frozenset("]")
+
+# Comment decoration skipped at the start of each line
+_DECORATION: frozenset[str] = frozenset("/*!#")
+
+
+def scan_doc_markup(
+    text: str,
+    *,
+    comment: bool = True,
+) -> list[tuple[int, int, TokenType]]:
+    """Find documentation markup in a doc comment or docstring.
+
+    Args:
+        text: Full text of the doc token, including delimiters.
+        comment: Whether `text` is a comment. Comment decoration (`*`,
+            `///`) is skipped at line starts, and comment-only markup
+            (Doxygen `\\param`, bare `[Path]` links) is recognized.
+
+    Returns:
+        Sorted, non-overlapping `(start, end, type)` offsets into `text`.
+    """
+    spans: list[tuple[int, int, TokenType]] = []
+    length = len(text)
+    section_indent = -1
+    section_kind: TokenType | None = None
+    entry_indent = -1
+    pos = 0
+
+    while pos < length:
+        eol = text.find("\n", pos)
+        if eol == -1:
+            eol = length
+        line_start = pos
+
+        while pos < eol and text[pos] in " \t":
+            pos += 1
+        if comment:
+            while pos < eol and text[pos] in _DECORATION:
+                pos += 1
+            while pos < eol and text[pos] in " \t":
+                pos += 1
+
+        if pos == eol:
+            pos = eol + 1
+            continue
+        indent = pos - line_start
+
+        if section_indent >= 0 and indent <= section_indent:
+            section_indent = -1
+            section_kind = None
+
+        header = text[pos:eol].rstrip()
+        if header in _GOOGLE_SECTIONS:
+            spans.append((pos, pos + len(header), _TAG))
+            section_indent = indent
+            section_kind = _GOOGLE_SECTIONS[header]
+            entry_indent = -1
+            pos = eol + 1
+            continue
+
+        if section_kind is not None:
+            if entry_indent < 0:
+                entry_indent = indent
+            if indent == entry_indent:
+                pos = _scan_section_entry(text, pos, eol, section_kind, spans)
+
+        char = text[pos] if pos < eol else ""
+        if char == "@" or (char == "\\" and comment):
+            pos = _scan_block_tag(text, pos, eol, spans)
+        elif char == ":":
+            pos = _scan_rest_field(text, pos, eol, spans)
+
+        _scan_inline(text, pos, eol, line_start, spans, comment)
+        pos = eol + 1
+
+    return spans
+
+
+def split_doc_markup(
+    code: str,
+    start: int,
+    end: int,
+    token_type: TokenType,
+    line: int,
+    col: int,
+    escapes: EscapeConfig | None = None,
+) -> Iterator[Token]:
+    """Emit the doc comment or docstring code[start:end] with its markup split out.
+
+    Args:
+        start: Position of the doc token (including delimiters).
+        end: Position after the doc token.
+        token_type: COMMENT_DOC or STRING_DOC; used for non-markup text.
+        line: Line of the token's first character.
+        col: Column of the token's first character.
+        escapes: Escape configuration for docstrings; text between markup
+            is split with `split_escapes()`. None for comments and raw strings.
+
+    Yields:
+        Tokens covering code[start:end] in order.
+    """
+    spans = scan_doc_markup(code[start:end], comment=token_type != TokenType.STRING_DOC)
+    seg = start
+
+    for span_start, span_end, span_type in spans:
+        span_start += start
+        span_end += start
+        if span_start > seg:
+            yield from _split_plain(code, seg, span_start, token_type, line, col, escapes)
+            line, col = _advance(code, seg, span_start, line, col)
+        yield Token(span_type, code[span_start:span_end], line, col)
+        line, col = _advance(code, span_start, span_end, line, col)
+        seg = span_end
+
+    if seg < end or seg == start:
+        yield from _split_plain(code, seg, end, token_type, line, col, escapes)
+
+
+def _split_plain(
+    code: str,
+    start: int,
+    end: int,
+    token_type: TokenType,
+    line: int,
+    col: int,
+    escapes: EscapeConfig | None,
+) -> Iterator[Token]:
+    """Emit text between markup spans, splitting escapes when configured."""
+    if escapes is None:
+        yield Token(token_type, code[start:end], line, col)
+    else:
+        yield from split_escapes(code, start, end, token_type, line, col, escapes)
+
+
+def _advance(code: str, start: int, end: int, line: int, col: int) -> tuple[int, int]:
+    """Return the line and column just after code[start:end]."""
+    newlines = code.count("\n", start, end)
+    if newlines:
+        return line + newlines, end - code.rfind("\n", start, end)
+    return line, col + end - start
+
+
+def _scan_word(text: str, pos: int, end: int, chars: frozenset[str]) -> int:
+    """Advance past characters in `chars`, stopping at `end`."""
+    while pos < end and text[pos] in chars:
+        pos += 1
+    return pos
+
+
+def _skip_blanks(text: str, pos: int, end: int) -> int:
+    """Advance past spaces and tabs."""
+    while pos < end and text[pos] in " \t":
+        pos += 1
+    return pos
+
+
+def _scan_braced_type(
+    text: str,
+    pos: int,
+    end: int,
+    spans: list[tuple[int, int, TokenType]],
+) -> int:
+    """Scan a JSDoc `{Type}` expression, allowing nested braces."""
+    depth = 0
+    scan = pos
+    while scan < end:
+        char = text[scan]
+        if char == "{":
+            depth += 1
+        elif char == "}":
+            depth -= 1
+            if depth == 0:
+                spans.append((pos, scan + 1, _TYPE))
+                return scan + 1
+        scan += 1
+    return pos
+
+
+def _scan_block_tag(
+    text: str,
+    pos: int,
+    end: int,
+    spans: list[tuple[int, int, TokenType]],
+) -> int:
+    """Scan `@tag` (or Doxygen `\\tag`) and its name or type argument."""
+    tag_end = _scan_word(text, pos + 1, end, IDENT_CONT)
+    if tag_end == pos + 1 or not text[pos + 1].isalpha():
+        return pos
+    spans.append((pos, tag_end, _TAG))
+    name = text[pos + 1 : tag_end].lower()
+
+    scan = _skip_blanks(text, tag_end, end)
+    if scan < end and text[scan] == "{":
+        after = _scan_braced_type(text, scan, end, spans)
+        if after == scan:
+            return tag_end
+        tag_end = after
+        scan = _skip_blanks(text, after, end)
+
+    if scan >= end:
+        return tag_end
+
+    if name in _PARAM_TAGS:
+        if text[scan] in "<[":
+            # Java type parameter `<T>` or JSDoc optional `[name=default]`
+            close = text.find(">" if text[scan] == "<" else "]", scan, end)
+            if close == -1:
+                return tag_end
+            arg_end = close + 1
+        else:
+            arg_end = _scan_word(text, scan, end, _DOTTED_CHARS)
+        kind = _PARAM
+    elif name in _TYPE_TAGS:
+        arg_end = _scan_word(text, scan, end, _DOTTED_CHARS)
+        kind = _TYPE
+    elif name in _LINK_TAGS:
+        arg_end = _scan_word(text, scan, end, _LINK_CHARS)
+        kind = _LINK
+    else:
+        return tag_end
+
+    if arg_end == scan:
+        return tag_end
+    spans.append((scan, arg_end, kind))
+    return arg_end
+
+
+def _scan_rest_field(
+    text: str,
+    pos: int,
+    end: int,
+    spans: list[tuple[int, int, TokenType]],
+) -> int:
+    """Scan a reST field list marker such as `:param int x:` or `:returns:`."""
+    close = text.find(":", pos + 1, end)
+    if close == -1 or close == pos + 1 or not text[pos + 1].isalpha():
+        return pos
+    if close + 1 < end and text[close + 1] not in " \t":
+        # `:role:` followed by text is a role, not a field
+        return pos
+
+    words = text[pos + 1 : close].split()
+    name_end = _scan_word(text, pos + 1, close, IDENT_CONT)
+    if not words or name_end != pos + 1 + len(words[0]):
+        return pos
+
+    field = words[0].lower()
+    spans.append((pos, name_end, _TAG))
+    if field in _REST_PARAM_FIELDS or field in _REST_TYPE_FIELDS:
+        scan = name_end
+        for index, word in enumerate(words[1:], start=1):
+            scan = text.find(word, scan, close)
+            if field in _REST_TYPE_FIELDS or index < len(words) - 1:
+                kind = _TYPE
+            else:
+                kind = _PARAM
+            spans.append((scan, scan + len(word), kind))
+            scan += len(word)
+    spans.append((close, close + 1, _TAG))
+    return close + 1
+
+
+def _scan_section_entry(
+    text: str,
+    pos: int,
+    end: int,
+    kind: TokenType,
+    spans: list[tuple[int, int, TokenType]],
+) -> int:
+    """Scan a Google-style entry such as `name (int): description`."""
+    name_start = pos
+    while pos < end and text[pos] == "*" and pos - name_start < 2:
+        pos += 1
+    name_end = _scan_word(text, pos, end, _DOTTED_CHARS)
+    if name_end == pos:
+        return name_start
+
+    scan = name_end
+    type_span = None
+    if scan + 1 < end and text[scan] == " " and text[scan + 1] == "(":
+        close = text.find(")", scan + 2, end)
+        if close == -1:
+            return name_start
+        type_span = (scan + 2, close, _TYPE)
+        scan = close + 1
+
+    if scan >= end or text[scan] != ":":
+        return name_start
+
+    spans.append((name_start, name_end, kind))
+    if type_span is not None and type_span[1] > type_span[0]:
+        spans.append(type_span)
+    return scan + 1
+
+
+def _scan_inline(
+    text: str,
+    pos: int,
+    end: int,
+    line_start: int,
+    spans: list[tuple[int, int, TokenType]],
+    comment: bool,
+) -> None:
+    """Scan inline code, links, inline tags, and reST roles within a line."""
+    while pos < end:
+        char = text[pos]
+
+        if char == "`":
+            pos = _scan_code(text, pos, end, spans)
+            continue
+
+        if char == "[" and (pos == line_start or text[pos - 1] not in _INDEX_PRECEDERS):
+            pos = _scan_link(text, pos, end, spans, comment)
+            continue
+
+        if char == "{" and pos + 1 < end and text[pos + 1] == "@":
+            pos = _scan_inline_tag(text, pos, end, spans)
+            continue
+
+        if char == ":" and (pos == line_start or text[pos - 1] not in IDENT_CONT):
+            pos = _scan_role(text, pos, end, spans)
+            continue
+
+        pos += 1
+
+
+def _scan_code(
+    text: str,
+    pos: int,
+    end: int,
+    spans: list[tuple[int, int, TokenType]],
+) -> int:
+    """Scan backtick inline code; the closing run must match the opening run."""
+    run_end = pos
+    while run_end < end and text[run_end] == "`":
+        run_end += 1
+    fence = text[pos:run_end]
+
+    close = text.find(fence, run_end, end)
+    if close == -1 or close == run_end:
+        return run_end
+    spans.append((pos, close + len(fence), _CODE))
+    return close + len(fence)
+
+
+def _scan_link(
+    text: str,
+    pos: int,
+    end: int,
+    spans: list[tuple[int, int, TokenType]],
+    comment: bool,
+) -> int:
+    """Scan a rustdoc intra-doc link or Markdown link."""
+    if pos + 1 < end and text[pos + 1] == "`":
+        # [`Path`] — find the closing backtick run first, since the path
+        # itself may contain brackets (`[u8]`)
+        close = text.find("`]", pos + 2, end)
+        if close == -1:
+            return pos + 1
+        close += 1
+    else:
+        close = text.find("]", pos + 1, end)
+        if close == -1 or close == pos + 1:
+            return pos + 1
+
+    after = close + 1
+    if after < end and text[after] in "([":
+        # [text](url) or [text][ref]
+        target_close = text.find(")" if text[after] == "(" else "]", after + 1, end)
+        if target_close != -1:
+            spans.append((pos, target_close + 1, _LINK))
+            return target_close + 1
+
+    inner = text[pos + 1 : close]
+    if inner[0] == "`" and inner[-1] == "`" and len(inner) > 2:
+        spans.append((pos, close + 1, _LINK))
+        return close + 1
+    if comment and (inner[0].isalpha() or inner[0] == "_") and all(c in _PATH_CHARS for c in inner):
+        spans.append((pos, close + 1, _LINK))
+        return close + 1
+    return pos + 1
+
+
+def _scan_inline_tag(
+    text: str,
+    pos: int,
+    end: int,
+    spans: list[tuple[int, int, TokenType]],
+) -> int:
+    """Scan `{@link Target label}` or `{@code expr}`."""
+    close = text.find("}", pos + 2, end)
+    tag_end = _scan_word(text, pos + 2, end, IDENT_CONT)
+    if close == -1 or tag_end == pos + 2:
+        return pos + 1
+
+    spans.append((pos, tag_end, _TAG))
+    name = text[pos + 2 : tag_end].lower()
+    scan = _skip_blanks(text, tag_end, close)
+
+    if scan < close:
+        if name in _CODE_TAGS:
+            spans.append((scan, close, _CODE))
+        elif name in _LINK_TAGS:
+            target_end = scan
+            while target_end < close and text[target_end] not in " \t|":
+                target_end += 1
+            spans.append((scan, target_end, _LINK))
+
+    spans.append((close, close + 1, _TAG))
+    return close + 1
+
+
+def _scan_role(
+    text: str,
+    pos: int,
+    end: int,
+    spans: list[tuple[int, int, TokenType]],
+) -> int:
+    """Scan a reST/Sphinx role such as :class:`Foo` or :py:func:`bar`."""
+    scan = pos + 1
+    while scan < end and (text[scan] in IDENT_CONT or text[scan] in ":-."):
+        if text[scan] == ":" and scan + 1 < end and text[scan + 1] == "`":
+            break
+        scan += 1
+    if scan == pos + 1 or scan + 1 >= end or text[scan] != ":" or text[scan + 1] != "`":
+        return pos + 1
+
+    close = text.find("`", scan + 2, end)
+    if close == -1:
+        return pos + 1
+    spans.append((pos, scan + 1, _TAG))
+    spans.append((scan + 1, close + 1, _LINK))
+    return close + 1
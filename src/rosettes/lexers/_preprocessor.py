@@ -0,0 +1,175 @@
+"""C preprocessor directive scanning shared by the C, C++ and CUDA lexers.
+
+A directive runs from `#` to the end of its logical line, following
+backslash-newline continuations. `scan_directive()` tokenizes the parts
+that are specific to preprocessing and tells the lexer where to resume:
+
+- `#include <stdio.h>` → COMMENT_PREPROC, COMMENT_PREPROCFILE; the rest of
+  the line (e.g., a trailing comment) is lexed normally
+- `#define NAME(x) body` → COMMENT_PREPROC, NAME_FUNCTION_MAGIC; the
+  replacement list is lexed as code (with `#`/`##` as operators)
+- `#ifdef NAME`, `#ifndef NAME`, `#undef NAME` → the macro name is
+  NAME_FUNCTION_MAGIC
+- `#if 0` → the lexer emits the disabled region up to the matching
+  `#else`, `#elif` or `#endif` as a single COMMENT token
+- Any other directive (`#pragma once`, `#if X > 2`) → COMMENT_PREPROC up to
+  a trailing comment
+
+**Thread-Safety:**
+
+Pure functions over their arguments.
+"""
+
+from __future__ import annotations
+
+from rosettes._types import Token, TokenType
+from rosettes.lexers._scanners import IDENT_START, scan_identifier
+
+__all__ = ["scan_directive", "scan_logical_line_end"]
+
+_INCLUDE_DIRECTIVES = frozenset({"include", "include_next", "import"})
+_MACRO_DIRECTIVES = frozenset({"define", "undef", "ifdef", "ifndef"})
+_CONDITIONAL_OPENERS = frozenset({"if", "ifdef", "ifndef"})
+
+
+def scan_logical_line_end(code: str, pos: int, length: int) -> int:
+    """Find the end of the logical line at pos, following `\\` continuations.
+
+    Returns:
+        Position of the terminating newline, or length.
+    """
+    while True:
+        newline = code.find("\n", pos, length)
+        if newline == -1:
+            return length
+        before = newline - 1
+        if before >= pos and code[before] == "\r":
+            before -= 1
+        if before < pos or code[before] != "\\":
+            return newline
+        pos = newline + 1
+
+
+def _skip_blanks(code: str, pos: int, end: int) -> int:
+    while pos < end and code[pos] in " \t":
+        pos += 1
+    return pos
+
+
+def _rest_end(code: str, pos: int, end: int) -> int:
+    """Find where a directive's text ends: a trailing comment or the line end.
+
+    Double-quoted strings are skipped so `"//"` in `#error` text is kept.
+    """
+    while pos < end:
+        char = code[pos]
+        if char == '"':
+            close = code.find('"', pos + 1, end)
+            if close == -1:
+                return end
+            pos = close + 1
+            continue
+        if char == "/" and pos + 1 < end and code[pos + 1] in "/*":
+            return pos
+        pos += 1
+    return end
+
+
+def _disabled_region_end(code: str, pos: int, length: int) -> int:
+    """Find the start of the `#else`/`#elif`/`#endif` line closing an `#if 0` block.
+
+    Nested conditionals inside the block are skipped.
+
+    Returns:
+        Position of that line's start, or length if the block is unterminated.
+    """
+    depth = 0
+    line_start = pos
+    while line_start < length:
+        hash_pos = _skip_blanks(code, line_start, length)
+        if hash_pos < length and code[hash_pos] == "#":
+            name_start = _skip_blanks(code, hash_pos + 1, length)
+            name = code[name_start : scan_identifier(code, name_start)]
+            if name in _CONDITIONAL_OPENERS:
+                depth += 1
+            elif name == "endif":
+                if depth == 0:
+                    return line_start
+                depth -= 1
+            elif name in ("else", "elif", "elifdef", "elifndef") and depth == 0:
+                return line_start
+        line_end = scan_logical_line_end(code, line_start, length)
+        line_start = line_end + 1
+    return length
+
+
+def scan_directive(
+    code: str,
+    pos: int,
+    length: int,
+    line: int,
+    col: int,
+) -> tuple[list[Token], int, int, int]:
+    """Tokenize the preprocessor-specific head of the directive at pos.
+
+    Args:
+        code: Source code.
+        pos: Position of the `#`.
+        length: End of the region being lexed.
+        line: Line of the `#`.
+        col: Column of the `#`.
+
+    Returns:
+        Tuple of (tokens, resume position, directive end, disabled end).
+        The text of a directive continued with `\\` stays in one token.
+        The lexer emits the tokens, continues lexing at the resume
+        position, and treats everything before the directive end as part
+        of the directive. For `#if 0`, the disabled end is where the
+        disabled region that follows the directive line stops (-1 for
+        other directives); the lexer emits that region as one COMMENT.
+    """
+    directive_end = scan_logical_line_end(code, pos, length)
+    name_start = _skip_blanks(code, pos + 1, directive_end)
+    name_end = name_start
+    if name_start < directive_end and code[name_start] in IDENT_START:
+        name_end = scan_identifier(code, name_start)
+    name = code[name_start:name_end]
+    tokens = [Token(TokenType.COMMENT_PREPROC, code[pos:name_end], line, col)]
+
+    arg_start = _skip_blanks(code, name_end, directive_end)
+    arg_col = col + arg_start - pos
+    if arg_start > name_end:
+        whitespace = code[name_end:arg_start]
+        tokens.append(Token(TokenType.WHITESPACE, whitespace, line, col + name_end - pos))
+
+    # #include <file> / "file"
+    if name in _INCLUDE_DIRECTIVES and arg_start < directive_end and code[arg_start] in '<"':
+        close = code.find(">" if code[arg_start] == "<" else '"', arg_start + 1, directive_end)
+        target_end = close + 1 if close != -1 else directive_end
+        target = code[arg_start:target_end]
+        tokens.append(Token(TokenType.COMMENT_PREPROCFILE, target, line, arg_col))
+        return tokens, target_end, directive_end, -1
+
+    # #define NAME, #ifdef NAME, ...
+    if name in _MACRO_DIRECTIVES and arg_start < directive_end and code[arg_start] in IDENT_START:
+        macro_end = scan_identifier(code, arg_start)
+        macro = code[arg_start:macro_end]
+        tokens.append(Token(TokenType.NAME_FUNCTION_MAGIC, macro, line, arg_col))
+        return tokens, macro_end, directive_end, -1
+
+    rest_end = _rest_end(code, arg_start, directive_end)
+    value_end = rest_end
+    while value_end > arg_start and code[value_end - 1] in " \t":
+        value_end -= 1
+    if value_end > arg_start:
+        tokens.append(Token(TokenType.COMMENT_PREPROC, code[arg_start:value_end], line, arg_col))
+
+    # #if 0 ... #endif
+    if name == "if" and code[arg_start:value_end] == "0" and directive_end < length:
+        body_end = _disabled_region_end(code, directive_end + 1, length)
+        # The region's final newline stays whitespace
+        if body_end > directive_end + 1 and code[body_end - 1] == "\n":
+            body_end -= 1
+        return tokens, value_end, directive_end, body_end
+
+    return tokens, value_end, directive_end, -1
@@ -24,6 +24,7 @@ Configuration Dataclasses:
 - `StringConfig`: Customize quote types, escape handling
 - `CommentConfig`: Customize comment markers
 - `OperatorConfig`: Define operator character sets
+- `EscapeConfig`: Customize escape sequence recognition inside strings
 
 Mixin Classes:
 
@@ -39,6 +40,8 @@ Standalone Functions:
 - `scan_identifier()`: Fast identifier scanning
 - `scan_string()`: String literal scanning
 - `scan_block_comment()`: Block comment scanning
+- `scan_escape()`: Escape sequence scanning
+- `split_escapes()`: Emit a string literal as STRING and STRING_ESCAPE tokens
 
 **Usage:**
 
@@ -70,6 +73,7 @@ local variables. Character sets are defined as module-level frozensets.
 
 from __future__ import annotations
 
+from collections.abc import Iterator
 from dataclasses import dataclass
 from typing import TYPE_CHECKING
 
@@ -84,6 +88,18 @@ __all__ = [
     "StringConfig",
     "CommentConfig",
     "OperatorConfig",
+    "EscapeConfig",
+    # Escape presets
+    "C_ESCAPES",
+    "PYTHON_ESCAPES",
+    "RUST_ESCAPES",
+    "GO_ESCAPES",
+    "JS_ESCAPES",
+    "JSON_ESCAPES",
+    "CSS_ESCAPES",
+    "SINGLE_QUOTE_ESCAPES",
+    # Doc comment openers
+    "C_DOC_COMMENT_PREFIXES",
     # Mixin classes
     "WhitespaceMixin",
     "CStyleCommentsMixin",
@@ -93,8 +109,12 @@ __all__ = [
     "CStyleOperatorsMixin",
     # Standalone scanners
     "scan_c_style_number",
+    "scan_cpp_raw_string",
     "scan_identifier",
     "scan_operators",
+    "scan_escape",
+    "split_escapes",
+    "is_doc_comment",
 ]
 
 
@@ -180,6 +200,70 @@ class OperatorConfig:
     one_char: frozenset[str] = frozenset()
 
 
+@dataclass(frozen=True, slots=True)
+class EscapeConfig:
+    """Configuration for escape sequences inside string literals.
+
+    Every escape starts with `escape_char` followed by one character.
+    The numeric forms below extend that single character with a bounded
+    run of digits, so scanning an escape never looks past its own text.
+
+    Attributes:
+        escape_char: The character that introduces an escape.
+        hex_digits: Max hex digits after `x` (None = unbounded, 0 = not special).
+        unicode_short: Hex digits after `u` (e.g., 4 for `\\u00e9`, 0 = not special).
+        unicode_long: Hex digits after `U` (e.g., 8 for `\\U0001F600`, 0 = not special).
+        unicode_braces: Whether `\\u{...}` is recognized (Rust, Swift, ES6).
+        named_unicode: Whether `\\N{...}` is recognized (Python).
+        octal_digits: Max octal digits after the escape char (0 = not special).
+        bare_hex_digits: Max hex digits directly after the escape char, with
+            no `x` prefix (e.g., 6 for CSS `\\26`, 0 = not special).
+        escapable: If set, only these characters may follow the escape char;
+            any other backslash is literal text (e.g., `\\'` and `\\\\` in
+            single-quoted Perl, Ruby, or PHP strings).
+    """
+
+    escape_char: str = "\\"
+    hex_digits: int | None = 2
+    unicode_short: int = 4
+    unicode_long: int = 8
+    unicode_braces: bool = True
+    named_unicode: bool = False
+    octal_digits: int = 3
+    bare_hex_digits: int = 0
+    escapable: frozenset[str] | None = None
+
+
+# Escape presets for common language families
+C_ESCAPES = EscapeConfig(hex_digits=None, unicode_braces=False)
+PYTHON_ESCAPES = EscapeConfig(unicode_braces=False, named_unicode=True)
+RUST_ESCAPES = EscapeConfig(unicode_short=0, unicode_long=0, octal_digits=0)
+GO_ESCAPES = EscapeConfig(unicode_braces=False)
+JS_ESCAPES = EscapeConfig(unicode_long=0)
+JSON_ESCAPES = EscapeConfig(hex_digits=0, unicode_long=0, unicode_braces=False, octal_digits=0)
+CSS_ESCAPES = EscapeConfig(
+    hex_digits=0,
+    unicode_short=0,
+    unicode_long=0,
+    unicode_braces=False,
+    octal_digits=0,
+    bare_hex_digits=6,
+)
+# Single-quoted strings where only \\ and \' are escapes (PHP, Ruby, Perl)
+SINGLE_QUOTE_ESCAPES = EscapeConfig(
+    hex_digits=0,
+    unicode_short=0,
+    unicode_long=0,
+    unicode_braces=False,
+    octal_digits=0,
+    escapable=frozenset("\\'"),
+)
+
+# Doc comment openers: Javadoc/JSDoc/Doxygen `/** */`, Qt `/*! */`,
+# Rust/Swift/Dart/C# `///`, and inner `//!` comments.
+C_DOC_COMMENT_PREFIXES: tuple[str, ...] = ("/**", "/*!", "///", "//!")
+
+
 # =============================================================================
 # Character sets (shared across all scanners)
 # =============================================================================
@@ -254,6 +338,33 @@ def scan_block_comment(code: str, pos: int, end_marker: str = "*/") -> int:
     return pos
 
 
+def is_doc_comment(
+    code: str,
+    pos: int,
+    prefixes: tuple[str, ...] = C_DOC_COMMENT_PREFIXES,
+) -> bool:
+    """Check whether the comment at pos is a documentation comment.
+
+    A prefix doesn't count when it's immediately followed by its own last
+    character, so `////` and `/***` banners stay ordinary comments, and
+    the empty block comment `/**/` is never a doc comment.
+
+    Args:
+        pos: Position of the comment marker.
+        prefixes: Doc comment openers, longest first.
+    """
+    for prefix in prefixes:
+        if code.startswith(prefix, pos):
+            after = pos + len(prefix)
+            if after >= len(code):
+                return True
+            next_char = code[after]
+            if next_char == prefix[-1] or (prefix[-1] == "*" and next_char == "/"):
+                return False
+            return True
+    return False
+
+
 def scan_identifier(
     code: str,
     pos: int,
@@ -375,6 +486,202 @@ def scan_triple_string(code: str, pos: int, quote: str) -> tuple[int, int]:
         pos = triple_pos + 1
 
 
+_CPP_RAW_PREFIXES = ('R"', 'LR"', 'uR"', 'UR"', 'u8R"')
+
+
+def scan_cpp_raw_string(code: str, pos: int) -> int | None:
+    """Scan a C++ raw string literal like `R"delim(...)delim"`.
+
+    Recognizes the `R`, `LR`, `uR`, `UR` and `u8R` prefixes. The delimiter
+    has at most 16 characters and no spaces, parentheses or backslashes.
+
+    Args:
+        pos: Position of the prefix.
+
+    Returns:
+        Position after the closing quote (end of input if unterminated),
+        or None if pos does not start a raw string literal.
+    """
+    for prefix in _CPP_RAW_PREFIXES:
+        if code.startswith(prefix, pos):
+            break
+    else:
+        return None
+
+    length = len(code)
+    delim_start = pos + len(prefix)
+    paren = delim_start
+    while paren < length and paren - delim_start <= 16 and code[paren] not in '( )\\\t\n"':
+        paren += 1
+    if paren >= length or code[paren] != "(" or paren - delim_start > 16:
+        return None
+
+    closer = ")" + code[delim_start:paren] + '"'
+    close = code.find(closer, paren + 1)
+    return length if close == -1 else close + len(closer)
+
+
+def scan_escape(
+    code: str,
+    pos: int,
+    end: int | None = None,
+    config: EscapeConfig | None = None,
+) -> int:
+    """Scan one escape sequence.
+
+    Unknown escapes consume the escape char plus one character, which
+    keeps the escaped quote or backslash out of the surrounding text.
+
+    Args:
+        code: Source code.
+        pos: Position of the escape character.
+        end: Scanning limit (defaults to end of code).
+        config: Escape configuration.
+
+    Returns:
+        Position after the escape sequence, or `pos` unchanged if the
+        character after the escape char is not in `config.escapable`.
+    """
+    if config is None:
+        config = EscapeConfig()
+    # Lexers skip `pos += 2` past a backslash, so `end` can overshoot the code
+    end = len(code) if end is None else min(end, len(code))
+
+    if pos + 1 >= end:
+        return pos + 1
+
+    char = code[pos + 1]
+    if config.escapable is not None and char not in config.escapable:
+        return pos
+    pos += 2
+
+    if config.bare_hex_digits and char in HEX_DIGITS:
+        return _scan_bounded(code, pos, end, HEX_DIGITS, config.bare_hex_digits - 1)
+
+    if char == "x" and config.hex_digits != 0:
+        return _scan_bounded(code, pos, end, HEX_DIGITS, config.hex_digits)
+
+    if char == "u":
+        if config.unicode_braces and pos < end and code[pos] == "{":
+            return _scan_braced(code, pos, end)
+        if config.unicode_short:
+            return _scan_bounded(code, pos, end, HEX_DIGITS, config.unicode_short)
+        return pos
+
+    if char == "U" and config.unicode_long:
+        return _scan_bounded(code, pos, end, HEX_DIGITS, config.unicode_long)
+
+    if char == "N" and config.named_unicode and pos < end and code[pos] == "{":
+        return _scan_braced(code, pos, end)
+
+    if char in OCTAL_DIGITS and config.octal_digits:
+        return _scan_bounded(code, pos, end, OCTAL_DIGITS, config.octal_digits - 1)
+
+    if char == "\r" and pos < end and code[pos] == "\n":
+        return pos + 1  # CRLF line continuation
+
+    return pos
+
+
+def _scan_bounded(
+    code: str,
+    pos: int,
+    end: int,
+    digit_set: frozenset[str],
+    limit: int | None,
+) -> int:
+    """Scan up to `limit` digits (None = unbounded)."""
+    stop = end if limit is None else min(end, pos + limit)
+    while pos < stop and code[pos] in digit_set:
+        pos += 1
+    return pos
+
+
+def _scan_braced(code: str, pos: int, end: int) -> int:
+    """Scan a `{...}` escape body; stops at a newline or quote if unclosed."""
+    scan = pos + 1
+    while scan < end:
+        char = code[scan]
+        if char == "}":
+            return scan + 1
+        if char in "\n\"'":
+            break
+        scan += 1
+    return pos
+
+
+def split_escapes(
+    code: str,
+    start: int,
+    end: int,
+    token_type: TokenType,
+    line: int,
+    col: int,
+    config: EscapeConfig | None = None,
+) -> Iterator[Token]:
+    """Emit the string literal code[start:end] as string and escape tokens.
+
+    Escape sequences become STRING_ESCAPE tokens; the text between them
+    keeps `token_type`. Literals without escapes yield a single token, so
+    the common case costs one C-level str.find(). Positions of tokens after
+    embedded newlines are tracked, and token values always reconstruct
+    code[start:end] exactly.
+
+    Args:
+        start: Position of the literal (including any prefix and quotes).
+        end: Position after the literal.
+        token_type: Type for non-escape segments (e.g., STRING, STRING_DOC).
+        line: Line of the literal's first character.
+        col: Column of the literal's first character.
+        config: Escape configuration.
+
+    Yields:
+        Tokens covering code[start:end] in order.
+    """
+    if config is None:
+        config = EscapeConfig()
+    escape_char = config.escape_char
+    end = min(end, len(code))
+
+    esc = code.find(escape_char, start, end)
+    if esc == -1:
+        yield Token(token_type, code[start:end], line, col)
+        return
+
+    line_start = start - col + 1
+    seg = start
+    seg_line = line
+    seg_col = col
+
+    while esc != -1:
+        esc_end = scan_escape(code, esc, end, config)
+        if esc_end == esc:
+            # Literal escape char (not followed by an escapable character)
+            esc = code.find(escape_char, esc + 1, end)
+            continue
+
+        if esc > seg:
+            yield Token(token_type, code[seg:esc], seg_line, seg_col)
+            newlines = code.count("\n", seg, esc)
+            if newlines:
+                line += newlines
+                line_start = code.rfind("\n", seg, esc) + 1
+
+        value = code[esc:esc_end]
+        yield Token(TokenType.STRING_ESCAPE, value, line, esc - line_start + 1)
+        if "\n" in value:
+            line += value.count("\n")
+            line_start = esc + value.rfind("\n") + 1
+
+        seg = esc_end
+        seg_line = line
+        seg_col = seg - line_start + 1
+        esc = code.find(escape_char, seg, end)
+
+    if seg < end:
+        yield Token(token_type, code[seg:end], seg_line, seg_col)
+
+
 def scan_c_style_number(
     code: str,
     pos: int,
@@ -563,8 +870,14 @@ class CStyleCommentsMixin:
     """Mixin for C-style comments (// and /* */).
 
     Used by: JavaScript, TypeScript, C, C++, Java, Go, Rust, etc.
+
+    Comments opened by one of `DOC_COMMENT_PREFIXES` are COMMENT_DOC;
+    set it to `()` for languages without doc comment syntax.
     """
 
+    # Override in subclass to customize
+    DOC_COMMENT_PREFIXES: tuple[str, ...] = C_DOC_COMMENT_PREFIXES
+
     def _scan_line_comment(self, code: str, pos: int) -> int:
         """Scan // comment to end of line."""
         return scan_line_comment(code, pos)
@@ -585,21 +898,21 @@ class CStyleCommentsMixin:
             return None, pos
 
         next_char = code[pos + 1]
+        if next_char not in "/*":
+            return None, pos
 
         if next_char == "/":
             # Line comment
             end_pos = scan_line_comment(code, pos + 2)
-            return Token(TokenType.COMMENT_SINGLE, code[pos:end_pos], line, col), end_pos
-
-        if next_char == "*":
+            token_type = TokenType.COMMENT_SINGLE
+        else:
             # Block comment
             end_pos = scan_block_comment(code, pos + 2, "*/")
-            return (
-                Token(TokenType.COMMENT_MULTILINE, code[pos:end_pos], line, col),
-                end_pos,
-            )
+            token_type = TokenType.COMMENT_MULTILINE
 
-        return None, pos
+        if is_doc_comment(code, pos, self.DOC_COMMENT_PREFIXES):
+            token_type = TokenType.COMMENT_DOC
+        return Token(token_type, code[pos:end_pos], line, col), end_pos
 
 
 class HashCommentsMixin:
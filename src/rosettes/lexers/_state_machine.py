@@ -35,6 +35,8 @@ Helper functions provide common patterns:
 - `scan_triple_string()`: Handle triple-quoted strings
 - `scan_line_comment()`: Scan to end of line
 - `scan_block_comment()`: Scan to end marker
+- `split_escapes()`: Emit a scanned string as STRING and STRING_ESCAPE tokens
+  (re-exported from `rosettes.lexers._scanners`)
 
 **Adding New Languages:**
 
@@ -92,6 +94,7 @@ from typing import TYPE_CHECKING
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._scanners import EscapeConfig, split_escapes
 
 if TYPE_CHECKING:
     pass
@@ -102,6 +105,8 @@ __all__ = [
     "scan_until",
     "scan_string",
     "scan_triple_string",
+    "EscapeConfig",
+    "split_escapes",
 ]
 
 
@@ -192,6 +197,10 @@ class StateMachineLexer:
     )
     WHITESPACE: frozenset[str] = frozenset(" \t\n\r\f\v")
 
+    # Whether STRING_DOC tokens are documentation (Python docstrings, Perl
+    # POD) rather than multi-line string literals (Java text blocks)
+    DOCSTRINGS: bool = False
+
     def tokenize(
         self,
         code: str,
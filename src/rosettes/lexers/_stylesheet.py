@@ -0,0 +1,717 @@
+"""Shared scanner for CSS and the stylesheet preprocessors.
+
+The CSS, SCSS, Sass, Less and Stylus lexers all tokenize with
+`scan_stylesheet()`. A `StylesheetConfig` describes what each dialect adds
+on top of CSS: line comments, `$`/`@` variables, interpolation, indented
+syntax, mixin directives, and control-flow keywords.
+
+**Selectors vs. Declarations:**
+
+An identifier followed by `:` is a property (`color: red`) unless the
+colon starts a pseudo-class (`a:hover {`). The scanner decides by looking
+at what ends the line: `;` or `}` means a declaration, `{` means a
+selector. In indented syntaxes, a line with more-indented children is a
+selector. Each decision is cached until its terminator, so scanning stays
+O(n). Indentation is measured in columns, with tabs advancing to the next
+multiple of `LexerConfig.tab_size`.
+
+**Thread-Safety:**
+
+All state is local to `scan_stylesheet()`; configs are frozen.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+from dataclasses import dataclass
+
+from rosettes._types import Token, TokenType
+from rosettes.lexers._scanners import (
+    CSS_ESCAPES,
+    DIGITS,
+    HEX_DIGITS,
+    OperatorConfig,
+    scan_block_comment,
+    scan_operators,
+    scan_string,
+    split_escapes,
+)
+
+__all__ = [
+    "CSS",
+    "LESS",
+    "SASS",
+    "SCSS",
+    "STYLUS",
+    "StylesheetConfig",
+    "scan_stylesheet",
+]
+
+_WHITESPACE = frozenset(" \t\n\r\f\v")
+_UNIT_CHARS = frozenset("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ%")
+
+# At-rules defined by CSS itself (Less treats any other @name as a variable)
+CSS_AT_RULES = frozenset(
+    {
+        "charset",
+        "container",
+        "counter-style",
+        "document",
+        "font-face",
+        "font-feature-values",
+        "import",
+        "keyframes",
+        "layer",
+        "media",
+        "namespace",
+        "page",
+        "plugin",
+        "property",
+        "scope",
+        "starting-style",
+        "supports",
+        "viewport",
+    }
+)
+
+
+@dataclass(frozen=True, slots=True)
+class StylesheetConfig:
+    """Dialect features layered on top of CSS.
+
+    Attributes:
+        line_comments: Whether `//` starts a comment.
+        variable_prefix: Prefix for variables (e.g., `$`), empty for none.
+        at_variables: Whether `@name` is a variable unless it is a CSS
+            at-rule (Less).
+        interpolation: Interpolation opener (`#{` or `@{`), empty for none.
+        indented: Whether nesting is by indentation and newlines end
+            declarations (Sass, Stylus).
+        bare_properties: Whether properties may omit the colon (Stylus).
+        placeholders: Whether `%name` is a placeholder selector.
+        function_calls: Whether `name(` is a function call rather than a
+            plain CSS value name.
+        assignments: Whether `name = value` assigns a variable (Stylus).
+        keywords: Words that are always keywords.
+        conditions: At-rules (`@if`) and words (`when`) that start a
+            condition, in which `condition_keywords` are keywords.
+        condition_keywords: Words that are keywords inside a condition.
+        constants: Words that are keyword constants (`true`, `null`).
+        mixin_rules: At-rules whose next identifier names a mixin or
+            function (`@mixin`, `@include`).
+        operators: Operators outside of selectors' combinators.
+    """
+
+    line_comments: bool = False
+    variable_prefix: str = ""
+    at_variables: bool = False
+    interpolation: str = ""
+    indented: bool = False
+    bare_properties: bool = False
+    placeholders: bool = False
+    function_calls: bool = False
+    assignments: bool = False
+    keywords: frozenset[str] = frozenset()
+    conditions: frozenset[str] = frozenset()
+    condition_keywords: frozenset[str] = frozenset()
+    constants: frozenset[str] = frozenset()
+    mixin_rules: frozenset[str] = frozenset()
+    operators: OperatorConfig = OperatorConfig(one_char=frozenset("+>~*"))
+
+
+_PREPROCESSOR_OPERATORS = OperatorConfig(
+    two_char=frozenset({"==", "!=", "<=", ">=", "=<"}),
+    one_char=frozenset("+-*/%<>=~"),
+)
+
+CSS = StylesheetConfig()
+
+SCSS = StylesheetConfig(
+    line_comments=True,
+    variable_prefix="$",
+    interpolation="#{",
+    placeholders=True,
+    function_calls=True,
+    conditions=frozenset({"@if", "@else", "@each", "@for", "@while", "@use", "@forward"}),
+    condition_keywords=frozenset(
+        {"and", "or", "not", "if", "in", "from", "through", "to", "as", "with", "show", "hide"}
+    ),
+    constants=frozenset({"true", "false", "null"}),
+    mixin_rules=frozenset({"@mixin", "@include", "@function"}),
+    operators=_PREPROCESSOR_OPERATORS,
+)
+
+SASS = StylesheetConfig(
+    line_comments=True,
+    variable_prefix="$",
+    interpolation="#{",
+    indented=True,
+    placeholders=True,
+    function_calls=True,
+    conditions=SCSS.conditions,
+    condition_keywords=SCSS.condition_keywords,
+    constants=SCSS.constants,
+    mixin_rules=SCSS.mixin_rules,
+    operators=_PREPROCESSOR_OPERATORS,
+)
+
+LESS = StylesheetConfig(
+    line_comments=True,
+    at_variables=True,
+    interpolation="@{",
+    function_calls=True,
+    conditions=frozenset({"when"}),
+    condition_keywords=frozenset({"and", "not", "or"}),
+    constants=frozenset({"true", "false"}),
+    operators=_PREPROCESSOR_OPERATORS,
+)
+
+STYLUS = StylesheetConfig(
+    line_comments=True,
+    variable_prefix="$",
+    indented=True,
+    bare_properties=True,
+    placeholders=True,
+    function_calls=True,
+    assignments=True,
+    keywords=frozenset(
+        {"if", "else", "unless", "for", "in", "return", "and", "or", "not", "is", "isnt"}
+    ),
+    constants=frozenset({"true", "false", "null"}),
+    operators=OperatorConfig(
+        three_char=frozenset({"..."}),
+        two_char=frozenset(
+            {"==", "!=", "<=", ">=", "?=", ":=", "+=", "-=", "*=", "/=", "..", "**", "&&", "||"}
+        ),
+        one_char=frozenset("+-*/%<>=~?!"),
+    ),
+)
+
+
+def _is_ident_char(char: str) -> bool:
+    return char.isalnum() or char in "-_"
+
+
+def _scan_ident(code: str, pos: int, length: int) -> int:
+    while pos < length and _is_ident_char(code[pos]):
+        pos += 1
+    return pos
+
+
+def _indent_width(code: str, line_start: int, pos: int, tab_size: int) -> int:
+    """Column width of the indentation between line_start and pos."""
+    width = 0
+    for char in code[line_start:pos]:
+        if char == "\t":
+            width += tab_size - width % tab_size
+        else:
+            width += 1
+    return width
+
+
+def _has_children(code: str, pos: int, length: int, indent: int, tab_size: int) -> bool:
+    """Whether the next non-blank line after pos is indented deeper than indent."""
+    newline = code.find("\n", pos, length)
+    while newline != -1:
+        line_start = newline + 1
+        scan = line_start
+        while scan < length and code[scan] in " \t":
+            scan += 1
+        if scan >= length:
+            return False
+        if code[scan] not in "\r\n":
+            return _indent_width(code, line_start, scan, tab_size) > indent
+        newline = code.find("\n", scan, length)
+    return False
+
+
+def _scan_rule_end(code: str, pos: int, length: int) -> tuple[int, bool | None]:
+    """Find what ends the rest of a line: `;`/`}` (True), `{` (False), or newline (None).
+
+    Interpolations (`#{...}`, `@{...}`) are skipped so their braces don't count.
+    """
+    depth = 0
+    while pos < length:
+        char = code[pos]
+        if char == "\n":
+            return pos, None
+        if char in "#@" and pos + 1 < length and code[pos + 1] == "{":
+            depth += 1
+            pos += 2
+            continue
+        if char == "}" and depth:
+            depth -= 1
+        elif char in ";}":
+            return pos, True
+        elif char == "{":
+            return pos, False
+        pos += 1
+    return pos, None
+
+
+def _line_ends_with_comma(code: str, pos: int, length: int) -> bool:
+    newline = code.find("\n", pos, length)
+    text = code[pos : newline if newline != -1 else length].rstrip()
+    return text.endswith(",")
+
+
+def _split_interpolated(
+    code: str,
+    start: int,
+    end: int,
+    line: int,
+    col: int,
+    opener: str,
+) -> Iterator[Token]:
+    """Emit a string literal, splitting out interpolations as STRING_INTERPOL."""
+    segment = start
+    found = code.find(opener, start, end) if opener else -1
+    while found != -1:
+        close = code.find("}", found + 2, end)
+        if close == -1:
+            break
+        if found > segment:
+            yield from split_escapes(
+                code, segment, found, TokenType.STRING, line, col + segment - start, CSS_ESCAPES
+            )
+        yield Token(TokenType.STRING_INTERPOL, code[found : close + 1], line, col + found - start)
+        segment = close + 1
+        found = code.find(opener, segment, end)
+    if segment < end:
+        yield from split_escapes(
+            code, segment, end, TokenType.STRING, line, col + segment - start, CSS_ESCAPES
+        )
+
+
+def scan_stylesheet(
+    code: str,
+    start: int,
+    end: int | None,
+    config: StylesheetConfig,
+    *,
+    tab_size: int = 4,
+) -> Iterator[Token]:
+    """Tokenize CSS or a preprocessor dialect described by config.
+
+    Args:
+        code: Source code.
+        start: Starting index in the source string.
+        end: Optional ending index in the source string.
+        config: Dialect features.
+        tab_size: Tab width for comparing indentation in indented syntaxes.
+
+    Yields:
+        Tokens with 1-based line and column positions.
+    """
+    pos = start
+    length = end if end is not None else len(code)
+    line = 1
+    line_start = start
+
+    operators = config.operators
+    interpolation = config.interpolation
+    brace_depth = 0
+    paren_depth = 0
+    interp_depths: list[int] = []
+    line_begin = True
+    indent = 0
+    in_value = False
+    in_condition = False
+    expect_mixin = False
+    # Cached selector/declaration decision, valid for colons before rule_end
+    rule_end = -1
+    rule_is_declaration = False
+
+    while pos < length:
+        char = code[pos]
+        col = pos - line_start + 1
+
+        # Whitespace
+        if char in _WHITESPACE:
+            begin = pos
+            start_line = line
+            while pos < length and code[pos] in _WHITESPACE:
+                if code[pos] == "\n":
+                    line += 1
+                    line_start = pos + 1
+                pos += 1
+            if line > start_line:
+                line_begin = True
+                if config.indented:
+                    in_value = in_condition = expect_mixin = False
+            yield Token(TokenType.WHITESPACE, code[begin:pos], start_line, col)
+            continue
+
+        first = line_begin
+        if first:
+            indent = _indent_width(code, line_start, pos, tab_size)
+            line_begin = False
+
+        # Comments /* */
+        if char == "/" and pos + 1 < length and code[pos + 1] == "*":
+            begin = pos
+            pos = scan_block_comment(code, pos + 2, "*/")
+            value = code[begin:pos]
+            newlines = value.count("\n")
+            yield Token(TokenType.COMMENT_MULTILINE, value, line, col)
+            if newlines:
+                line += newlines
+                line_start = begin + value.rfind("\n") + 1
+            continue
+
+        # Comments //
+        if config.line_comments and char == "/" and pos + 1 < length and code[pos + 1] == "/":
+            begin = pos
+            newline = code.find("\n", pos, length)
+            pos = newline if newline != -1 else length
+            yield Token(TokenType.COMMENT_SINGLE, code[begin:pos], line, col)
+            continue
+
+        # Interpolation #{...} or @{...}
+        if interpolation and code.startswith(interpolation, pos):
+            interp_depths.append(brace_depth)
+            yield Token(TokenType.STRING_INTERPOL, interpolation, line, col)
+            pos += 2
+            continue
+
+        # At-rules @media, @mixin, etc. (variables in Less)
+        if char == "@":
+            begin = pos
+            pos += 1
+            if config.at_variables and pos < length and code[pos] == "@":
+                pos += 1
+            pos = _scan_ident(code, pos, length)
+            word = code[begin:pos]
+            if config.at_variables:
+                temp = pos
+                while temp < length and code[temp] in " \t":
+                    temp += 1
+                is_definition = temp < length and code[temp] == ":"
+                if is_definition or word[1:].lower() not in CSS_AT_RULES:
+                    in_value = in_value or is_definition
+                    yield Token(TokenType.NAME_VARIABLE, word, line, col)
+                    continue
+            if word in config.mixin_rules:
+                expect_mixin = True
+            if word in config.conditions:
+                in_condition = True
+            yield Token(TokenType.KEYWORD, word, line, col)
+            continue
+
+        # Variables $name
+        if char == config.variable_prefix and pos + 1 < length and _is_ident_char(code[pos + 1]):
+            begin = pos
+            pos = _scan_ident(code, pos + 1, length)
+            temp = pos
+            while temp < length and code[temp] in " \t":
+                temp += 1
+            if temp < length and code[temp] == ":":
+                in_value = True
+            yield Token(TokenType.NAME_VARIABLE, code[begin:pos], line, col)
+            continue
+
+        # ID selectors #id
+        if char == "#":
+            begin = pos
+            pos += 1
+            # Could be hex color or ID
+            hex_start = pos
+            while pos < length and code[pos] in HEX_DIGITS:
+                pos += 1
+            hex_len = pos - hex_start
+            if hex_len in (3, 4, 6, 8) and (pos >= length or not code[pos].isalnum()):
+                # It's a color
+                yield Token(TokenType.NUMBER_HEX, code[begin:pos], line, col)
+            else:
+                # It's an ID selector
+                pos = _scan_ident(code, pos, length)
+                yield Token(TokenType.NAME_TAG, code[begin:pos], line, col)
+            continue
+
+        # Class selectors .class
+        if char == ".":
+            if pos + 1 < length and (code[pos + 1].isalpha() or code[pos + 1] in "-_"):
+                begin = pos
+                pos = _scan_ident(code, pos + 1, length)
+                yield Token(TokenType.NAME_CLASS, code[begin:pos], line, col)
+                continue
+            # Could be a number starting with .
+            if pos + 1 < length and code[pos + 1] in DIGITS:
+                begin = pos
+                pos += 1
+                while pos < length and code[pos] in DIGITS:
+                    pos += 1
+                yield Token(TokenType.NUMBER_FLOAT, code[begin:pos], line, col)
+                continue
+            op, new_pos = scan_operators(code, pos, operators)
+            if op is not None:
+                yield Token(TokenType.OPERATOR, op, line, col)
+                pos = new_pos
+                continue
+            yield Token(TokenType.PUNCTUATION, ".", line, col)
+            pos += 1
+            continue
+
+        # Placeholder selectors %name
+        if (
+            config.placeholders
+            and char == "%"
+            and not in_value
+            and pos + 1 < length
+            and (code[pos + 1].isalpha() or code[pos + 1] in "-_")
+        ):
+            begin = pos
+            pos = _scan_ident(code, pos + 1, length)
+            yield Token(TokenType.NAME_CLASS, code[begin:pos], line, col)
+            continue
+
+        # Parent selector &
+        if char == "&" and not (pos + 1 < length and code[pos + 1] == "&"):
+            yield Token(TokenType.NAME_BUILTIN_PSEUDO, "&", line, col)
+            pos += 1
+            continue
+
+        # Strings
+        if char in "\"'":
+            begin = pos
+            pos, _ = scan_string(code, pos + 1, char)
+            if interpolation and code.find(interpolation, begin, pos) != -1:
+                yield from _split_interpolated(code, begin, pos, line, col, interpolation)
+            else:
+                yield from split_escapes(
+                    code, begin, pos, TokenType.STRING, line, col, CSS_ESCAPES
+                )
+            continue
+
+        # Numbers (including units)
+        if char in DIGITS or (char == "-" and pos + 1 < length and code[pos + 1] in DIGITS):
+            begin = pos
+            if char == "-":
+                pos += 1
+            while pos < length and code[pos] in DIGITS:
+                pos += 1
+            if pos + 1 < length and code[pos] == "." and code[pos + 1] in DIGITS:
+                pos += 1
+                while pos < length and code[pos] in DIGITS:
+                    pos += 1
+            # Unit suffix
+            unit_start = pos
+            while pos < length and code[pos] in _UNIT_CHARS:
+                pos += 1
+            is_float = "." in code[begin:unit_start]
+            number_type = TokenType.NUMBER_FLOAT if is_float else TokenType.NUMBER_INTEGER
+            yield Token(number_type, code[begin:pos], line, col)
+            continue
+
+        # Mixin shorthands in indented syntax: =name defines, +name includes
+        if (
+            config.indented
+            and first
+            and char in "=+"
+            and pos + 1 < length
+            and (code[pos + 1].isalpha() or code[pos + 1] in "-_")
+        ):
+            expect_mixin = True
+            yield Token(TokenType.OPERATOR, char, line, col)
+            pos += 1
+            continue
+
+        # Lone minus in expressions
+        if (
+            char == "-"
+            and "-" in operators.one_char
+            and not (pos + 1 < length and _is_ident_char(code[pos + 1]))
+        ):
+            op, new_pos = scan_operators(code, pos, operators)
+            yield Token(TokenType.OPERATOR, op or "-", line, col)
+            pos = new_pos if op else pos + 1
+            continue
+
+        # Property names, values, and keywords (identifiers)
+        if char.isalpha() or char in "-_":
+            begin = pos
+            pos = _scan_ident(code, pos, length)
+            word = code[begin:pos]
+
+            # url(...) holds an unquoted URL, which may contain //
+            if word.lower() == "url" and pos < length and code[pos] == "(":
+                yield Token(
+                    TokenType.NAME_FUNCTION if config.function_calls else TokenType.NAME,
+                    word,
+                    line,
+                    col,
+                )
+                yield Token(TokenType.PUNCTUATION, "(", line, col + 3)
+                pos += 1
+                url_start = pos
+                while pos < length and code[pos] not in ")\"'\n":
+                    pos += 1
+                if pos > url_start and (pos >= length or code[pos] in ")\n"):
+                    yield Token(TokenType.STRING, code[url_start:pos], line, col + 4)
+                else:
+                    pos = url_start
+                paren_depth += 1
+                continue
+
+            if expect_mixin:
+                expect_mixin = False
+                yield Token(TokenType.NAME_FUNCTION, word, line, col)
+                continue
+
+            temp = pos
+            while temp < length and code[temp] in " \t":
+                temp += 1
+            next_char = code[temp] if temp < length else ""
+
+            # Stylus assignments: name = value, name ?= value, name := value
+            if config.assignments and (
+                (next_char == "=" and code[temp + 1 : temp + 2] != "=")
+                or code.startswith(("?=", ":="), temp)
+            ):
+                in_value = True
+                yield Token(TokenType.NAME_VARIABLE, word, line, col)
+                continue
+
+            # Property (followed by :) or selector with a pseudo-class
+            if next_char == ":":
+                after = code[temp + 1] if temp + 1 < length else ""
+                if after == ":":
+                    is_declaration = False
+                elif in_value or paren_depth or after in " \t\r\n" or not after:
+                    is_declaration = True
+                else:
+                    if temp >= rule_end:
+                        rule_end, terminator = _scan_rule_end(code, temp, length)
+                        if terminator is None:
+                            if config.indented:
+                                terminator = not _has_children(code, temp, length, indent, tab_size)
+                            else:
+                                terminator = not _line_ends_with_comma(code, temp, length)
+                        rule_is_declaration = terminator
+                    is_declaration = rule_is_declaration
+                if is_declaration:
+                    in_value = True
+                    yield Token(TokenType.NAME_ATTRIBUTE, word, line, col)
+                    continue
+                yield Token(TokenType.NAME, word, line, col)
+                continue
+
+            # Stylus properties without a colon: `color red`
+            if (
+                config.bare_properties
+                and first
+                and (indent or brace_depth)
+                and temp > pos
+                and next_char
+                and next_char not in "{,=(\r\n+*/%<>?"
+                and not (next_char == "-" and code[temp + 1 : temp + 2] in (" ", "\t"))
+                and word not in config.keywords
+                and not _line_ends_with_comma(code, temp, length)
+                and not _has_children(code, temp, length, indent, tab_size)
+            ):
+                in_value = True
+                yield Token(TokenType.NAME_ATTRIBUTE, word, line, col)
+                continue
+
+            # Function calls and module members: darken(...), math.div(...)
+            if config.function_calls and pos < length:
+                if code[pos] == "(":
+                    yield Token(TokenType.NAME_FUNCTION, word, line, col)
+                    continue
+                if code[pos] == "." and pos + 1 < length:
+                    member_end = _scan_ident(code, pos + 1, length)
+                    if code[pos + 1] == config.variable_prefix or (
+                        member_end > pos + 1 and code[member_end : member_end + 1] == "("
+                    ):
+                        yield Token(TokenType.NAME_NAMESPACE, word, line, col)
+                        yield Token(TokenType.PUNCTUATION, ".", line, col + len(word))
+                        pos += 1
+                        if member_end > pos:
+                            member = code[pos:member_end]
+                            yield Token(TokenType.NAME_FUNCTION, member, line, col + len(word) + 1)
+                            pos = member_end
+                        continue
+
+            if word in config.keywords:
+                yield Token(TokenType.KEYWORD, word, line, col)
+            elif word in config.conditions:
+                in_condition = True
+                yield Token(TokenType.KEYWORD, word, line, col)
+            elif in_condition and word in config.condition_keywords:
+                yield Token(TokenType.KEYWORD, word, line, col)
+            elif word in config.constants:
+                yield Token(TokenType.KEYWORD_CONSTANT, word, line, col)
+            else:
+                yield Token(TokenType.NAME, word, line, col)
+            continue
+
+        # Pseudo-classes and pseudo-elements
+        if char == ":":
+            op, new_pos = scan_operators(code, pos, operators)
+            if op is not None:
+                yield Token(TokenType.OPERATOR, op, line, col)
+                pos = new_pos
+                continue
+            begin = pos
+            pos += 1
+            if not in_value:
+                if pos < length and code[pos] == ":":
+                    pos += 1  # ::pseudo-element
+                pos = _scan_ident(code, pos, length)
+            if pos > begin + 1:
+                yield Token(TokenType.NAME_DECORATOR, code[begin:pos], line, col)
+            else:
+                yield Token(TokenType.PUNCTUATION, ":", line, col)
+            continue
+
+        # Flags: !important, !default, !global
+        if char == "!" and pos + 1 < length and code[pos + 1].isalpha():
+            begin = pos
+            pos = _scan_ident(code, pos + 1, length)
+            yield Token(TokenType.KEYWORD, code[begin:pos], line, col)
+            continue
+
+        # Attribute selectors [attr]
+        if char == "[" and not in_value:
+            begin = pos
+            bracket_depth = 1
+            pos += 1
+            while pos < length and bracket_depth > 0:
+                if code[pos] == "[":
+                    bracket_depth += 1
+                elif code[pos] == "]":
+                    bracket_depth -= 1
+                pos += 1
+            yield Token(TokenType.NAME_ATTRIBUTE, code[begin:pos], line, col)
+            continue
+
+        # Operators
+        op, new_pos = scan_operators(code, pos, operators)
+        if op is not None:
+            yield Token(TokenType.OPERATOR, op, line, col)
+            pos = new_pos
+            continue
+
+        # Punctuation
+        if char in "{}();,[]":
+            if char == "}" and interp_depths and interp_depths[-1] == brace_depth:
+                interp_depths.pop()
+                yield Token(TokenType.STRING_INTERPOL, "}", line, col)
+                pos += 1
+                continue
+            if char == "{":
+                brace_depth += 1
+            elif char == "}":
+                brace_depth = max(brace_depth - 1, 0)
+            elif char == "(":
+                paren_depth += 1
+            elif char == ")":
+                paren_depth = max(paren_depth - 1, 0)
+            if char in "{};":
+                in_value = in_condition = expect_mixin = False
+            yield Token(TokenType.PUNCTUATION, char, line, col)
+            pos += 1
+            continue
+
+        yield Token(TokenType.ERROR, char, line, col)
+        pos += 1
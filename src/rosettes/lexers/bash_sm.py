@@ -40,13 +40,25 @@ from collections.abc import Iterator
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
 from rosettes.lexers._scanners import (
+    C_ESCAPES,
     DIGITS,
+    EscapeConfig,
     HashCommentsMixin,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["BashStateMachineLexer"]
 
+# Inside double quotes, a backslash only escapes $ ` " \ and newline.
+_DOUBLE_QUOTE_ESCAPES = EscapeConfig(
+    hex_digits=0,
+    unicode_short=0,
+    unicode_long=0,
+    unicode_braces=False,
+    octal_digits=0,
+    escapable=frozenset('$`"\\\n'),
+)
 
 _KEYWORDS: frozenset[str] = frozenset(
     {
@@ -202,9 +214,13 @@ class BashStateMachineLexer(
             # Double-quoted strings (with variable expansion)
             if char == '"':
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != '"':
                     if code[pos] == "\\" and pos + 1 < length:
+                        if code[pos + 1] == "\n":
+                            line += 1
+                            line_start = pos + 2
                         pos += 2
                         continue
                     if code[pos] == "\n":
@@ -213,7 +229,9 @@ class BashStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, start_line, col, _DOUBLE_QUOTE_ESCAPES
+                )
                 continue
 
             # Single-quoted strings (literal)
@@ -233,15 +251,21 @@ class BashStateMachineLexer(
             # $'...' ANSI-C quoting
             if char == "$" and pos + 1 < length and code[pos + 1] == "'":
                 start = pos
+                start_line = line
                 pos += 2
                 while pos < length and code[pos] != "'":
                     if code[pos] == "\\" and pos + 1 < length:
                         pos += 2
                         continue
+                    if code[pos] == "\n":
+                        line += 1
+                        line_start = pos + 1
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, start_line, col, C_ESCAPES
+                )
                 continue
 
             # Heredoc <<EOF or <<'EOF' or <<-EOF
@@ -5,7 +5,8 @@ O(n) guaranteed, zero regex, thread-safe.
 **Language Support:**
 
 - C11/C17 syntax with common extensions
-- Preprocessor directives (`#include`, `#define`, etc.)
+- Preprocessor directives: include targets, macro names, `#if 0` blocks
+  and `\\` line continuations
 - All standard types including stdint.h types
 - Integer suffixes (`L`, `LL`, `U`, `UL`, etc.)
 - Floating-point suffixes (`f`, `F`, `l`, `L`)
@@ -14,7 +15,7 @@ O(n) guaranteed, zero regex, thread-safe.
 
 Uses C-style mixins for common patterns. C-specific additions:
 
-- Preprocessor directive handling (`#include`, `#define`, etc.)
+- Preprocessor directive handling via `rosettes.lexers._preprocessor`
 - Type suffixes on numeric literals
 - Standard C types as built-in keywords
 
@@ -38,7 +39,10 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
+from rosettes.lexers._preprocessor import scan_directive
 from rosettes.lexers._scanners import (
+    C_ESCAPES,
     IDENT_START,
     CStyleCommentsMixin,
     CStyleNumbersMixin,
@@ -46,8 +50,8 @@ from rosettes.lexers._scanners import (
     NumberConfig,
     OperatorConfig,
     scan_identifier,
-    scan_line_comment,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -182,6 +186,9 @@ class CStateMachineLexer(
         length = end if end is not None else len(code)
         line = 1
         line_start = start
+        # End of the current directive's logical line, and of an #if 0 region
+        directive_end = -1
+        disabled_end = -1
 
         while pos < length:
             char = code[pos]
@@ -199,22 +206,56 @@ class CStateMachineLexer(
                 yield Token(TokenType.WHITESPACE, code[start:pos], start_line, col)
                 continue
 
-            # Preprocessor directives
+            # Line continuation
+            if char == "\\" and code.startswith(("\\\n", "\\\r\n"), pos):
+                value = "\\\n" if code[pos + 1] == "\n" else "\\\r\n"
+                yield Token(TokenType.WHITESPACE, value, line, col)
+                pos += len(value)
+                line += 1
+                line_start = pos
+                continue
+
+            # Disabled #if 0 region
+            if directive_end < pos < disabled_end:
+                start = pos
+                pos = disabled_end
+                value = code[start:pos]
+                yield Token(TokenType.COMMENT, value, line, col)
+                newlines = value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = start + value.rfind("\n") + 1
+                continue
+
+            # Preprocessor directives (# and ## are operators in macro bodies)
             if char == "#":
+                if pos < directive_end:
+                    value = "##" if code.startswith("##", pos) else "#"
+                    yield Token(TokenType.OPERATOR, value, line, col)
+                    pos += len(value)
+                    continue
                 start = pos
-                pos = scan_line_comment(code, pos)
-                yield Token(TokenType.COMMENT_PREPROC, code[start:pos], line, col)
+                tokens, pos, directive_end, disabled_end = scan_directive(
+                    code, pos, length, line, col
+                )
+                yield from tokens
+                newlines = code.count("\n", start, pos)
+                if newlines:
+                    line += newlines
+                    line_start = code.rfind("\n", start, pos) + 1
                 continue
 
             # Comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -223,7 +264,7 @@ class CStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col, C_ESCAPES)
                 continue
 
             # Character literals
@@ -231,7 +272,9 @@ class CStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING_CHAR, line, col, C_ESCAPES
+                )
                 continue
 
             # Numbers
@@ -12,6 +12,7 @@ from rosettes._types import Token, TokenType
 from rosettes.lexers._scanners import (
     DIGITS,
     HEX_DIGITS,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -219,6 +220,7 @@ class ClojureStateMachineLexer(StateMachineLexer):
             # Strings
             if char == '"':
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != '"':
                     if code[pos] == "\\" and pos + 1 < length:
@@ -230,7 +232,7 @@ class ClojureStateMachineLexer(StateMachineLexer):
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 continue
 
             # Character literals \c \newline \u0041
@@ -1,6 +1,25 @@
 """Hand-written CSV lexer using state machine approach.
 
 O(n) guaranteed, zero regex, thread-safe.
+
+**Column-Aware Coloring:**
+
+Fields are typed by their column index, cycling through
+`CsvStateMachineLexer.COLUMN_TYPES`, so every theme shows each column in
+its own color ("rainbow CSV"). Header cells are GENERIC_HEADING and the
+delimiter is PUNCTUATION.
+
+**Dialect Sniffing:**
+
+The delimiter (`,`, `;`, tab or `|`) is detected from the first records
+by `sniff_delimiter()`. The first record is treated as a header when it
+is followed by more data and its fields are distinct, non-empty and
+non-numeric (see `has_header()`).
+
+**See Also:**
+
+- `rosettes.formatters.terminal`: `TerminalFormatter(align_columns=True)`
+  pads fields so columns line up
 """
 
 from __future__ import annotations
@@ -9,19 +28,168 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
-from rosettes.lexers._scanners import DIGITS
 from rosettes.lexers._state_machine import StateMachineLexer
 
-__all__ = ["CsvStateMachineLexer"]
+__all__ = ["CsvStateMachineLexer", "has_header", "sniff_delimiter"]
+
+# Candidate delimiters, in tie-break order
+_DELIMITERS = ",\t;|"
+
+# Records sampled for sniffing
+_SAMPLE_RECORDS = 5
+
+
+def _scan_quoted(code: str, pos: int, end: int) -> int:
+    """Scan a quoted field at pos; a doubled quote is an escaped quote."""
+    quote = code[pos]
+    pos += 1
+    while pos < end:
+        if code[pos] == quote:
+            if pos + 1 < end and code[pos + 1] == quote:
+                pos += 2
+                continue
+            return pos + 1
+        pos += 1
+    return end
+
+
+def _sample_records(code: str, start: int, end: int) -> list[str]:
+    """Return the text of the first non-blank records, without quoted sections.
+
+    Double-quoted sections are dropped so delimiters inside quotes are not
+    counted, and newlines inside them don't split records.
+    """
+    records: list[str] = []
+    parts: list[str] = []
+    pos = start
+    seg = start
+    while pos < end and len(records) < _SAMPLE_RECORDS:
+        char = code[pos]
+        if char == '"':
+            parts.append(code[seg:pos])
+            pos = _scan_quoted(code, pos, end)
+            seg = pos
+            continue
+        if char in "\r\n":
+            parts.append(code[seg:pos])
+            record = "".join(parts)
+            if record.strip():
+                records.append(record)
+            parts = []
+            pos += 1
+            seg = pos
+            continue
+        pos += 1
+    parts.append(code[seg:pos])
+    record = "".join(parts)
+    if record.strip() and len(records) < _SAMPLE_RECORDS:
+        records.append(record)
+    return records
+
+
+def sniff_delimiter(code: str, start: int = 0, end: int | None = None) -> str:
+    """Guess the field delimiter of CSV data from its first records.
+
+    A delimiter that occurs the same (non-zero) number of times in every
+    sampled record wins; otherwise the most frequent one. Ties go to `,`,
+    then tab, `;` and `|`.
+
+    Args:
+        code: CSV data.
+        start: Starting index in the source string.
+        end: Optional ending index in the source string.
+
+    Returns:
+        The delimiter character; `,` when none occurs.
+
+    Example:
+        >>> sniff_delimiter("a;b;c\\n1;2,5;3\\n")
+        ';'
+    """
+    records = _sample_records(code, start, end if end is not None else len(code))
+    best = ","
+    best_score = (False, 0)
+    for delimiter in _DELIMITERS:
+        counts = [record.count(delimiter) for record in records]
+        if not counts or not any(counts):
+            continue
+        score = (min(counts) == max(counts), sum(counts))
+        if score > best_score:
+            best, best_score = delimiter, score
+    return best
+
+
+def _split_record(code: str, pos: int, end: int, delimiter: str) -> tuple[list[str], int]:
+    """Split the record at pos into stripped fields.
+
+    Returns:
+        Tuple of (fields, position after the record's line break).
+    """
+    fields: list[str] = []
+    field_start = pos
+    while pos < end:
+        char = code[pos]
+        if char in "\"'" and not code[field_start:pos].strip():
+            pos = _scan_quoted(code, pos, end)
+            continue
+        if char == delimiter or char in "\r\n":
+            fields.append(code[field_start:pos].strip())
+            if char != delimiter:
+                if char == "\r" and pos + 1 < end and code[pos + 1] == "\n":
+                    pos += 1
+                return fields, pos + 1
+            field_start = pos + 1
+        pos += 1
+    fields.append(code[field_start:pos].strip())
+    return fields, pos
+
+
+def _is_number(text: str) -> bool:
+    try:
+        float(text)
+    except ValueError:
+        return False
+    return True
+
+
+def has_header(
+    code: str,
+    delimiter: str,
+    start: int = 0,
+    end: int | None = None,
+) -> bool:
+    """Decide whether the first record of CSV data is a header row.
+
+    The first record is a header when more data follows and its fields
+    are non-empty, distinct and not numbers (after removing quotes).
+
+    Args:
+        code: CSV data.
+        delimiter: Field delimiter (see `sniff_delimiter()`).
+        start: Starting index in the source string.
+        end: Optional ending index in the source string.
+    """
+    length = end if end is not None else len(code)
+    pos = start
+    while pos < length and code[pos] in "\r\n":
+        pos += 1
+    fields, pos = _split_record(code, pos, length, delimiter)
+    if not code[pos:length].strip():
+        return False
+    names = [field.strip("\"'") for field in fields]
+    if any(not name or _is_number(name) for name in names):
+        return False
+    return len(set(names)) == len(names)
 
 
 class CsvStateMachineLexer(StateMachineLexer):
     """CSV file lexer.
 
     Highlights:
-    - Quoted strings (including escaped quotes)
-    - Numbers (integers and floats)
-    - Delimiters (comma, semicolon, tab)
+    - Fields by column index (see COLUMN_TYPES), including quoted fields
+      with doubled quotes and embedded newlines
+    - Header cells (GENERIC_HEADING)
+    - The sniffed delimiter (comma, semicolon, tab or pipe)
     - Whitespace
     """
 
@@ -30,8 +198,17 @@ class CsvStateMachineLexer(StateMachineLexer):
     filenames = ("*.csv", "*.tsv")
     mimetypes = ("text/csv", "text/tab-separated-values")
 
-    # Common CSV delimiters
-    DELIMITERS = frozenset(",;\t")
+    # Token types for columns 0, 1, 2, ...; neighbours differ in every palette
+    COLUMN_TYPES: tuple[TokenType, ...] = (
+        TokenType.STRING,
+        TokenType.NAME_FUNCTION,
+        TokenType.NUMBER,
+        TokenType.KEYWORD,
+        TokenType.NAME_CLASS,
+        TokenType.NAME_TAG,
+        TokenType.NAME_CONSTANT,
+        TokenType.NAME_NAMESPACE,
+    )
 
     def tokenize(
         self,
@@ -46,6 +223,12 @@ class CsvStateMachineLexer(StateMachineLexer):
         line = 1
         line_start = start
 
+        delimiter = sniff_delimiter(code, start, length)
+        in_header = has_header(code, delimiter, start, length)
+        column_types = self.COLUMN_TYPES
+        column = 0
+        record_started = False
+
         while pos < length:
             char = code[pos]
             col = pos - line_start + 1
@@ -58,119 +241,44 @@ class CsvStateMachineLexer(StateMachineLexer):
                 yield Token(TokenType.WHITESPACE, code[start_pos:pos], line, col)
                 continue
 
-            # Newline
-            if char == "\n":
-                yield Token(TokenType.WHITESPACE, char, line, col)
-                pos += 1
-                line += 1
-                line_start = pos
-                continue
-
-            # Carriage return (handle \r\n and standalone \r)
-            if char == "\r":
-                if pos + 1 < length and code[pos + 1] == "\n":
-                    yield Token(TokenType.WHITESPACE, "\r\n", line, col)
-                    pos += 2
-                else:
-                    yield Token(TokenType.WHITESPACE, char, line, col)
-                    pos += 1
+            # Newline (\n, \r\n or standalone \r) ends the record
+            if char in "\r\n":
+                value = "\r\n" if code.startswith("\r\n", pos) else char
+                yield Token(TokenType.WHITESPACE, value, line, col)
+                pos += len(value)
                 line += 1
                 line_start = pos
+                if record_started:
+                    in_header = False
+                column = 0
+                record_started = False
                 continue
 
-            # Delimiters (comma, semicolon, tab)
-            if char in self.DELIMITERS:
+            if char == delimiter:
                 yield Token(TokenType.PUNCTUATION, char, line, col)
                 pos += 1
+                column += 1
                 continue
 
-            # Quoted strings (double quotes)
-            if char == '"':
-                start_pos = pos
-                pos += 1
-                while pos < length:
-                    if code[pos] == '"':
-                        # Check for escaped quote ("")
-                        if pos + 1 < length and code[pos + 1] == '"':
-                            pos += 2
-                            continue
-                        # End of string
-                        pos += 1
-                        break
-                    elif code[pos] == "\n":
-                        # Track line numbers within multi-line strings
-                        line += 1
-                        line_start = pos + 1
-                        pos += 1
-                    else:
-                        pos += 1
-                yield Token(TokenType.STRING, code[start_pos:pos], line, col)
-                continue
+            record_started = True
+            field_type = (
+                TokenType.GENERIC_HEADING if in_header else column_types[column % len(column_types)]
+            )
 
-            # Single-quoted strings
-            if char == "'":
+            # Quoted field (double or single quotes, doubled to escape)
+            if char in "\"'":
                 start_pos = pos
-                pos += 1
-                while pos < length:
-                    if code[pos] == "'":
-                        # Check for escaped quote ('')
-                        if pos + 1 < length and code[pos + 1] == "'":
-                            pos += 2
-                            continue
-                        pos += 1
-                        break
-                    elif code[pos] == "\n":
-                        line += 1
-                        line_start = pos + 1
-                        pos += 1
-                    else:
-                        pos += 1
-                yield Token(TokenType.STRING, code[start_pos:pos], line, col)
-                continue
-
-            # Numbers (including negative and floats)
-            if (
-                char in DIGITS
-                or (char == "-" and pos + 1 < length and code[pos + 1] in DIGITS)
-                or (char == "+" and pos + 1 < length and code[pos + 1] in DIGITS)
-            ):
-                start_pos = pos
-                if char in "+-":
-                    pos += 1
-                # Integer part
-                while pos < length and code[pos] in DIGITS:
-                    pos += 1
-                is_float = False
-                # Decimal part
-                if pos < length and code[pos] == ".":
-                    next_pos = pos + 1
-                    if next_pos < length and code[next_pos] in DIGITS:
-                        is_float = True
-                        pos += 1
-                        while pos < length and code[pos] in DIGITS:
-                            pos += 1
-                # Exponent part (e.g., 1e10, 1E-5)
-                if pos < length and code[pos] in "eE":
-                    next_pos = pos + 1
-                    if next_pos < length and (code[next_pos] in DIGITS or code[next_pos] in "+-"):
-                        is_float = True
-                        pos += 1
-                        if pos < length and code[pos] in "+-":
-                            pos += 1
-                        while pos < length and code[pos] in DIGITS:
-                            pos += 1
-                token_type = TokenType.NUMBER_FLOAT if is_float else TokenType.NUMBER_INTEGER
-                yield Token(token_type, code[start_pos:pos], line, col)
+                pos = _scan_quoted(code, pos, length)
+                yield Token(field_type, code[start_pos:pos], line, col)
+                newlines = code.count("\n", start_pos, pos)
+                if newlines:
+                    line += newlines
+                    line_start = code.rfind("\n", start_pos, pos) + 1
                 continue
 
             # Unquoted field value (text until delimiter or newline)
             start_pos = pos
-            while pos < length and code[pos] not in self.DELIMITERS and code[pos] not in "\n\r":
+            while pos < length and code[pos] != delimiter and code[pos] not in "\n\r":
                 pos += 1
-            if pos > start_pos:
-                yield Token(TokenType.TEXT, code[start_pos:pos], line, col)
-                continue
+            yield Token(field_type, code[start_pos:pos], line, col)
 
-            # Fallback: single character
-            yield Token(TokenType.TEXT, char, line, col)
-            pos += 1
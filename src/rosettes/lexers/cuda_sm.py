@@ -9,16 +9,20 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
+from rosettes.lexers._preprocessor import scan_directive
 from rosettes.lexers._scanners import (
+    C_ESCAPES,
     IDENT_START,
     CStyleCommentsMixin,
     CStyleNumbersMixin,
     CStyleOperatorsMixin,
     NumberConfig,
     OperatorConfig,
+    scan_cpp_raw_string,
     scan_identifier,
-    scan_line_comment,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -238,6 +242,9 @@ class CudaStateMachineLexer(
         length = end if end is not None else len(code)
         line = 1
         line_start = start
+        # End of the current directive's logical line, and of an #if 0 region
+        directive_end = -1
+        disabled_end = -1
 
         while pos < length:
             char = code[pos]
@@ -255,22 +262,56 @@ class CudaStateMachineLexer(
                 yield Token(TokenType.WHITESPACE, code[start:pos], start_line, col)
                 continue
 
-            # Preprocessor
+            # Line continuation
+            if char == "\\" and code.startswith(("\\\n", "\\\r\n"), pos):
+                value = "\\\n" if code[pos + 1] == "\n" else "\\\r\n"
+                yield Token(TokenType.WHITESPACE, value, line, col)
+                pos += len(value)
+                line += 1
+                line_start = pos
+                continue
+
+            # Disabled #if 0 region
+            if directive_end < pos < disabled_end:
+                start = pos
+                pos = disabled_end
+                value = code[start:pos]
+                yield Token(TokenType.COMMENT, value, line, col)
+                newlines = value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = start + value.rfind("\n") + 1
+                continue
+
+            # Preprocessor directives (# and ## are operators in macro bodies)
             if char == "#":
+                if pos < directive_end:
+                    value = "##" if code.startswith("##", pos) else "#"
+                    yield Token(TokenType.OPERATOR, value, line, col)
+                    pos += len(value)
+                    continue
                 start = pos
-                pos = scan_line_comment(code, pos)
-                yield Token(TokenType.COMMENT_PREPROC, code[start:pos], line, col)
+                tokens, pos, directive_end, disabled_end = scan_directive(
+                    code, pos, length, line, col
+                )
+                yield from tokens
+                newlines = code.count("\n", start, pos)
+                if newlines:
+                    line += newlines
+                    line_start = code.rfind("\n", start, pos) + 1
                 continue
 
             # Comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -284,12 +325,26 @@ class CudaStateMachineLexer(
                 pos += 3
                 continue
 
+            # Raw strings R"delimiter(...)delimiter"
+            if char in "RLuU":
+                raw_end = scan_cpp_raw_string(code, pos)
+                if raw_end is not None:
+                    start = pos
+                    pos = raw_end
+                    value = code[start:pos]
+                    yield Token(TokenType.STRING, value, line, col)
+                    newlines = value.count("\n")
+                    if newlines:
+                        line += newlines
+                        line_start = start + value.rfind("\n") + 1
+                    continue
+
             # Strings
             if char == '"':
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col, C_ESCAPES)
                 continue
 
             # Character literals
@@ -297,7 +352,9 @@ class CudaStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING_CHAR, line, col, C_ESCAPES
+                )
                 continue
 
             # Numbers
@@ -17,6 +17,7 @@ from rosettes.lexers._scanners import (
     CStyleCommentsMixin,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -87,6 +88,9 @@ class CueStateMachineLexer(
     filenames = ("*.cue",)
     mimetypes = ("text/x-cue",)
 
+    # CUE has no dedicated doc comment syntax
+    DOC_COMMENT_PREFIXES = ()
+
     def tokenize(
         self,
         code: str,
@@ -172,7 +176,7 @@ class CueStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -201,7 +205,7 @@ class CueStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Regular strings
@@ -209,7 +213,7 @@ class CueStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
@@ -9,6 +9,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START,
     CStyleCommentsMixin,
@@ -16,10 +17,12 @@ from rosettes.lexers._scanners import (
     CStyleOperatorsMixin,
     NumberConfig,
     OperatorConfig,
+    is_doc_comment,
     scan_block_comment,
     scan_identifier,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -132,6 +135,9 @@ class DartStateMachineLexer(
     filenames = ("*.dart",)
     mimetypes = ("application/dart", "text/x-dart")
 
+    # Dart doc comments
+    DOC_COMMENT_PREFIXES = ("/**", "///")
+
     NUMBER_CONFIG = NumberConfig()
 
     OPERATOR_CONFIG = OperatorConfig(
@@ -202,16 +208,24 @@ class DartStateMachineLexer(
                     start = pos
                     while pos < length and code[pos] != "\n":
                         pos += 1
-                    yield Token(TokenType.COMMENT_SINGLE, code[start:pos], line, col)
+                    if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                        yield from split_doc_markup(
+                            code, start, pos, TokenType.COMMENT_DOC, line, col
+                        )
+                    else:
+                        yield Token(TokenType.COMMENT_SINGLE, code[start:pos], line, col)
                     continue
                 if next_char == "*":
                     start = pos
-                    is_doc = pos + 2 < length and code[pos + 2] == "*"
                     pos = scan_block_comment(code, pos + 2, "*/")
                     value = code[start:pos]
                     newlines = value.count("\n")
-                    token_type = TokenType.STRING_DOC if is_doc else TokenType.COMMENT_MULTILINE
-                    yield Token(token_type, value, line, col)
+                    if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                        yield from split_doc_markup(
+                            code, start, pos, TokenType.COMMENT_DOC, line, col
+                        )
+                    else:
+                        yield Token(TokenType.COMMENT_MULTILINE, value, line, col)
                     if newlines:
                         line += newlines
                         line_start = start + value.rfind("\n") + 1
@@ -232,7 +246,7 @@ class DartStateMachineLexer(
                 quote = char
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, quote)
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -259,7 +273,7 @@ class DartStateMachineLexer(
                 quote = char
                 pos += 1
                 pos, _ = scan_string(code, pos, quote)
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
@@ -2,11 +2,29 @@
 
 O(n) guaranteed, zero regex, thread-safe.
 Uses C-level str.find() for fast line scanning.
+
+**Language Support:**
+
+- Unified diffs (`---`/`+++` file headers, `@@` hunks)
+- Git extended headers (`diff --git`, `index`, modes, renames, copies,
+  similarity) and binary notices (`Binary files ... differ`,
+  `GIT binary patch`)
+- Combined diffs (`diff --cc`) with one `+`/`-` column per parent
+- `git diff --word-diff` markers (`[-old-]`, `{+new+}`)
+- `\\ No newline at end of file`
+- `--stat` summaries with `+`/`-` bars
+- Context diffs (`***`, `!`) and SVN `Index:` headers
+
+**Hunk Tracking:**
+
+Hunk headers (`@@ -1,3 +1,4 @@`) are parsed so the lexer knows how many
+lines belong to the hunk. A removed line that reads `-- x` is therefore
+GENERIC_DELETED inside a hunk rather than a `---` file header.
 """
 
 from __future__ import annotations
 
-from collections.abc import Iterator
+from collections.abc import Callable, Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
@@ -14,11 +32,217 @@ from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["DiffStateMachineLexer"]
 
+# Git extended header keywords
+_EXTENDED_HEADERS = (
+    "deleted file mode",
+    "new file mode",
+    "old mode",
+    "new mode",
+    "dissimilarity index",
+    "similarity index",
+    "rename from",
+    "rename to",
+    "copy from",
+    "copy to",
+    "index",
+)
+
+_HEX_DIGITS = frozenset("0123456789abcdefABCDEF")
+
+_Pieces = list[tuple[TokenType, str]]
+
+
+def _parse_hunk_header(content: str) -> tuple[int, int, int] | None:
+    """Parse `@@ -a,b +c,d @@` (or `@@@ -a,b -c,d +e,f @@@`).
+
+    Returns:
+        Tuple of (parent count, old line count, new line count), or None if
+        the header has no ranges. For combined diffs the old count is the
+        largest of the parents' counts.
+    """
+    marker_len = len(content) - len(content.lstrip("@"))
+    close = content.find(" " + "@" * marker_len, marker_len)
+    if close == -1:
+        return None
+    old_count = -1
+    new_count = -1
+    for spec in content[marker_len:close].split():
+        if spec[0] not in "+-":
+            return None
+        _, _, count_text = spec[1:].partition(",")
+        count = int(count_text) if count_text.isdigit() else 1
+        if spec[0] == "-":
+            old_count = max(old_count, count)
+        else:
+            new_count = count
+    if old_count < 0 or new_count < 0:
+        return None
+    return marker_len - 1, old_count, new_count
+
+
+def _split_word_diff(content: str) -> _Pieces:
+    """Split `[-removed-]` and `{+added+}` markers out of a word-diff line."""
+    pieces: _Pieces = []
+    pos = 0
+    seg = 0
+    length = len(content)
+    while pos < length - 1:
+        pair = content[pos : pos + 2]
+        if pair == "[-" or pair == "{+":
+            close = content.find("-]" if pair == "[-" else "+}", pos + 2)
+            if close != -1:
+                if pos > seg:
+                    pieces.append((TokenType.TEXT, content[seg:pos]))
+                marker_type = (
+                    TokenType.GENERIC_DELETED if pair == "[-" else TokenType.GENERIC_INSERTED
+                )
+                pieces.append((marker_type, content[pos : close + 2]))
+                pos = seg = close + 2
+                continue
+        pos += 1
+    if seg < length:
+        pieces.append((TokenType.TEXT, content[seg:]))
+    return pieces
+
+
+def _has_word_marker(content: str) -> bool:
+    """Check for a complete `[-...-]` or `{+...+}` word-diff marker."""
+    removed = content.find("[-")
+    added = content.find("{+")
+    return (removed != -1 and content.find("-]", removed + 2) != -1) or (
+        added != -1 and content.find("+}", added + 2) != -1
+    )
+
+
+def _split_words(text: str, classify: Callable[[str], TokenType]) -> _Pieces:
+    """Split text at spaces, typing each word with classify."""
+    pieces: _Pieces = []
+    pos = 0
+    length = len(text)
+    while pos < length:
+        if text[pos] == " ":
+            word_end = pos
+            while word_end < length and text[word_end] == " ":
+                word_end += 1
+            pieces.append((TokenType.WHITESPACE, text[pos:word_end]))
+        else:
+            word_end = text.find(" ", pos)
+            if word_end == -1:
+                word_end = length
+            word = text[pos:word_end]
+            if word.endswith(",") and len(word) > 1:
+                pieces.append((classify(word[:-1]), word[:-1]))
+                pieces.append((TokenType.PUNCTUATION, ","))
+            else:
+                pieces.append((classify(word), word))
+        pos = word_end
+    return pieces
+
+
+def _classify_stat_word(word: str) -> TokenType:
+    if word.isdigit():
+        return TokenType.NUMBER_INTEGER
+    if word.startswith("insertion"):
+        return TokenType.GENERIC_INSERTED
+    if word.startswith("deletion"):
+        return TokenType.GENERIC_DELETED
+    if word == "Bin":
+        return TokenType.KEYWORD
+    if word == "->":
+        return TokenType.OPERATOR
+    return TokenType.TEXT
+
+
+def _split_extended_header(content: str) -> _Pieces | None:
+    """Tokenize a git extended header line such as `rename from a.txt`."""
+    for keyword in _EXTENDED_HEADERS:
+        if not content.startswith(keyword + " "):
+            continue
+        value_start = len(keyword) + 1
+        value = content[value_start:]
+        pieces: _Pieces = [(TokenType.KEYWORD, keyword), (TokenType.WHITESPACE, " ")]
+        if keyword.startswith(("rename", "copy")):
+            pieces.append((TokenType.STRING, value))
+        elif keyword.endswith("mode"):
+            pieces.append((TokenType.NUMBER_OCT, value))
+        elif keyword.endswith("similarity index"):
+            pieces.append((TokenType.NUMBER, value))
+        else:
+            hashes, space, mode = value.partition(" ")
+            pieces.extend(_split_index_hashes(hashes))
+            if space:
+                pieces.append((TokenType.WHITESPACE, space))
+                pieces.append((TokenType.NUMBER_OCT, mode))
+        return pieces
+    return None
+
+
+def _split_index_hashes(text: str) -> _Pieces:
+    """Split `abc123..def456` or `a1,b2..c3` into hashes and separators."""
+    pieces: _Pieces = []
+    pos = 0
+    length = len(text)
+    while pos < length:
+        if text[pos] in _HEX_DIGITS:
+            hash_end = pos
+            while hash_end < length and text[hash_end] in _HEX_DIGITS:
+                hash_end += 1
+            pieces.append((TokenType.NUMBER_HEX, text[pos:hash_end]))
+        else:
+            hash_end = pos
+            while hash_end < length and text[hash_end] not in _HEX_DIGITS:
+                hash_end += 1
+            pieces.append((TokenType.PUNCTUATION, text[pos:hash_end]))
+        pos = hash_end
+    return pieces
+
+
+def _split_stat_line(content: str) -> _Pieces | None:
+    """Tokenize a `--stat` line (` path | 12 +++--`) or its summary line."""
+    if not content.startswith(" "):
+        return None
+    stripped = content.lstrip(" ")
+    indent = content[: len(content) - len(stripped)]
+
+    # " 3 files changed, 10 insertions(+), 2 deletions(-)"
+    if " changed" in stripped and stripped[:1].isdigit():
+        return [(TokenType.WHITESPACE, indent), *_split_words(stripped, _classify_stat_word)]
+
+    bar = stripped.rfind(" | ")
+    if bar <= 0:
+        return None
+    detail = stripped[bar + 3 :].lstrip(" ")
+    detail_start = len(stripped) - len(detail)
+    count, _, graph = detail.partition(" ")
+    if detail.startswith("Bin"):
+        detail_pieces = _split_words(detail, _classify_stat_word)
+    elif count.isdigit() and not graph.strip("+-"):
+        detail_pieces = [(TokenType.NUMBER_INTEGER, count)]
+        if graph or detail != count:
+            detail_pieces.append((TokenType.WHITESPACE, " "))
+        plus_end = len(graph) - len(graph.lstrip("+"))
+        if plus_end:
+            detail_pieces.append((TokenType.GENERIC_INSERTED, graph[:plus_end]))
+        if plus_end < len(graph):
+            detail_pieces.append((TokenType.GENERIC_DELETED, graph[plus_end:]))
+    else:
+        return None
+
+    path_end = len(stripped[:bar].rstrip(" "))
+    pieces = [(TokenType.WHITESPACE, indent), (TokenType.STRING, stripped[:path_end])]
+    pieces.append((TokenType.WHITESPACE, stripped[path_end : bar + 1]))
+    pieces.append((TokenType.PUNCTUATION, "|"))
+    pieces.append((TokenType.WHITESPACE, stripped[bar + 2 : detail_start]))
+    pieces.extend(detail_pieces)
+    return [(token_type, text) for token_type, text in pieces if text]
+
 
 class DiffStateMachineLexer(StateMachineLexer):
     """Diff/Patch lexer optimized with C-level str.find().
 
     Line-based format - uses find() to scan lines without allocating intermediate lists.
+    Most lines are a single token; headers, `--stat` lines and word-diff
+    lines are split into several.
     """
 
     name = "diff"
@@ -38,6 +262,14 @@ class DiffStateMachineLexer(StateMachineLexer):
         length = end if end is not None else len(code)
         line = 1
 
+        # Hunk state: lines left on each side, parent count, word-diff mode
+        in_hunk = False
+        old_left = 0
+        new_left = 0
+        parents = 1
+        word_diff = False
+        in_binary = False
+
         while pos < length:
             # Use C-level find to get line end - much faster than char-by-char
             line_end = code.find("\n", pos, length)
@@ -48,13 +280,81 @@ class DiffStateMachineLexer(StateMachineLexer):
                 has_newline = True
 
             content = code[pos:line_end]
+            token_type = TokenType.TEXT
+            pieces: _Pieces | None = None
+
+            # Combined diffs may end with deletions after the result lines run out
+            if in_hunk and parents > 1 and new_left <= 0:
+                prefix = content[:parents]
+                in_hunk = (
+                    len(prefix) == parents
+                    and "-" in prefix
+                    and not prefix.strip(" -")
+                    and not content.startswith("--- ")
+                )
 
             # Classify line by first character - most common cases first
-            if content:
+            if not content:
+                if in_hunk and not word_diff:
+                    old_left -= 1
+                    new_left -= 1
+            elif content.startswith("\\ "):
+                token_type = TokenType.COMMENT_SPECIAL
+            elif content[0] == "@" and content.startswith("@@"):
+                token_type = TokenType.GENERIC_SUBHEADING
+                counts = _parse_hunk_header(content)
+                in_hunk = counts is not None
+                if counts is not None:
+                    parents, old_left, new_left = counts
+            elif content[0] == "d" and content.startswith("diff "):
+                token_type = TokenType.GENERIC_HEADING
+                in_hunk = word_diff = in_binary = False
+            elif in_hunk and parents > 1:
+                prefix = content[:parents]
+                if "-" in prefix:
+                    token_type = TokenType.GENERIC_DELETED
+                elif "+" in prefix:
+                    token_type = TokenType.GENERIC_INSERTED
+                if "-" not in prefix:
+                    new_left -= 1
+            elif in_hunk:
+                first_char = content[0]
+                if not word_diff and (
+                    first_char not in " +-" or first_char == " " and _has_word_marker(content)
+                ):
+                    word_diff = True
+                if first_char == "-" and not word_diff:
+                    token_type = TokenType.GENERIC_DELETED
+                    old_left -= 1
+                elif first_char == "+" and not word_diff:
+                    token_type = TokenType.GENERIC_INSERTED
+                    new_left -= 1
+                elif first_char == "~" and word_diff:
+                    token_type = TokenType.PUNCTUATION
+                elif word_diff:
+                    pieces = _split_word_diff(content)
+                else:
+                    old_left -= 1
+                    new_left -= 1
+            elif in_binary and content.startswith(("literal ", "delta ")):
+                keyword, _, size = content.partition(" ")
+                pieces = [
+                    (TokenType.KEYWORD, keyword),
+                    (TokenType.WHITESPACE, " "),
+                    (TokenType.NUMBER_INTEGER, size),
+                ]
+            elif in_binary:
+                token_type = TokenType.STRING_OTHER
+            elif content.startswith("GIT binary patch"):
+                token_type = TokenType.COMMENT_SPECIAL
+                in_binary = True
+            elif content.startswith("Binary files ") and content.endswith(" differ"):
+                token_type = TokenType.COMMENT_SPECIAL
+            else:
                 first_char = content[0]
 
                 if first_char == " ":
-                    token_type = TokenType.TEXT
+                    pieces = _split_stat_line(content)
                 elif first_char == "+":
                     token_type = (
                         TokenType.GENERIC_HEADING
@@ -67,12 +367,6 @@ class DiffStateMachineLexer(StateMachineLexer):
                         if len(content) >= 3 and content[1:3] == "--"
                         else TokenType.GENERIC_DELETED
                     )
-                elif first_char == "@" and len(content) >= 2 and content[1] == "@":
-                    token_type = TokenType.GENERIC_SUBHEADING
-                elif first_char == "d" and content.startswith("diff "):
-                    token_type = TokenType.GENERIC_HEADING
-                elif first_char == "i" and content.startswith("index "):
-                    token_type = TokenType.COMMENT_SINGLE
                 elif (
                     first_char == "I"
                     and content.startswith("Index: ")
@@ -83,9 +377,18 @@ class DiffStateMachineLexer(StateMachineLexer):
                     token_type = TokenType.GENERIC_HEADING
                 elif first_char == "!":
                     token_type = TokenType.GENERIC_STRONG
-                else:
-                    token_type = TokenType.TEXT
+                elif first_char in "odnrsci":
+                    pieces = _split_extended_header(content)
+
+            if in_hunk and not word_diff and parents == 1 and old_left <= 0 and new_left <= 0:
+                in_hunk = False
 
+            if pieces is not None:
+                col = 1
+                for piece_type, text in pieces:
+                    yield Token(piece_type, text, line, col)
+                    col += len(text)
+            elif content:
                 yield Token(token_type, content, line, 1)
 
             pos = line_end
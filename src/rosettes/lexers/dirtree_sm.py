@@ -0,0 +1,227 @@
+"""Hand-written directory tree lexer for `tree` command output.
+
+O(n) guaranteed, zero regex, thread-safe.
+
+**Language Support:**
+
+- Unicode (`├── `, `└── `, `│   `) and ASCII (`|-- `, `` `-- ``) guides,
+  including the non-breaking spaces newer `tree` versions emit
+- `tree -F` suffixes (`dir/`, `run.sh*`, `link@`)
+- Symlinks (`name -> target`)
+- Metadata columns (`[4.0K]`, `[-rw-r--r--]`)
+- The `3 directories, 5 files` summary
+
+**File-Type Roles:**
+
+Entries are typed by what they are, so themes color a listing the way a
+file browser would:
+
+- Root and directories → NAME_NAMESPACE (a trailing `/`, or an entry
+  followed by deeper entries)
+- Source files → NAME_FUNCTION
+- Config and data files (JSON, YAML, TOML, INI, Dockerfile, ...) →
+  NAME_ATTRIBUTE
+- Docs (Markdown, text, README/LICENSE-style names) → STRING_DOC
+- Anything else → TEXT
+
+Languages are detected with `rosettes._registry.guess_language()`.
+
+**See Also:**
+
+- `rosettes.filetree`: Renders a directory on disk in this format
+- `rosettes.lexers.tree_sm`: Tree-sitter query lexer
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+
+from rosettes._config import LexerConfig
+from rosettes._registry import guess_language
+from rosettes._types import Token, TokenType
+from rosettes.lexers._state_machine import StateMachineLexer
+
+__all__ = ["DirectoryTreeStateMachineLexer"]
+
+# Four-character guide units; non-breaking spaces are normalized first
+_GUIDE_UNITS = frozenset(
+    {"├── ", "└── ", "│   ", "    ", "|-- ", "`-- ", "+-- ", "\\-- ", "|   "}
+)
+
+_CONFIG_LANGUAGES = frozenset(
+    {
+        "json",
+        "yaml",
+        "toml",
+        "ini",
+        "xml",
+        "csv",
+        "hcl",
+        "nginx",
+        "dockerfile",
+        "makefile",
+        "cue",
+        "pkl",
+    }
+)
+_DOC_LANGUAGES = frozenset({"markdown", "plaintext"})
+# File stems (the name up to the first dot) of docs
+_DOC_NAMES = frozenset(
+    {"README", "LICENSE", "LICENCE", "CHANGELOG", "CONTRIBUTING", "AUTHORS", "NOTICE"}
+)
+
+# `tree -F` type indicators other than the directory slash
+_INDICATORS = frozenset("*@=|")
+
+
+def _guide_end(content: str) -> tuple[int, int]:
+    """Measure the guide prefix of an entry line.
+
+    Returns:
+        Tuple of (end of the guide, depth in guide units).
+    """
+    pos = 0
+    depth = 0
+    while pos + 4 <= len(content):
+        unit = content[pos : pos + 4].replace("\xa0", " ")
+        if unit not in _GUIDE_UNITS:
+            break
+        pos += 4
+        depth += 1
+    return pos, depth
+
+
+def _is_summary(content: str) -> bool:
+    """Check for `N directories, M files` (or one of the two)."""
+    count, _, rest = content.partition(" ")
+    return count.isdigit() and rest.startswith(("director", "file"))
+
+
+def _file_type(name: str) -> TokenType:
+    """Pick the token type for a file from its detected language."""
+    if name[-1:] in _INDICATORS:
+        name = name[:-1]
+    if name.upper().partition(".")[0] in _DOC_NAMES:
+        return TokenType.STRING_DOC
+    language = guess_language(name)
+    if language is None:
+        return TokenType.TEXT
+    if language in _DOC_LANGUAGES:
+        return TokenType.STRING_DOC
+    if language in _CONFIG_LANGUAGES:
+        return TokenType.NAME_ATTRIBUTE
+    return TokenType.NAME_FUNCTION
+
+
+def _words(content: str) -> Iterator[tuple[int, str]]:
+    """Yield (offset, text) for words and the spaces between them."""
+    pos = 0
+    while pos < len(content):
+        space = content[pos] == " "
+        word_end = pos
+        while word_end < len(content) and (content[word_end] == " ") == space:
+            word_end += 1
+        yield pos, content[pos:word_end]
+        pos = word_end
+
+
+def _entry_tokens(content: str, guide_end: int, line: int, is_dir: bool) -> Iterator[Token]:
+    """Tokenize one entry line after its guide has been measured."""
+    if guide_end:
+        yield Token(TokenType.PUNCTUATION, content[:guide_end], line, 1)
+    pos = guide_end
+
+    # [size], [permissions] metadata columns
+    while content.startswith("[", pos):
+        close = content.find("]", pos)
+        if close == -1:
+            break
+        yield Token(TokenType.COMMENT, content[pos : close + 1], line, pos + 1)
+        pos = close + 1
+        space_end = pos
+        while space_end < len(content) and content[space_end] == " ":
+            space_end += 1
+        if space_end > pos:
+            yield Token(TokenType.WHITESPACE, content[pos:space_end], line, pos + 1)
+        pos = space_end
+
+    name_end = content.find(" -> ", pos)
+    if name_end == -1:
+        name_end = len(content)
+    name = content[pos:name_end]
+    if name:
+        is_dir = is_dir or name.endswith("/")
+        name_type = TokenType.NAME_NAMESPACE if is_dir else _file_type(name)
+        yield Token(name_type, name, line, pos + 1)
+
+    if name_end < len(content):
+        target = content[name_end + 4 :]
+        yield Token(TokenType.WHITESPACE, " ", line, name_end + 1)
+        yield Token(TokenType.OPERATOR, "->", line, name_end + 2)
+        yield Token(TokenType.WHITESPACE, " ", line, name_end + 4)
+        if target:
+            target_type = TokenType.NAME_NAMESPACE if is_dir else _file_type(target)
+            yield Token(target_type, target, line, name_end + 5)
+
+
+class DirectoryTreeStateMachineLexer(StateMachineLexer):
+    """Lexer for directory listings in `tree` format."""
+
+    name = "tree-listing"
+    aliases = ("dir-listing", "tree-output")
+    filenames = ()
+    mimetypes = ()
+
+    def tokenize(
+        self,
+        code: str,
+        config: LexerConfig | None = None,
+        *,
+        start: int = 0,
+        end: int | None = None,
+    ) -> Iterator[Token]:
+        pos = start
+        length = end if end is not None else len(code)
+        line = 1
+        seen_root = False
+
+        while pos < length:
+            line_end = code.find("\n", pos, length)
+            if line_end == -1:
+                line_end = length
+            content = code[pos:line_end].rstrip("\r")
+            next_pos = line_end + 1
+
+            if content.strip():
+                if _is_summary(content):
+                    for word_start, word in _words(content):
+                        if word.isdigit():
+                            word_type = TokenType.NUMBER_INTEGER
+                        elif word[0] == " ":
+                            word_type = TokenType.WHITESPACE
+                        else:
+                            word_type = TokenType.COMMENT
+                        yield Token(word_type, word, line, word_start + 1)
+                else:
+                    guide_end, depth = _guide_end(content)
+                    # A directory is followed by a deeper entry
+                    has_children = False
+                    if next_pos < length:
+                        next_end = code.find("\n", next_pos, length)
+                        next_line = code[next_pos : next_end if next_end != -1 else length]
+                        has_children = _guide_end(next_line)[1] > depth
+                    is_root = not seen_root and guide_end == 0
+                    seen_root = True
+                    yield from _entry_tokens(content, guide_end, line, is_root or has_children)
+            elif content:
+                yield Token(TokenType.WHITESPACE, content, line, 1)
+
+            content_end = len(content)
+            if pos + content_end < line_end:
+                trailing = code[pos + content_end : line_end]
+                yield Token(TokenType.WHITESPACE, trailing, line, content_end + 1)
+                content_end = line_end - pos
+            if line_end < length:
+                yield Token(TokenType.WHITESPACE, "\n", line, content_end + 1)
+            pos = next_pos
+            line += 1
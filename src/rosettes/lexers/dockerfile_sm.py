@@ -12,6 +12,7 @@ from rosettes._types import Token, TokenType
 from rosettes.lexers._scanners import (
     DIGITS,
     HashCommentsMixin,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -119,6 +120,7 @@ class DockerfileStateMachineLexer(
             # Strings
             if char in "\"'":
                 start = pos
+                start_line = line
                 quote = char
                 pos += 1
                 while pos < length and code[pos] != quote:
@@ -131,7 +133,7 @@ class DockerfileStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 at_line_start = False
                 continue
 
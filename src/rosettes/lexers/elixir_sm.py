@@ -17,6 +17,7 @@ from rosettes.lexers._scanners import (
     HashCommentsMixin,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -179,7 +180,9 @@ class ElixirStateMachineLexer(
                         start = pos
                         pos += 2
                         pos, _ = scan_string(code, pos, '"')
-                        yield Token(TokenType.STRING_SYMBOL, code[start:pos], line, col)
+                        yield from split_escapes(
+                            code, start, pos, TokenType.STRING_SYMBOL, line, col
+                        )
                         continue
                     if next_char.isalpha() or next_char == "_":
                         start = pos
@@ -195,6 +198,7 @@ class ElixirStateMachineLexer(
             # Sigils ~r/.../ ~s"..." etc.
             if char == "~" and pos + 1 < length and code[pos + 1].isalpha():
                 start = pos
+                start_line = line
                 pos += 2
                 sigil_type = code[pos - 1]
                 if pos < length:
@@ -214,8 +218,13 @@ class ElixirStateMachineLexer(
                     # Modifiers
                     while pos < length and code[pos].isalpha():
                         pos += 1
-                token_type = TokenType.STRING_REGEX if sigil_type in "rR" else TokenType.STRING
-                yield Token(token_type, code[start:pos], line, col)
+                if sigil_type in "rR":
+                    yield Token(TokenType.STRING_REGEX, code[start:pos], start_line, col)
+                elif sigil_type.islower():
+                    # Lowercase sigils interpret escapes; uppercase ones are raw
+                    yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
+                else:
+                    yield Token(TokenType.STRING, code[start:pos], start_line, col)
                 continue
 
             # Heredocs """...""" or '''...'''
@@ -223,7 +232,7 @@ class ElixirStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING_DOC, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_DOC, line, col)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -248,7 +257,7 @@ class ElixirStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Charlists
@@ -256,7 +265,7 @@ class ElixirStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
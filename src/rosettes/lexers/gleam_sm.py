@@ -10,6 +10,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     BINARY_DIGITS,
     DIGITS,
@@ -17,6 +18,7 @@ from rosettes.lexers._scanners import (
     OCTAL_DIGITS,
     CStyleCommentsMixin,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -83,6 +85,9 @@ class GleamStateMachineLexer(
     filenames = ("*.gleam",)
     mimetypes = ("text/x-gleam",)
 
+    # Module (////) and item (///) doc comments
+    DOC_COMMENT_PREFIXES = ("////", "///")
+
     def tokenize(
         self,
         code: str,
@@ -115,12 +120,14 @@ class GleamStateMachineLexer(
             # Comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -138,7 +145,7 @@ class GleamStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
@@ -44,6 +44,7 @@ from collections.abc import Iterator
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
 from rosettes.lexers._scanners import (
+    GO_ESCAPES,
     IDENT_START,
     CStyleCommentsMixin,
     CStyleNumbersMixin,
@@ -52,6 +53,7 @@ from rosettes.lexers._scanners import (
     OperatorConfig,
     scan_identifier,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -178,6 +180,9 @@ class GoStateMachineLexer(
     filenames = ("*.go",)
     mimetypes = ("text/x-go",)
 
+    # Go doc comments are ordinary // comments above declarations
+    DOC_COMMENT_PREFIXES = ()
+
     # Go has imaginary numbers (1i)
     NUMBER_CONFIG = NumberConfig(
         imaginary_suffix="i",
@@ -276,7 +281,7 @@ class GoStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col, GO_ESCAPES)
                 continue
 
             # Runes (character literals)
@@ -284,7 +289,9 @@ class GoStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING_CHAR, line, col, GO_ESCAPES
+                )
                 continue
 
             # Numbers
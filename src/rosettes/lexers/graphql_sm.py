@@ -14,6 +14,7 @@ from rosettes.lexers._scanners import (
     HashCommentsMixin,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -138,7 +139,7 @@ class GraphqlStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
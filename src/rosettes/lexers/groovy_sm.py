@@ -9,6 +9,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_CONT,
     IDENT_START,
@@ -19,6 +20,7 @@ from rosettes.lexers._scanners import (
     OperatorConfig,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -216,12 +218,14 @@ class GroovyStateMachineLexer(
             # Comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -239,7 +243,7 @@ class GroovyStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -273,14 +277,14 @@ class GroovyStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             if char == "'":
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Slashy strings /regex/
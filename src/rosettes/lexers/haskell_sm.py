@@ -13,6 +13,7 @@ from rosettes.lexers._scanners import (
     DIGITS,
     HEX_DIGITS,
     OCTAL_DIGITS,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -160,6 +161,7 @@ class HaskellStateMachineLexer(StateMachineLexer):
             # Strings
             if char == '"':
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != '"':
                     if code[pos] == "\\" and pos + 1 < length:
@@ -171,7 +173,7 @@ class HaskellStateMachineLexer(StateMachineLexer):
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 continue
 
             # Character literals
@@ -184,7 +186,7 @@ class HaskellStateMachineLexer(StateMachineLexer):
                     pos += 1
                 if pos < length and code[pos] == "'":
                     pos += 1
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_CHAR, line, col)
                 continue
 
             # Numbers
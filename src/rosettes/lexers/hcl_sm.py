@@ -13,6 +13,7 @@ from rosettes.lexers._scanners import (
     DIGITS,
     CStyleCommentsMixin,
     HashCommentsMixin,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -175,6 +176,9 @@ class HclStateMachineLexer(
     filenames = ("*.tf", "*.tfvars", "*.hcl")
     mimetypes = ("text/x-hcl", "application/x-terraform")
 
+    # HCL has no dedicated doc comment syntax
+    DOC_COMMENT_PREFIXES = ()
+
     def tokenize(
         self,
         code: str,
@@ -283,6 +287,7 @@ class HclStateMachineLexer(
             # Strings
             if char == '"':
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != '"':
                     if code[pos] == "\\" and pos + 1 < length:
@@ -294,7 +299,7 @@ class HclStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 continue
 
             # Numbers
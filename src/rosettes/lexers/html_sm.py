@@ -146,12 +146,14 @@ class HtmlStateMachineLexer(StateMachineLexer):
                         # Whitespace
                         if code[pos] in " \t\n\r":
                             ws_start = pos
+                            ws_line = line
+                            ws_col = pos - line_start + 1
                             while pos < length and code[pos] in " \t\n\r":
                                 if code[pos] == "\n":
                                     line += 1
                                     line_start = pos + 1
                                 pos += 1
-                            yield Token(TokenType.WHITESPACE, code[ws_start:pos], line, col)
+                            yield Token(TokenType.WHITESPACE, code[ws_start:pos], ws_line, ws_col)
                             continue
 
                         # End of tag
@@ -189,6 +191,8 @@ class HtmlStateMachineLexer(StateMachineLexer):
                         if code[pos] in "\"'":
                             quote = code[pos]
                             val_start = pos
+                            val_line = line
+                            val_col = pos - line_start + 1
                             pos += 1
                             while pos < length and code[pos] != quote:
                                 if code[pos] == "\n":
@@ -197,12 +201,7 @@ class HtmlStateMachineLexer(StateMachineLexer):
                                 pos += 1
                             if pos < length:
                                 pos += 1
-                            yield Token(
-                                TokenType.STRING,
-                                code[val_start:pos],
-                                line,
-                                val_start - line_start + 1,
-                            )
+                            yield Token(TokenType.STRING, code[val_start:pos], val_line, val_col)
                             continue
 
                         # Unquoted attribute value
@@ -227,6 +226,7 @@ class HtmlStateMachineLexer(StateMachineLexer):
             # Text content
             if char != "<":
                 start = pos
+                start_line = line
                 while pos < length and code[pos] != "<":
                     if code[pos] == "\n":
                         line += 1
@@ -234,9 +234,9 @@ class HtmlStateMachineLexer(StateMachineLexer):
                     pos += 1
                 text = code[start:pos]
                 if text.strip():
-                    yield Token(TokenType.TEXT, text, line, col)
+                    yield Token(TokenType.TEXT, text, start_line, col)
                 elif text:
-                    yield Token(TokenType.WHITESPACE, text, line, col)
+                    yield Token(TokenType.WHITESPACE, text, start_line, col)
                 continue
 
             yield Token(TokenType.ERROR, char, line, col)
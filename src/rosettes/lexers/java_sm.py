@@ -40,6 +40,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START_DOLLAR,
     CStyleCommentsMixin,
@@ -47,10 +48,12 @@ from rosettes.lexers._scanners import (
     CStyleOperatorsMixin,
     NumberConfig,
     OperatorConfig,
+    is_doc_comment,
     scan_block_comment,
     scan_identifier,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -160,6 +163,9 @@ class JavaStateMachineLexer(
     filenames = ("*.java",)
     mimetypes = ("text/x-java",)
 
+    # Javadoc
+    DOC_COMMENT_PREFIXES = ("/**",)
+
     NUMBER_CONFIG = NumberConfig(
         integer_suffixes=("l", "L"),
         float_suffixes=("f", "F", "d", "D"),
@@ -233,12 +239,15 @@ class JavaStateMachineLexer(
                     continue
                 if next_char == "*":
                     start = pos
-                    is_javadoc = pos + 2 < length and code[pos + 2] == "*"
                     pos = scan_block_comment(code, pos + 2, "*/")
                     value = code[start:pos]
                     newlines = value.count("\n")
-                    token_type = TokenType.STRING_DOC if is_javadoc else TokenType.COMMENT_MULTILINE
-                    yield Token(token_type, value, line, col)
+                    if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                        yield from split_doc_markup(
+                            code, start, pos, TokenType.COMMENT_DOC, line, col
+                        )
+                    else:
+                        yield Token(TokenType.COMMENT_MULTILINE, value, line, col)
                     if newlines:
                         line += newlines
                         line_start = start + value.rfind("\n") + 1
@@ -258,7 +267,7 @@ class JavaStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING_DOC, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_DOC, line, col)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -269,7 +278,7 @@ class JavaStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Characters
@@ -277,7 +286,7 @@ class JavaStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_CHAR, line, col)
                 continue
 
             # Numbers
@@ -48,6 +48,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START_DOLLAR,
     # Mixins
@@ -56,11 +57,13 @@ from rosettes.lexers._scanners import (
     CStyleOperatorsMixin,
     CStyleStringsMixin,
     # Configuration
+    JS_ESCAPES,
     NumberConfig,
     OperatorConfig,
     StringConfig,
     # Helpers
     scan_identifier,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -202,6 +205,7 @@ class JavaScriptStateMachineLexer(
     Configuration:
         NUMBER_CONFIG: Enables BigInt suffix ('n')
         STRING_CONFIG: Enables template literals (backticks)
+        ESCAPE_CONFIG: Adds `\\u{...}` code point escapes
         OPERATOR_CONFIG: JS-specific operators (===, ??, ?., etc.)
 
     Token Classification:
@@ -233,6 +237,9 @@ class JavaScriptStateMachineLexer(
         backtick=True,  # Template literals
     )
 
+    # Escapes split out of strings (JavaScript adds \u{...} code points)
+    ESCAPE_CONFIG = JS_ESCAPES
+
     # Configure operators
     OPERATOR_CONFIG = OperatorConfig(
         three_char=frozenset({"===", "!==", ">>>", "**=", "&&=", "||=", "??="}),
@@ -298,23 +305,27 @@ class JavaScriptStateMachineLexer(
             # Comments (// and /* */)
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
                 # Track newlines in block comments
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
             # Strings (", ', `)
             token, new_pos, newlines = self._try_string(code, pos, line, col)
             if token:
+                yield from split_escapes(
+                    code, pos, new_pos, token.type, line, col, self.ESCAPE_CONFIG
+                )
                 if newlines:
                     line += newlines
                     line_start = pos + token.value.rfind("\n") + 1
-                yield token
                 pos = new_pos
                 continue
 
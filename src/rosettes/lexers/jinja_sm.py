@@ -18,9 +18,11 @@ from rosettes.lexers._scanners import (
     DIGITS,
     IDENT_CONT,
     IDENT_START,
+    PYTHON_ESCAPES,
     CStyleNumbersMixin,
     NumberConfig,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -397,7 +399,9 @@ class JinjaStateMachineLexer(CStyleNumbersMixin, StateMachineLexer):
                 quote = char
                 pos += 1
                 pos, newlines = scan_string(code, pos, quote)
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, line, col, PYTHON_ESCAPES
+                )
                 line += newlines
                 if newlines > 0:
                     last_newline = code.rfind("\n", start, pos)
@@ -46,6 +46,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._scanners import JSON_ESCAPES, split_escapes
 from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["JsonStateMachineLexer"]
@@ -116,7 +117,9 @@ class JsonStateMachineLexer(StateMachineLexer):
                         pos += 2  # Skip escape sequence
                     else:
                         pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, line, col, JSON_ESCAPES
+                )
                 continue
 
             # Numbers - inline for speed
@@ -17,6 +17,7 @@ from rosettes.lexers._scanners import (
     HashCommentsMixin,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -250,7 +251,7 @@ class JuliaStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -261,7 +262,7 @@ class JuliaStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Character literals
@@ -274,7 +275,7 @@ class JuliaStateMachineLexer(
                     pos += 1
                 if pos < length and code[pos] == "'":
                     pos += 1
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_CHAR, line, col)
                 continue
 
             # Raw strings r"..."
@@ -9,6 +9,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START,
     CStyleCommentsMixin,
@@ -16,10 +17,12 @@ from rosettes.lexers._scanners import (
     CStyleOperatorsMixin,
     NumberConfig,
     OperatorConfig,
+    is_doc_comment,
     scan_block_comment,
     scan_identifier,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -147,6 +150,9 @@ class KotlinStateMachineLexer(
     filenames = ("*.kt", "*.kts")
     mimetypes = ("text/x-kotlin",)
 
+    # KDoc
+    DOC_COMMENT_PREFIXES = ("/**",)
+
     NUMBER_CONFIG = NumberConfig(
         integer_suffixes=("u", "U", "L", "uL", "UL"),
         float_suffixes=("f", "F"),
@@ -219,12 +225,15 @@ class KotlinStateMachineLexer(
                     continue
                 if next_char == "*":
                     start = pos
-                    is_kdoc = pos + 2 < length and code[pos + 2] == "*"
                     pos = scan_block_comment(code, pos + 2, "*/")
                     value = code[start:pos]
                     newlines = value.count("\n")
-                    token_type = TokenType.STRING_DOC if is_kdoc else TokenType.COMMENT_MULTILINE
-                    yield Token(token_type, value, line, col)
+                    if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                        yield from split_doc_markup(
+                            code, start, pos, TokenType.COMMENT_DOC, line, col
+                        )
+                    else:
+                        yield Token(TokenType.COMMENT_MULTILINE, value, line, col)
                     if newlines:
                         line += newlines
                         line_start = start + value.rfind("\n") + 1
@@ -255,7 +264,7 @@ class KotlinStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Character literals
@@ -263,7 +272,7 @@ class KotlinStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_CHAR, line, col)
                 continue
 
             # Numbers
@@ -0,0 +1,68 @@
+"""Hand-written Less lexer using state machine approach.
+
+O(n) guaranteed, zero regex, thread-safe.
+
+**Language Support:**
+
+- Everything in the CSS lexer
+- Variables (`@name: value`, `@@name`) alongside CSS at-rules
+- Mixins (`.mixin();`, `#namespace > .mixin()`) and guards (`when`, `and`, `not`)
+- Interpolation (`@{name}`) in selectors, properties, and strings
+- Operations, escaping (`~"..."`), and function calls
+- Line comments (`//`)
+
+**Token Classification:**
+
+- Variables: `@gap` → NAME_VARIABLE (CSS at-rules like `@media` stay KEYWORD)
+- Guards: `when`, `and`, `not` → KEYWORD
+- Interpolation: `@{` and `}` → STRING_INTERPOL
+- Functions: `darken(` → NAME_FUNCTION
+
+**Thread-Safety:**
+
+Uses only local variables in `tokenize()`.
+
+**See Also:**
+
+- `rosettes.lexers.css_sm`: CSS lexer
+- `rosettes.lexers._stylesheet`: Shared stylesheet scanner
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+
+from rosettes._config import LexerConfig
+from rosettes._types import Token
+from rosettes.lexers._state_machine import StateMachineLexer
+from rosettes.lexers._stylesheet import LESS, scan_stylesheet
+
+__all__ = ["LessStateMachineLexer"]
+
+
+class LessStateMachineLexer(StateMachineLexer):
+    """Less lexer with variables, mixins, guards, and interpolation.
+
+    Example:
+        >>> from rosettes import get_lexer
+        >>> lexer = get_lexer("less")
+        >>> tokens = list(lexer.tokenize("@gap: 4px;"))
+        >>> tokens[0].type
+        <TokenType.NAME_VARIABLE: 'nv'>
+    """
+
+    name = "less"
+    aliases = ()
+    filenames = ("*.less",)
+    mimetypes = ("text/x-less-css",)
+
+    def tokenize(
+        self,
+        code: str,
+        config: LexerConfig | None = None,
+        *,
+        start: int = 0,
+        end: int | None = None,
+    ) -> Iterator[Token]:
+        tab_size = (config or LexerConfig()).tab_size
+        return scan_stylesheet(code, start, end, LESS, tab_size=tab_size)
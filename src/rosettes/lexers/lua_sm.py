@@ -13,6 +13,7 @@ from rosettes.lexers._scanners import (
     DIGITS,
     HEX_DIGITS,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -179,7 +180,7 @@ class LuaStateMachineLexer(StateMachineLexer):
                 quote = char
                 pos += 1
                 pos, _ = scan_string(code, pos, quote)
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
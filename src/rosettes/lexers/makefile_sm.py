@@ -9,7 +9,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
-from rosettes.lexers._scanners import HashCommentsMixin
+from rosettes.lexers._scanners import HashCommentsMixin, split_escapes
 from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["MakefileStateMachineLexer"]
@@ -238,7 +238,7 @@ class MakefileStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 at_line_start = False
                 continue
 
@@ -14,6 +14,15 @@ O(n) guaranteed, zero regex, thread-safe.
 - Horizontal rules (`---`, `***`, `___`)
 - Unordered lists (`-`, `*`, `+`) and ordered lists (`1.`)
 
+GitHub-flavored Markdown extensions:
+
+- Tables with header, delimiter (`| :--- |`), and body rows
+- Task list checkboxes (`- [ ]`, `- [x]`)
+- Footnote references and definitions (`[^1]`, `[^1]: text`)
+- Autolinks (`<https://...>`, `<user@example.com>`, bare `https://` and `www.`)
+- Raw HTML blocks, delegated to the HTML lexer
+- YAML (`---`) and TOML (`+++`) frontmatter, delegated to those lexers
+
 **Design Philosophy:**
 
 Markdown lexing is line-oriented. The lexer tracks at_line_start
@@ -24,6 +33,10 @@ Fenced code blocks receive special handling: the content between
 ` ``` ` markers is yielded as a single token, preserving the language
 hint for potential nested highlighting.
 
+Frontmatter and HTML blocks are embedded languages: their extent is found
+by the Markdown lexer, and their content is tokenized by the YAML, TOML,
+or HTML lexer with line and column positions shifted into place.
+
 **Performance:**
 
 ~50µs per 100-line file.
@@ -35,6 +48,8 @@ Uses only local variables in `tokenize()`.
 **See Also:**
 
 - `rosettes.lexers.rst_sm`: reStructuredText lexer
+- `rosettes.lexers.html_sm`, `rosettes.lexers.yaml_sm`, `rosettes.lexers.toml_sm`:
+  Lexers for embedded HTML blocks and frontmatter
 """
 
 from __future__ import annotations
@@ -42,11 +57,34 @@ from __future__ import annotations
 from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
+from rosettes._registry import get_lexer
 from rosettes._types import Token, TokenType
 from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["MarkdownStateMachineLexer"]
 
+# Characters that end a run of regular text
+_TEXT_STOP: frozenset[str] = frozenset("\n`*_[]!#>-+|<")
+
+# Frontmatter fences → embedded language
+_FRONTMATTER: dict[str, str] = {"---": "yaml", "+++": "toml"}
+
+# HTML elements whose blocks run to their closing tag rather than a blank line
+_HTML_RAW_TAGS: frozenset[str] = frozenset({"pre", "script", "style", "textarea"})
+
+# Task list checkboxes
+_TASK_BOXES: frozenset[str] = frozenset({"[ ]", "[x]", "[X]"})
+
+# Bare autolink prefixes (GFM extended autolinks)
+_URL_PREFIXES: tuple[str, ...] = ("https://", "http://", "www.")
+
+# Trailing characters excluded from bare autolinks
+_URL_TRAILING: frozenset[str] = frozenset("?!.,:*_~'\"")
+
+# Table row states
+_TABLE_HEADER = 1
+_TABLE_BODY = 2
+
 
 class MarkdownStateMachineLexer(StateMachineLexer):
     """Markdown lexer with CommonMark syntax support.
@@ -60,7 +98,11 @@ class MarkdownStateMachineLexer(StateMachineLexer):
     - STRING: Fenced code blocks and inline code
     - GENERIC_STRONG: Bold text (`**text**`)
     - GENERIC_EMPH: Italic text (`*text*`)
-    - NAME_TAG: Link/image markers and URLs
+    - NAME_LABEL: Links, images, and autolinks
+    - NAME_DECORATOR: Footnote references and definitions (`[^1]`)
+    - KEYWORD_CONSTANT: Task list checkboxes (`[ ]`, `[x]`)
+    - PUNCTUATION: List markers, rules, table pipes and delimiter rows
+    - Frontmatter and HTML blocks: tokens from the YAML, TOML, or HTML lexer
 
     **Example:**
 
@@ -91,6 +133,19 @@ class MarkdownStateMachineLexer(StateMachineLexer):
         line = 1
         line_start = start
         at_line_start = True
+        table_row = 0
+
+        # Frontmatter --- or +++ (must open the document)
+        frontmatter = _find_frontmatter(code, pos, length)
+        if frontmatter is not None:
+            language, content_end, pos = frontmatter
+            yield Token(TokenType.PUNCTUATION, code[start : start + 3], 1, 1)
+            yield Token(TokenType.WHITESPACE, "\n", 1, 4)
+            yield from _delegate(language, code, start + 4, content_end, 2, 1)
+            line = 2 + code.count("\n", start + 4, content_end)
+            line_start = content_end
+            yield Token(TokenType.PUNCTUATION, code[content_end:pos], line, 1)
+            at_line_start = False
 
         while pos < length:
             char = code[pos]
@@ -103,8 +158,62 @@ class MarkdownStateMachineLexer(StateMachineLexer):
                 line += 1
                 line_start = pos
                 at_line_start = True
+                if table_row == _TABLE_HEADER:
+                    # Delimiter row | --- | :---: |
+                    eol = _line_end(code, pos, length)
+                    yield Token(TokenType.PUNCTUATION, code[pos:eol], line, 1)
+                    pos = eol
+                    table_row = _TABLE_BODY
+                    at_line_start = False
+                elif table_row == _TABLE_BODY:
+                    if _is_table_row(code, pos, length):
+                        at_line_start = False
+                    else:
+                        table_row = 0
                 continue
 
+            # Tables: a row with pipes followed by a delimiter row
+            if at_line_start and table_row == 0 and _starts_table(code, pos, length):
+                table_row = _TABLE_HEADER
+                at_line_start = False
+
+            # Table pipes (\| is a literal pipe inside a cell)
+            if table_row and char == "|" and (pos == line_start or code[pos - 1] != "\\"):
+                yield Token(TokenType.PUNCTUATION, char, line, col)
+                pos += 1
+                continue
+
+            # Table header cells
+            if table_row == _TABLE_HEADER:
+                start = pos
+                while pos < length and code[pos] not in "|\n":
+                    pos += 1 if code[pos] != "\\" else 2
+                pos = min(pos, length)
+                yield from _split_cell(code, start, pos, line, col)
+                continue
+
+            # Autolinks <https://example.com> <user@example.com>
+            if char == "<":
+                link_end = _scan_angle_autolink(code, pos, length)
+                if link_end > pos:
+                    yield Token(TokenType.NAME_LABEL, code[pos:link_end], line, col)
+                    pos = link_end
+                    at_line_start = False
+                    continue
+
+            # HTML blocks (delegated to the HTML lexer)
+            if at_line_start and char == "<":
+                block_end = _scan_html_block(code, pos, length)
+                if block_end > pos:
+                    yield from _delegate("html", code, pos, block_end, line, col)
+                    newlines = code.count("\n", pos, block_end)
+                    if newlines:
+                        line += newlines
+                        line_start = code.rfind("\n", pos, block_end) + 1
+                    pos = block_end
+                    at_line_start = False
+                    continue
+
             # Fenced code blocks ```
             if at_line_start and char == "`" and pos + 2 < length and code[pos : pos + 3] == "```":
                 start = pos
@@ -169,6 +278,12 @@ class MarkdownStateMachineLexer(StateMachineLexer):
             if at_line_start and char in "-*+" and pos + 1 < length and code[pos + 1] in " \t":
                 yield Token(TokenType.PUNCTUATION, char, line, col)
                 pos += 1
+                # Task list checkbox - [ ] / - [x]
+                box_end = _task_box_end(code, pos, length)
+                if box_end > pos:
+                    yield Token(TokenType.WHITESPACE, code[pos], line, col + 1)
+                    yield Token(TokenType.KEYWORD_CONSTANT, code[pos + 1 : box_end], line, col + 2)
+                    pos = box_end
                 at_line_start = False
                 continue
 
@@ -185,6 +300,14 @@ class MarkdownStateMachineLexer(StateMachineLexer):
                 ):
                     pos += 1
                     yield Token(TokenType.PUNCTUATION, code[start:pos], line, col)
+                    box_end = _task_box_end(code, pos, length)
+                    if box_end > pos:
+                        box_col = pos - line_start + 1
+                        yield Token(TokenType.WHITESPACE, code[pos], line, box_col)
+                        yield Token(
+                            TokenType.KEYWORD_CONSTANT, code[pos + 1 : box_end], line, box_col + 1
+                        )
+                        pos = box_end
                 else:
                     yield Token(TokenType.TEXT, code[start:pos], line, col)
                 at_line_start = False
@@ -238,6 +361,18 @@ class MarkdownStateMachineLexer(StateMachineLexer):
                 at_line_start = False
                 continue
 
+            # Footnotes [^1] and definitions [^1]:
+            if char == "[" and pos + 1 < length and code[pos + 1] == "^":
+                label_end = _scan_footnote(code, pos, length)
+                if label_end > pos:
+                    yield Token(TokenType.NAME_DECORATOR, code[pos:label_end], line, col)
+                    pos = label_end
+                    if pos < length and code[pos] == ":":
+                        yield Token(TokenType.PUNCTUATION, ":", line, pos - line_start + 1)
+                        pos += 1
+                    at_line_start = False
+                    continue
+
             # Links [text](url) or [text][ref]
             if char == "[":
                 start = pos
@@ -288,12 +423,28 @@ class MarkdownStateMachineLexer(StateMachineLexer):
                 at_line_start = False
                 continue
 
+            # Bare autolinks https://example.com www.example.com
+            if char in "hw" and (pos == line_start or not code[pos - 1].isalnum()):
+                url_end = _scan_bare_url(code, pos, length)
+                if url_end > pos:
+                    yield Token(TokenType.NAME_LABEL, code[pos:url_end], line, col)
+                    pos = url_end
+                    at_line_start = False
+                    continue
+
             # Regular text
             start = pos
-            while pos < length and code[pos] not in "\n`*_[]!#>-+":
+            while pos < length and code[pos] not in _TEXT_STOP:
                 if code[pos] in " \t":
                     pos += 1
                 else:
+                    if (
+                        code[pos] in "hw"
+                        and pos > start
+                        and not code[pos - 1].isalnum()
+                        and _scan_bare_url(code, pos, length) > pos
+                    ):
+                        break
                     pos += 1
                     at_line_start = False
             if pos > start:
@@ -311,3 +462,214 @@ class MarkdownStateMachineLexer(StateMachineLexer):
             yield Token(TokenType.TEXT, char, line, col)
             pos += 1
             at_line_start = False
+
+
+def _delegate(
+    language: str,
+    code: str,
+    start: int,
+    end: int,
+    line: int,
+    col: int,
+) -> Iterator[Token]:
+    """Tokenize an embedded block with another lexer.
+
+    The embedded lexer counts lines from 1 and columns from `start`, so
+    tokens are shifted to `line`, and tokens on the first line to `col`.
+    """
+    line_offset = line - 1
+    col_offset = col - 1
+    for token in get_lexer(language).tokenize(code, start=start, end=end):
+        if token.line == 1:
+            yield Token(token.type, token.value, line, token.column + col_offset)
+        else:
+            yield Token(token.type, token.value, token.line + line_offset, token.column)
+
+
+def _line_end(code: str, pos: int, end: int) -> int:
+    """Return the position of the newline ending pos's line (or end)."""
+    eol = code.find("\n", pos, end)
+    return end if eol == -1 else eol
+
+
+def _find_frontmatter(code: str, pos: int, end: int) -> tuple[str, int, int] | None:
+    """Find frontmatter opening at pos.
+
+    Returns (language, closing fence position, position after closing fence),
+    or None if pos doesn't open a terminated frontmatter block.
+    """
+    fence = code[pos : pos + 3]
+    language = _FRONTMATTER.get(fence)
+    if language is None or _line_end(code, pos, end) != pos + 3:
+        return None
+
+    scan = pos + 4
+    while scan < end:
+        eol = _line_end(code, scan, end)
+        if code[scan:eol].rstrip() == fence:
+            return language, scan, scan + 3
+        scan = eol + 1
+    return None
+
+
+def _starts_table(code: str, pos: int, end: int) -> bool:
+    """Check for a table header row at pos followed by a matching delimiter row."""
+    eol = _line_end(code, pos, end)
+    if eol == end or code.find("|", pos, eol) == -1:
+        return False
+    delimiter_end = _line_end(code, eol + 1, end)
+    delimiter = _table_cells(code[eol + 1 : delimiter_end])
+    if not delimiter or len(delimiter) != len(_table_cells(code[pos:eol])):
+        return False
+    for cell in delimiter:
+        dashes = cell.strip().removeprefix(":").removesuffix(":")
+        if not dashes or dashes.strip("-"):
+            return False
+    return True
+
+
+def _is_table_row(code: str, pos: int, end: int) -> bool:
+    """Check whether the line at pos continues a table body."""
+    eol = _line_end(code, pos, end)
+    return code.find("|", pos, eol) != -1 and bool(code[pos:eol].strip())
+
+
+def _table_cells(row: str) -> list[str]:
+    """Split a table row into cells, ignoring outer pipes."""
+    row = row.strip()
+    if row.startswith("|"):
+        row = row[1:]
+    if row.endswith("|") and not row.endswith("\\|"):
+        row = row[:-1]
+    return row.split("|") if row else []
+
+
+def _split_cell(code: str, start: int, end: int, line: int, col: int) -> Iterator[Token]:
+    """Emit a table header cell as whitespace and strong text."""
+    text = code[start:end]
+    content = text.strip()
+    if not content:
+        yield Token(TokenType.WHITESPACE, text, line, col)
+        return
+    leading = len(text) - len(text.lstrip())
+    if leading:
+        yield Token(TokenType.WHITESPACE, text[:leading], line, col)
+    yield Token(TokenType.GENERIC_STRONG, content, line, col + leading)
+    trailing = leading + len(content)
+    if trailing < len(text):
+        yield Token(TokenType.WHITESPACE, text[trailing:], line, col + trailing)
+
+
+def _task_box_end(code: str, pos: int, end: int) -> int:
+    """Return the end of a ` [ ]`/` [x]` checkbox after a list marker, or pos."""
+    box_end = pos + 4
+    if (
+        box_end <= end
+        and code[pos] in " \t"
+        and code[pos + 1 : box_end] in _TASK_BOXES
+        and (box_end == end or code[box_end] in " \t\n")
+    ):
+        return box_end
+    return pos
+
+
+def _scan_footnote(code: str, pos: int, end: int) -> int:
+    """Scan a `[^label]` footnote marker, returning pos if there is none."""
+    scan = pos + 2
+    while scan < end and code[scan] not in "] \t\n[":
+        scan += 1
+    if scan == pos + 2 or scan >= end or code[scan] != "]":
+        return pos
+    return scan + 1
+
+
+def _scan_angle_autolink(code: str, pos: int, end: int) -> int:
+    """Scan `<scheme:...>` or `<user@example.com>`, returning pos if neither."""
+    close = pos + 1
+    while close < end and code[close] not in " \t\n<>":
+        close += 1
+    if close >= end or code[close] != ">" or close == pos + 1:
+        return pos
+
+    target = code[pos + 1 : close]
+    scheme, colon, _ = target.partition(":")
+    if (
+        colon
+        and 2 <= len(scheme) <= 32
+        and scheme[0].isalpha()
+        and all(c.isalnum() or c in "+.-" for c in scheme)
+    ):
+        return close + 1
+    local, at, domain = target.partition("@")
+    if at and local and "." in domain and not domain.startswith("."):
+        return close + 1
+    return pos
+
+
+def _scan_bare_url(code: str, pos: int, end: int) -> int:
+    """Scan a GFM extended autolink (`https://...`, `www....`), returning pos if none."""
+    for prefix in _URL_PREFIXES:
+        if code.startswith(prefix, pos):
+            break
+    else:
+        return pos
+
+    scan = pos + len(prefix)
+    while scan < end and code[scan] not in " \t\n<":
+        scan += 1
+    # Trailing punctuation and unbalanced closing parens aren't part of the URL
+    while scan > pos + len(prefix):
+        last = code[scan - 1]
+        if last in _URL_TRAILING:
+            scan -= 1
+        elif last == ")" and code.count("(", pos, scan) < code.count(")", pos, scan):
+            scan -= 1
+        else:
+            break
+    if scan == pos + len(prefix):
+        return pos
+    return scan
+
+
+def _scan_html_block(code: str, pos: int, end: int) -> int:
+    """Find the end of an HTML block starting at pos, returning pos if there is none.
+
+    Comments end at `-->`, raw elements (`<pre>`, `<script>`, ...) at their
+    closing tag, and all other blocks at the next blank line.
+    """
+    if pos + 1 >= end:
+        return pos
+
+    if code.startswith("<!--", pos):
+        terminator: str | None = "-->"
+    else:
+        name_start = pos + 2 if code[pos + 1] == "/" else pos + 1
+        name_end = name_start
+        while name_end < end and (code[name_end].isalnum() or code[name_end] == "-"):
+            name_end += 1
+        if name_end == name_start:
+            if code[pos + 1] not in "!?":
+                return pos
+            terminator = ">"
+        elif not code[name_start].isalpha() or (
+            name_end < end and code[name_end] not in " \t\n/>"
+        ):
+            return pos
+        else:
+            name = code[name_start:name_end].lower()
+            terminator = f"</{name}>" if name in _HTML_RAW_TAGS else None
+
+    if terminator is not None:
+        found = code.find(terminator, pos, end)
+        if found == -1:
+            return end
+        return _line_end(code, found + len(terminator), end)
+
+    # Ends before the first blank line
+    eol = _line_end(code, pos, end)
+    while eol < end:
+        next_eol = _line_end(code, eol + 1, end)
+        if not code[eol + 1 : next_eol].strip():
+            return eol
+        eol = next_eol
+    return end
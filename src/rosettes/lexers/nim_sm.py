@@ -9,6 +9,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     BINARY_DIGITS,
     DIGITS,
@@ -17,6 +18,7 @@ from rosettes.lexers._scanners import (
     HashCommentsMixin,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -232,7 +234,7 @@ class NimStateMachineLexer(
                 start = pos
                 while pos < length and code[pos] != "\n":
                     pos += 1
-                yield Token(TokenType.COMMENT_DOC, code[start:pos], line, col)
+                yield from split_doc_markup(code, start, pos, TokenType.COMMENT_DOC, line, col)
                 continue
 
             # Line comments
@@ -284,7 +286,7 @@ class NimStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Character literals
@@ -297,7 +299,7 @@ class NimStateMachineLexer(
                     pos += 1
                 if pos < length and code[pos] == "'":
                     pos += 1
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_CHAR, line, col)
                 continue
 
             # Numbers
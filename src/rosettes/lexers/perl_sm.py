@@ -13,7 +13,9 @@ from rosettes.lexers._scanners import (
     DIGITS,
     HEX_DIGITS,
     OCTAL_DIGITS,
+    SINGLE_QUOTE_ESCAPES,
     HashCommentsMixin,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -277,6 +279,9 @@ class PerlStateMachineLexer(
     filenames = ("*.pl", "*.pm", "*.t")
     mimetypes = ("text/x-perl", "application/x-perl")
 
+    # STRING_DOC is only POD
+    DOCSTRINGS = True
+
     def tokenize(
         self,
         code: str,
@@ -368,6 +373,7 @@ class PerlStateMachineLexer(
             # Strings
             if char in "'\"":
                 start = pos
+                start_line = line
                 quote = char
                 pos += 1
                 while pos < length and code[pos] != quote:
@@ -380,7 +386,10 @@ class PerlStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                escapes = SINGLE_QUOTE_ESCAPES if quote == "'" else None
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, start_line, col, escapes
+                )
                 continue
 
             # Backticks
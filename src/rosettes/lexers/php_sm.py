@@ -43,13 +43,16 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START,
+    SINGLE_QUOTE_ESCAPES,
     CStyleCommentsMixin,
     CStyleNumbersMixin,
     CStyleOperatorsMixin,
     NumberConfig,
     OperatorConfig,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -298,12 +301,14 @@ class PhpStateMachineLexer(
             # C-style comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -362,6 +367,7 @@ class PhpStateMachineLexer(
             # Strings
             if char == '"':
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != '"':
                     if code[pos] == "\\" and pos + 1 < length:
@@ -373,7 +379,7 @@ class PhpStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 continue
 
             if char == "'":
@@ -386,7 +392,9 @@ class PhpStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, line, col, SINGLE_QUOTE_ESCAPES
+                )
                 continue
 
             # Numbers
@@ -10,15 +10,27 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     DIGITS,
     CStyleCommentsMixin,
+    EscapeConfig,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["PklStateMachineLexer"]
 
+# `\(...)` is interpolation, so only the listed characters form escapes.
+_ESCAPES = EscapeConfig(
+    hex_digits=0,
+    unicode_short=0,
+    unicode_long=0,
+    octal_digits=0,
+    escapable=frozenset("\\tnr\"u"),
+)
+
 
 _KEYWORDS: frozenset[str] = frozenset(
     {
@@ -143,12 +155,14 @@ class PklStateMachineLexer(
             # Comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -166,7 +180,7 @@ class PklStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col, _ESCAPES)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -183,7 +197,7 @@ class PklStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col, _ESCAPES)
                 continue
 
             # Numbers
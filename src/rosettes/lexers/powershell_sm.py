@@ -12,11 +12,22 @@ from rosettes._types import Token, TokenType
 from rosettes.lexers._scanners import (
     DIGITS,
     HEX_DIGITS,
+    EscapeConfig,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["PowershellStateMachineLexer"]
 
+# Double-quoted strings use the backtick as their escape character (`n, `t, `u{...}).
+_ESCAPES = EscapeConfig(
+    escape_char="`",
+    hex_digits=0,
+    unicode_short=0,
+    unicode_long=0,
+    octal_digits=0,
+)
+
 
 _KEYWORDS: frozenset[str] = frozenset(
     {
@@ -247,6 +258,7 @@ class PowershellStateMachineLexer(StateMachineLexer):
             # Strings
             if char in "\"'":
                 start = pos
+                start_line = line
                 quote = char
                 pos += 1
                 while pos < length:
@@ -263,7 +275,12 @@ class PowershellStateMachineLexer(StateMachineLexer):
                         line += 1
                         line_start = pos + 1
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                if quote == '"':
+                    yield from split_escapes(
+                        code, start, pos, TokenType.STRING, start_line, col, _ESCAPES
+                    )
+                else:
+                    yield Token(TokenType.STRING, code[start:pos], start_line, col)
                 continue
 
             # Here-strings @"..."@ or @'...'@
@@ -14,6 +14,7 @@ from rosettes.lexers._scanners import (
     HEX_DIGITS,
     CStyleCommentsMixin,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -82,6 +83,9 @@ class ProtobufStateMachineLexer(
     filenames = ("*.proto",)
     mimetypes = ("text/x-protobuf",)
 
+    # Protobuf documents with ordinary comments above declarations
+    DOC_COMMENT_PREFIXES = ()
+
     def tokenize(
         self,
         code: str,
@@ -129,7 +133,7 @@ class ProtobufStateMachineLexer(
                 quote = char
                 pos += 1
                 pos, _ = scan_string(code, pos, quote)
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
@@ -82,9 +82,12 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
+from rosettes.lexers._scanners import PYTHON_ESCAPES
 from rosettes.lexers._state_machine import (
     StateMachineLexer,
     scan_triple_string,
+    split_escapes,
 )
 
 __all__ = ["PythonStateMachineLexer"]
@@ -312,6 +315,9 @@ _EXCEPTIONS: frozenset[str] = frozenset(
 # String prefix characters
 _STRING_PREFIXES: frozenset[str] = frozenset("fFrRbBuU")
 
+# Prefixes allowed on a docstring
+_DOCSTRING_PREFIXES: frozenset[str] = frozenset({"r", "R", "u", "U"})
+
 # Two-character operators
 _TWO_CHAR_OPS: frozenset[str] = frozenset(
     {
@@ -376,6 +382,9 @@ class PythonStateMachineLexer(StateMachineLexer):
     filenames = ("*.py", "*.pyw", "*.pyi")
     mimetypes = ("text/x-python", "application/x-python")
 
+    # STRING_DOC is only the first statement of a module, class or def body
+    DOCSTRINGS = True
+
     def tokenize(
         self,
         code: str,
@@ -392,6 +401,10 @@ class PythonStateMachineLexer(StateMachineLexer):
         length = end if end is not None else len(code)
         line = 1
         line_start = start
+        # A docstring is the first statement of a module, class or def body
+        expect_docstring = True
+        in_header = False
+        header_depth = 0
 
         while pos < length:
             char = code[pos]
@@ -421,6 +434,9 @@ class PythonStateMachineLexer(StateMachineLexer):
                 yield Token(TokenType.COMMENT_SINGLE, code[start:pos], line, col)
                 continue
 
+            at_body_start = expect_docstring
+            expect_docstring = False
+
             # -----------------------------------------------------------------
             # String literals (including prefixed)
             # -----------------------------------------------------------------
@@ -429,13 +445,23 @@ class PythonStateMachineLexer(StateMachineLexer):
                 start = pos
                 start_line = line
                 token_type, pos, newlines = self._scan_string_literal(code, pos)
+                docstring = token_type == TokenType.STRING_DOC and at_body_start
+                if token_type == TokenType.STRING_DOC and not docstring:
+                    token_type = TokenType.STRING
 
                 if newlines:
                     line += newlines
                     value = code[start:pos]
                     line_start = start + value.rfind("\n") + 1
 
-                yield Token(token_type, code[start:pos], start_line, col)
+                if docstring:
+                    yield from split_doc_markup(
+                        code, start, pos, token_type, start_line, col, PYTHON_ESCAPES
+                    )
+                else:
+                    yield from split_escapes(
+                        code, start, pos, token_type, start_line, col, PYTHON_ESCAPES
+                    )
                 continue
 
             if char in _STRING_PREFIXES:
@@ -449,13 +475,39 @@ class PythonStateMachineLexer(StateMachineLexer):
                     start = pos
                     start_line = line
                     token_type, pos, newlines = self._scan_string_literal(code, pos)
+                    prefix = code[start:lookahead]
+                    # Only r"""...""" and u"""...""" can be docstrings
+                    docstring = (
+                        token_type == TokenType.STRING_DOC
+                        and prefix in _DOCSTRING_PREFIXES
+                        and at_body_start
+                    )
+                    if token_type == TokenType.STRING_DOC and not docstring:
+                        token_type = TokenType.STRING
 
                     if newlines:
                         line += newlines
                         value = code[start:pos]
                         line_start = start + value.rfind("\n") + 1
 
-                    yield Token(token_type, code[start:pos], start_line, col)
+                    # Raw strings (r"", rb"", Rf"") have no escape sequences
+                    raw = "r" in prefix or "R" in prefix
+                    if docstring:
+                        yield from split_doc_markup(
+                            code,
+                            start,
+                            pos,
+                            token_type,
+                            start_line,
+                            col,
+                            None if raw else PYTHON_ESCAPES,
+                        )
+                    elif raw:
+                        yield Token(token_type, code[start:pos], start_line, col)
+                    else:
+                        yield from split_escapes(
+                            code, start, pos, token_type, start_line, col, PYTHON_ESCAPES
+                        )
                     continue
                 # Otherwise, fall through to identifier handling
 
@@ -489,6 +541,9 @@ class PythonStateMachineLexer(StateMachineLexer):
                     else:
                         break
                 word = code[start:pos]
+                if word in ("def", "class"):
+                    in_header = True
+                    header_depth = 0
                 token_type = self._classify_word(word)
                 yield Token(token_type, word, line, col)
                 continue
@@ -563,6 +618,14 @@ class PythonStateMachineLexer(StateMachineLexer):
             # Punctuation
             # -----------------------------------------------------------------
             if char in "()[]{}:;.,\\":
+                if in_header:
+                    if char in "([{":
+                        header_depth += 1
+                    elif char in ")]}":
+                        header_depth -= 1
+                    elif char == ":" and header_depth == 0:
+                        in_header = False
+                        expect_docstring = True
                 yield Token(TokenType.PUNCTUATION, char, line, col)
                 pos += 1
                 continue
@@ -580,7 +643,8 @@ class PythonStateMachineLexer(StateMachineLexer):
     ) -> tuple[TokenType, int, int]:
         """Scan a string literal with optional prefix.
 
-        Returns (token_type, end_position, newline_count).
+        Returns (token_type, end_position, newline_count). Triple-quoted
+        strings are STRING_DOC; the caller decides whether one is a docstring.
         """
         length = len(code)
 
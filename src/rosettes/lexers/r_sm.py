@@ -13,6 +13,7 @@ from rosettes.lexers._scanners import (
     DIGITS,
     HEX_DIGITS,
     HashCommentsMixin,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -184,6 +185,7 @@ class RStateMachineLexer(
             # Strings
             if char in "\"'":
                 start = pos
+                start_line = line
                 quote = char
                 pos += 1
                 while pos < length and code[pos] != quote:
@@ -196,7 +198,7 @@ class RStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 continue
 
             # Raw strings r"..." or R"(...)"
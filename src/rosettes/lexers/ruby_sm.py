@@ -48,8 +48,10 @@ from rosettes.lexers._scanners import (
     DIGITS,
     HEX_DIGITS,
     OCTAL_DIGITS,
+    SINGLE_QUOTE_ESCAPES,
     HashCommentsMixin,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -232,7 +234,7 @@ class RubyStateMachineLexer(
                     start = pos
                     pos += 2
                     pos, _ = scan_string(code, pos, '"')
-                    yield Token(TokenType.STRING_SYMBOL, code[start:pos], line, col)
+                    yield from split_escapes(code, start, pos, TokenType.STRING_SYMBOL, line, col)
                     continue
                 yield Token(TokenType.PUNCTUATION, ":", line, col)
                 pos += 1
@@ -287,6 +289,7 @@ class RubyStateMachineLexer(
             # Strings
             if char == '"':
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != '"':
                     if code[pos] == "\\" and pos + 1 < length:
@@ -298,11 +301,12 @@ class RubyStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 continue
 
             if char == "'":
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != "'":
                     if code[pos] == "\\" and pos + 1 < length and code[pos + 1] in "\\'":
@@ -314,7 +318,9 @@ class RubyStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, start_line, col, SINGLE_QUOTE_ESCAPES
+                )
                 continue
 
             # Backtick commands
@@ -49,17 +49,20 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     BINARY_DIGITS,
     DIGITS,
     HEX_DIGITS,
     IDENT_START,
     OCTAL_DIGITS,
+    RUST_ESCAPES,
     CStyleCommentsMixin,
     CStyleOperatorsMixin,
     OperatorConfig,
     scan_identifier,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -253,12 +256,14 @@ class RustStateMachineLexer(
             # Comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -321,7 +326,7 @@ class RustStateMachineLexer(
                 pos += 2
                 pos, _ = scan_string(code, pos, quote)
                 token_type = TokenType.STRING if quote == '"' else TokenType.STRING_CHAR
-                yield Token(token_type, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, token_type, line, col, RUST_ESCAPES)
                 continue
 
             # Regular strings
@@ -329,7 +334,9 @@ class RustStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, line, col, RUST_ESCAPES
+                )
                 continue
 
             # Character literals
@@ -337,7 +344,9 @@ class RustStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING_CHAR, line, col, RUST_ESCAPES
+                )
                 continue
 
             # Numbers
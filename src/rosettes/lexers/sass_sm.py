@@ -0,0 +1,63 @@
+"""Hand-written Sass (indented syntax) lexer using state machine approach.
+
+O(n) guaranteed, zero regex, thread-safe.
+
+**Language Support:**
+
+- The SCSS feature set without braces or semicolons
+- Nesting by indentation; newlines end declarations
+- Mixin shorthands (`=name` defines, `+name` includes)
+
+**Token Classification:**
+
+Same as the SCSS lexer. A `name:value` line with indented children is a
+selector with a pseudo-class; without children it is a declaration.
+
+**Thread-Safety:**
+
+Uses only local variables in `tokenize()`.
+
+**See Also:**
+
+- `rosettes.lexers.scss_sm`: SCSS lexer
+- `rosettes.lexers._stylesheet`: Shared stylesheet scanner
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+
+from rosettes._config import LexerConfig
+from rosettes._types import Token
+from rosettes.lexers._state_machine import StateMachineLexer
+from rosettes.lexers._stylesheet import SASS, scan_stylesheet
+
+__all__ = ["SassStateMachineLexer"]
+
+
+class SassStateMachineLexer(StateMachineLexer):
+    """Sass lexer for the indented syntax.
+
+    Example:
+        >>> from rosettes import get_lexer
+        >>> lexer = get_lexer("sass")
+        >>> tokens = list(lexer.tokenize("=button\\n  color: red"))
+        >>> tokens[1].type
+        <TokenType.NAME_FUNCTION: 'nf'>
+    """
+
+    name = "sass"
+    aliases = ()
+    filenames = ("*.sass",)
+    mimetypes = ("text/x-sass",)
+
+    def tokenize(
+        self,
+        code: str,
+        config: LexerConfig | None = None,
+        *,
+        start: int = 0,
+        end: int | None = None,
+    ) -> Iterator[Token]:
+        tab_size = (config or LexerConfig()).tab_size
+        return scan_stylesheet(code, start, end, SASS, tab_size=tab_size)
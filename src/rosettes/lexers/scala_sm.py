@@ -9,6 +9,7 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START,
     CStyleCommentsMixin,
@@ -16,10 +17,12 @@ from rosettes.lexers._scanners import (
     CStyleOperatorsMixin,
     NumberConfig,
     OperatorConfig,
+    is_doc_comment,
     scan_block_comment,
     scan_identifier,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -126,6 +129,9 @@ class ScalaStateMachineLexer(
     filenames = ("*.scala", "*.sc")
     mimetypes = ("text/x-scala",)
 
+    # Scaladoc
+    DOC_COMMENT_PREFIXES = ("/**",)
+
     NUMBER_CONFIG = NumberConfig(
         integer_suffixes=("L", "l"),
         float_suffixes=("f", "F", "d", "D"),
@@ -202,12 +208,15 @@ class ScalaStateMachineLexer(
                     continue
                 if next_char == "*":
                     start = pos
-                    is_doc = pos + 2 < length and code[pos + 2] == "*"
                     pos = scan_block_comment(code, pos + 2, "*/")
                     value = code[start:pos]
                     newlines = value.count("\n")
-                    token_type = TokenType.STRING_DOC if is_doc else TokenType.COMMENT_MULTILINE
-                    yield Token(token_type, value, line, col)
+                    if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                        yield from split_doc_markup(
+                            code, start, pos, TokenType.COMMENT_DOC, line, col
+                        )
+                    else:
+                        yield Token(TokenType.COMMENT_MULTILINE, value, line, col)
                     if newlines:
                         line += newlines
                         line_start = start + value.rfind("\n") + 1
@@ -238,7 +247,7 @@ class ScalaStateMachineLexer(
                 start = pos
                 pos += 2
                 pos, newlines = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -248,7 +257,7 @@ class ScalaStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Character literals
@@ -256,7 +265,7 @@ class ScalaStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING_CHAR, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING_CHAR, line, col)
                 continue
 
             # Symbols (deprecated but still valid)
@@ -0,0 +1,73 @@
+"""Hand-written SCSS lexer using state machine approach.
+
+O(n) guaranteed, zero regex, thread-safe.
+
+**Language Support:**
+
+- Everything in the CSS lexer
+- Nested rules and the parent selector `&`
+- Variables (`$name`) and `!default`/`!global` flags
+- Mixins and functions (`@mixin`, `@include`, `@function`, `@return`)
+- Control flow (`@if`, `@else if`, `@each ... in`, `@for ... through`)
+- Interpolation (`#{$name}`), including inside strings
+- Placeholder selectors (`%name`) and `@extend`
+- Maps (`(key: value)`) and module members (`math.div()`, `map.$var`)
+- Line comments (`//`)
+
+**Token Classification:**
+
+- Variables: `$gap` → NAME_VARIABLE
+- Mixin and function names: `@include button` → NAME_FUNCTION
+- Interpolation: `#{` and `}` → STRING_INTERPOL
+- Parent selector: `&` → NAME_BUILTIN_PSEUDO
+- Module namespaces: `math` in `math.div()` → NAME_NAMESPACE
+
+**Thread-Safety:**
+
+Uses only local variables in `tokenize()`.
+
+**See Also:**
+
+- `rosettes.lexers.css_sm`: CSS lexer
+- `rosettes.lexers.sass_sm`: Sass lexer (indented syntax)
+- `rosettes.lexers._stylesheet`: Shared stylesheet scanner
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+
+from rosettes._config import LexerConfig
+from rosettes._types import Token
+from rosettes.lexers._state_machine import StateMachineLexer
+from rosettes.lexers._stylesheet import SCSS, scan_stylesheet
+
+__all__ = ["ScssStateMachineLexer"]
+
+
+class ScssStateMachineLexer(StateMachineLexer):
+    """SCSS lexer with variables, mixins, control flow, and interpolation.
+
+    Example:
+        >>> from rosettes import get_lexer
+        >>> lexer = get_lexer("scss")
+        >>> tokens = list(lexer.tokenize("$gap: 4px;"))
+        >>> tokens[0].type
+        <TokenType.NAME_VARIABLE: 'nv'>
+    """
+
+    name = "scss"
+    aliases = ()
+    filenames = ("*.scss",)
+    mimetypes = ("text/x-scss",)
+
+    def tokenize(
+        self,
+        code: str,
+        config: LexerConfig | None = None,
+        *,
+        start: int = 0,
+        end: int | None = None,
+    ) -> Iterator[Token]:
+        tab_size = (config or LexerConfig()).tab_size
+        return scan_stylesheet(code, start, end, SCSS, tab_size=tab_size)
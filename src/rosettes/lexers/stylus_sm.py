@@ -0,0 +1,70 @@
+"""Hand-written Stylus lexer using state machine approach.
+
+O(n) guaranteed, zero regex, thread-safe.
+
+**Language Support:**
+
+- Everything in the CSS lexer, with optional braces, colons, and semicolons
+- Nesting by indentation
+- Variables (`name = value`, `name ?= value`, `$name`)
+- Mixins and functions (`name(args)`), transparent mixin calls
+- Keywords (`if`, `unless`, `for ... in`, `return`) and ranges (`1..5`)
+- Line comments (`//`)
+
+**Token Classification:**
+
+- Properties: `color` in `color red` → NAME_ATTRIBUTE
+- Assigned variables: `gap` in `gap = 4px` → NAME_VARIABLE
+- Functions: `border-radius(` → NAME_FUNCTION
+
+A colon-less `name value` line is a declaration when it is indented and
+has no more-indented children.
+
+**Thread-Safety:**
+
+Uses only local variables in `tokenize()`.
+
+**See Also:**
+
+- `rosettes.lexers.css_sm`: CSS lexer
+- `rosettes.lexers._stylesheet`: Shared stylesheet scanner
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+
+from rosettes._config import LexerConfig
+from rosettes._types import Token
+from rosettes.lexers._state_machine import StateMachineLexer
+from rosettes.lexers._stylesheet import STYLUS, scan_stylesheet
+
+__all__ = ["StylusStateMachineLexer"]
+
+
+class StylusStateMachineLexer(StateMachineLexer):
+    """Stylus lexer with optional punctuation and indentation-based nesting.
+
+    Example:
+        >>> from rosettes import get_lexer
+        >>> lexer = get_lexer("stylus")
+        >>> tokens = list(lexer.tokenize("gap = 4px"))
+        >>> tokens[0].type
+        <TokenType.NAME_VARIABLE: 'nv'>
+    """
+
+    name = "stylus"
+    aliases = ("styl",)
+    filenames = ("*.styl",)
+    mimetypes = ("text/x-styl",)
+
+    def tokenize(
+        self,
+        code: str,
+        config: LexerConfig | None = None,
+        *,
+        start: int = 0,
+        end: int | None = None,
+    ) -> Iterator[Token]:
+        tab_size = (config or LexerConfig()).tab_size
+        return scan_stylesheet(code, start, end, STYLUS, tab_size=tab_size)
@@ -9,23 +9,36 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START,
     CStyleCommentsMixin,
     CStyleNumbersMixin,
     CStyleOperatorsMixin,
+    EscapeConfig,
     NumberConfig,
     OperatorConfig,
+    is_doc_comment,
     scan_block_comment,
     scan_identifier,
     scan_line_comment,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
 __all__ = ["SwiftStateMachineLexer"]
 
+# `\(...)` is interpolation, so only the listed characters form escapes.
+_ESCAPES = EscapeConfig(
+    hex_digits=0,
+    unicode_short=0,
+    unicode_long=0,
+    octal_digits=0,
+    escapable=frozenset("0\\tnr\"'u"),
+)
+
 
 _KEYWORDS: frozenset[str] = frozenset(
     {
@@ -134,6 +147,9 @@ class SwiftStateMachineLexer(
     filenames = ("*.swift",)
     mimetypes = ("text/x-swift",)
 
+    # Swift markup comments
+    DOC_COMMENT_PREFIXES = ("/**", "///")
+
     NUMBER_CONFIG = NumberConfig()
 
     OPERATOR_CONFIG = OperatorConfig(
@@ -203,16 +219,24 @@ class SwiftStateMachineLexer(
                     start = pos
                     while pos < length and code[pos] != "\n":
                         pos += 1
-                    yield Token(TokenType.COMMENT_SINGLE, code[start:pos], line, col)
+                    if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                        yield from split_doc_markup(
+                            code, start, pos, TokenType.COMMENT_DOC, line, col
+                        )
+                    else:
+                        yield Token(TokenType.COMMENT_SINGLE, code[start:pos], line, col)
                     continue
                 if next_char == "*":
                     start = pos
-                    is_doc = pos + 2 < length and code[pos + 2] == "*"
                     pos = scan_block_comment(code, pos + 2, "*/")
                     value = code[start:pos]
                     newlines = value.count("\n")
-                    token_type = TokenType.STRING_DOC if is_doc else TokenType.COMMENT_MULTILINE
-                    yield Token(token_type, value, line, col)
+                    if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                        yield from split_doc_markup(
+                            code, start, pos, TokenType.COMMENT_DOC, line, col
+                        )
+                    else:
+                        yield Token(TokenType.COMMENT_MULTILINE, value, line, col)
                     if newlines:
                         line += newlines
                         line_start = start + value.rfind("\n") + 1
@@ -239,7 +263,9 @@ class SwiftStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING_DOC, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING_DOC, line, col, _ESCAPES
+                )
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -250,7 +276,7 @@ class SwiftStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col, _ESCAPES)
                 continue
 
             # Numbers
@@ -10,14 +10,17 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     BINARY_DIGITS,
     DIGITS,
     HEX_DIGITS,
     OCTAL_DIGITS,
+    PYTHON_ESCAPES,
     HashCommentsMixin,
     scan_string,
     scan_triple_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -233,7 +236,15 @@ class TritonStateMachineLexer(
                 start = pos
                 pos += 3
                 pos, newlines = scan_triple_string(code, pos, '"')
-                yield Token(TokenType.STRING_DOC, code[start:pos], line, col)
+                if start == line_start or code[line_start:start].isspace():
+                    # Docstring position: split out its markup
+                    yield from split_doc_markup(
+                        code, start, pos, TokenType.STRING_DOC, line, col, PYTHON_ESCAPES
+                    )
+                else:
+                    yield from split_escapes(
+                        code, start, pos, TokenType.STRING_DOC, line, col, PYTHON_ESCAPES
+                    )
                 if newlines:
                     line += newlines
                     line_start = start + code[start:pos].rfind("\n") + 1
@@ -244,14 +255,18 @@ class TritonStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, line, col, PYTHON_ESCAPES
+                )
                 continue
 
             if char == "'":
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, "'")
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, line, col, PYTHON_ESCAPES
+                )
                 continue
 
             # Numbers
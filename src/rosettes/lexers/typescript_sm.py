@@ -41,8 +41,10 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     IDENT_START_DOLLAR,
+    JS_ESCAPES,
     CStyleCommentsMixin,
     CStyleNumbersMixin,
     CStyleOperatorsMixin,
@@ -50,6 +52,7 @@ from rosettes.lexers._scanners import (
     OperatorConfig,
     scan_identifier,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -287,12 +290,14 @@ class TypeScriptStateMachineLexer(
             # Comments
             token, new_pos = self._try_comment(code, pos, line, col)
             if token:
-                if token.type == TokenType.COMMENT_MULTILINE:
-                    newlines = token.value.count("\n")
-                    if newlines:
-                        line += newlines
-                        line_start = pos + token.value.rfind("\n") + 1
-                yield token
+                if token.type == TokenType.COMMENT_DOC:
+                    yield from split_doc_markup(code, pos, new_pos, token.type, line, col)
+                else:
+                    yield token
+                newlines = token.value.count("\n")
+                if newlines:
+                    line += newlines
+                    line_start = pos + token.value.rfind("\n") + 1
                 pos = new_pos
                 continue
 
@@ -320,7 +325,9 @@ class TypeScriptStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], start_line, col)
+                yield from split_escapes(
+                    code, start, pos, TokenType.STRING, start_line, col, JS_ESCAPES
+                )
                 continue
 
             # Strings
@@ -329,7 +336,7 @@ class TypeScriptStateMachineLexer(
                 quote = char
                 pos += 1
                 pos, _ = scan_string(code, pos, quote)
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col, JS_ESCAPES)
                 continue
 
             # Numbers
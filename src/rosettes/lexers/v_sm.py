@@ -17,6 +17,7 @@ from rosettes.lexers._scanners import (
     OCTAL_DIGITS,
     CStyleCommentsMixin,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -131,6 +132,9 @@ class VStateMachineLexer(
     filenames = ("*.v", "*.vv")
     mimetypes = ("text/x-v",)
 
+    # V doc comments are ordinary // comments above declarations
+    DOC_COMMENT_PREFIXES = ()
+
     def tokenize(
         self,
         code: str,
@@ -220,13 +224,14 @@ class VStateMachineLexer(
                 quote = code[pos + 1]
                 pos += 2
                 pos, _ = scan_string(code, pos, quote)
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Strings with interpolation
             if char == '"' or char == "'":
                 quote = char
                 start = pos
+                start_line = line
                 pos += 1
                 while pos < length and code[pos] != quote:
                     if code[pos] == "\\" and pos + 1 < length:
@@ -238,7 +243,7 @@ class VStateMachineLexer(
                     pos += 1
                 if pos < length:
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, start_line, col)
                 continue
 
             # Backtick strings (raw)
@@ -48,6 +48,7 @@ from rosettes.lexers._scanners import (
     CStyleNumbersMixin,
     HashCommentsMixin,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -231,7 +232,7 @@ class YamlStateMachineLexer(
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 at_line_start = False
                 continue
 
@@ -9,12 +9,15 @@ from collections.abc import Iterator
 
 from rosettes._config import LexerConfig
 from rosettes._types import Token, TokenType
+from rosettes.lexers._doc_markup import split_doc_markup
 from rosettes.lexers._scanners import (
     BINARY_DIGITS,
     DIGITS,
     HEX_DIGITS,
     OCTAL_DIGITS,
+    is_doc_comment,
     scan_string,
+    split_escapes,
 )
 from rosettes.lexers._state_machine import StateMachineLexer
 
@@ -216,6 +219,9 @@ class ZigStateMachineLexer(StateMachineLexer):
     filenames = ("*.zig",)
     mimetypes = ("text/x-zig",)
 
+    # Doc (///) and top-level doc (//!) comments
+    DOC_COMMENT_PREFIXES = ("///", "//!")
+
     def tokenize(
         self,
         code: str,
@@ -245,12 +251,15 @@ class ZigStateMachineLexer(StateMachineLexer):
                 yield Token(TokenType.WHITESPACE, code[start:pos], start_line, col)
                 continue
 
-            # Comments //
+            # Comments // (doc comments /// and //!)
             if char == "/" and pos + 1 < length and code[pos + 1] == "/":
                 start = pos
                 while pos < length and code[pos] != "\n":
                     pos += 1
-                yield Token(TokenType.COMMENT_SINGLE, code[start:pos], line, col)
+                if is_doc_comment(code, start, self.DOC_COMMENT_PREFIXES):
+                    yield from split_doc_markup(code, start, pos, TokenType.COMMENT_DOC, line, col)
+                else:
+                    yield Token(TokenType.COMMENT_SINGLE, code[start:pos], line, col)
                 continue
 
             # Builtins @name
@@ -271,7 +280,7 @@ class ZigStateMachineLexer(StateMachineLexer):
                 start = pos
                 pos += 1
                 pos, _ = scan_string(code, pos, '"')
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Character literals
@@ -292,7 +301,7 @@ class ZigStateMachineLexer(StateMachineLexer):
                 start = pos
                 while pos < length and code[pos] != "\n":
                     pos += 1
-                yield Token(TokenType.STRING, code[start:pos], line, col)
+                yield from split_escapes(code, start, pos, TokenType.STRING, line, col)
                 continue
 
             # Numbers
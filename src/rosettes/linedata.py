@@ -0,0 +1,182 @@
+"""Per-line data overlays: coverage, heatmaps and gutter columns.
+
+Attach metadata to lines for coverage reports, profiler output or blame
+views:
+
+```python
+>>> from rosettes import highlight
+>>> from rosettes.linedata import LineData, load_coverage_json
+>>> data = {
+...     3: LineData(intensity=0.9, columns=("412", "18.2ms")),
+...     4: LineData(intensity=0.1, columns=("3", "0.1ms")),
+... }
+>>> html = highlight(code, "python", line_data=data, show_linenos=True)
+>>> html = highlight(code, "python", line_data=load_coverage_json("coverage.json", "app.py"))
+```
+
+**Rendering:**
+
+- `status` (`covered`, `missed`, `partial`) adds the `line-covered`,
+  `line-missed` or `line-partial` line class in HTML and a green, red
+  or yellow background in the terminal.
+- `intensity` (0.0 to 1.0) adds the `line-heat` class with a
+  `--line-heat` custom property in HTML, and a background from a
+  five-step red ramp in the terminal (where it replaces a status
+  background).
+- `columns` are shown as gutter cells before the line number, right
+  aligned to the widest cell of each column (`<span class="line-gutter">`
+  in HTML, gray in the terminal). Lines without data get blank cells.
+
+Stylesheets from `SyntaxPalette.generate_css()` color these classes with
+the palette's `added`, `removed`, `warning` and `error` colors.
+
+**Line Numbers:**
+
+Keys are 1-based line numbers of the code passed to `highlight()`,
+like `hl_lines`; with `lines=`, `dedent=` or `trim=` they still refer to
+the original lines.
+
+**Thread-Safety:**
+
+LineData is a frozen dataclass; the adapters use only local state.
+
+**See Also:**
+
+- `rosettes.highlight`: `line_data=` argument
+- `rosettes._config.HighlightConfig`: `line_data` for formatters
+"""
+
+from __future__ import annotations
+
+import json
+import os
+from collections.abc import Mapping
+from dataclasses import dataclass
+from typing import Any
+
+__all__ = [
+    "LINE_STATUSES",
+    "LineData",
+    "coverage_line_data",
+    "gutter_cells",
+    "load_coverage_json",
+]
+
+LINE_STATUSES = frozenset({"covered", "missed", "partial"})
+
+
+@dataclass(frozen=True, slots=True)
+class LineData:
+    """Metadata for one line.
+
+    Attributes:
+        status: Coverage status: "covered", "missed" or "partial".
+        intensity: Heat from 0.0 (cold) to 1.0 (hot), e.g. share of time
+            spent on the line.
+        columns: Text for extra gutter columns, such as hit counts,
+            timings or commit ids.
+
+    Raises:
+        ValueError: If status is unknown or intensity is out of range.
+    """
+
+    status: str | None = None
+    intensity: float | None = None
+    columns: tuple[str, ...] = ()
+
+    def __post_init__(self) -> None:
+        if self.status is not None and self.status not in LINE_STATUSES:
+            raise ValueError(
+                f"Unknown line status {self.status!r}; expected one of {sorted(LINE_STATUSES)}"
+            )
+        if self.intensity is not None and not 0.0 <= self.intensity <= 1.0:
+            raise ValueError(f"intensity must be between 0.0 and 1.0, got {self.intensity}")
+
+
+def gutter_cells(
+    line_data: Mapping[int, LineData],
+) -> tuple[dict[int, tuple[str, ...]], tuple[str, ...]]:
+    """Pad each line's columns to the widest cell of its column.
+
+    Returns:
+        (cells, blank): padded cells per line with columns, and blank
+        cells for the other lines. Both are empty without columns.
+    """
+    count = max((len(data.columns) for data in line_data.values()), default=0)
+    if not count:
+        return {}, ()
+    widths = [0] * count
+    for data in line_data.values():
+        for index, cell in enumerate(data.columns):
+            widths[index] = max(widths[index], len(cell))
+    cells: dict[int, tuple[str, ...]] = {}
+    for line, data in line_data.items():
+        if data.columns:
+            padded = (*data.columns, *[""] * (count - len(data.columns)))
+            cells[line] = tuple(cell.rjust(width) for cell, width in zip(padded, widths))
+    return cells, tuple(" " * width for width in widths)
+
+
+def _find_file(files: Mapping[str, Any], filename: str) -> Mapping[str, Any]:
+    if filename in files:
+        return files[filename]
+    wanted = os.path.normpath(filename).replace("\\", "/")
+    candidates = [
+        key
+        for key in files
+        if (normalized := os.path.normpath(key).replace("\\", "/")) == wanted
+        or normalized.endswith("/" + wanted)
+        or wanted.endswith("/" + normalized)
+    ]
+    if len(candidates) != 1:
+        problem = "is ambiguous" if candidates else "was not found"
+        raise LookupError(f"{filename!r} {problem} in the coverage report")
+    return files[candidates[0]]
+
+
+def coverage_line_data(report: Mapping[str, Any], filename: str) -> dict[int, LineData]:
+    """Convert one file of a coverage.py JSON report to line data.
+
+    Executed lines are "covered", missing lines "missed", and executed
+    lines with a missing branch (branch coverage) "partial". Excluded
+    lines get no status.
+
+    Args:
+        report: Parsed output of `coverage json`.
+        filename: File to extract, as recorded in the report or a path
+            suffix of it (`app.py` matches `src/app.py`).
+
+    Returns:
+        Line data keyed by line number.
+
+    Raises:
+        LookupError: If the file isn't in the report, or matches several.
+    """
+    entry = _find_file(report.get("files", {}), filename)
+    partial = {start for start, _ in entry.get("missing_branches", ())}
+    data: dict[int, LineData] = {}
+    for line in entry.get("executed_lines", ()):
+        data[line] = LineData(status="partial" if line in partial else "covered")
+    for line in entry.get("missing_lines", ()):
+        data[line] = LineData(status="missed")
+    return data
+
+
+def load_coverage_json(path: str | os.PathLike[str], filename: str) -> dict[int, LineData]:
+    """Read a coverage.py JSON report and return one file's line data.
+
+    Args:
+        path: Report written by `coverage json` (usually `coverage.json`).
+        filename: File to extract; see coverage_line_data().
+
+    Returns:
+        Line data keyed by line number.
+
+    Raises:
+        OSError: If the report can't be read.
+        ValueError: If the report isn't valid JSON.
+        LookupError: If the file isn't in the report.
+    """
+    with open(path, encoding="utf-8") as f:
+        report = json.load(f)
+    return coverage_line_data(report, filename)
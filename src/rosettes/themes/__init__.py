@@ -164,14 +164,18 @@ def register_palette(palette: Palette) -> None:
     Args:
         palette: The palette to register.
     """
+    # Lazy init, so built-ins aren't skipped when a plugin registers first
+    if not _PALETTES:
+        _init_registry()
     _PALETTES[palette.name] = palette
 
 
-def get_palette(name: str) -> Palette:
+def get_palette(name: str | None = None) -> Palette:
     """Get a registered palette by name.
 
     Args:
-        name: The palette name.
+        name: The palette name. Defaults to the configured palette
+            (see `rosettes.config`), "bengal-tiger" unless configured.
 
     Returns:
         The requested palette.
@@ -183,6 +187,10 @@ def get_palette(name: str) -> Palette:
     if not _PALETTES:
         _init_registry()
 
+    if name is None:
+        from rosettes.config import get_config
+
+        name = get_config().palette
     if name not in _PALETTES:
         available = ", ".join(sorted(_PALETTES.keys()))
         raise LookupError(f"Unknown syntax theme: {name!r}. Available: {available}")
@@ -19,6 +19,15 @@ This keeps themes manageable (~20 colors) while supporting 100+ token types.
   - Contains two SyntaxPalette instances
   - Generates `@media (prefers-color-scheme)` CSS
 
+**Print Styles:**
+
+`generate_css(print=True)` appends an `@media print` section built from
+`for_print()`: a light palette derived from any palette by darkening each
+color until it reaches 4.5:1 contrast on white (7:1 and black text with
+`print="high-contrast"`). The section drops backgrounds, turns
+highlighted and classed lines into a colored left border, underlines
+search marks, wraps long lines and prints every tab of a code group.
+
 **Creating Palettes:**
 
 Minimal (only required fields):
@@ -69,16 +78,105 @@ modified. Safe to share across threads.
 
 from __future__ import annotations
 
-from dataclasses import dataclass
+import colorsys
+from dataclasses import dataclass, replace
 from typing import TYPE_CHECKING
 
 if TYPE_CHECKING:
     from typing import Literal
 
     CssClassStyle = Literal["semantic", "pygments"]
+    PrintMode = Literal["light", "high-contrast"]
+
+    from rosettes.themes._roles import SyntaxRole
 
 __all__ = ["SyntaxPalette", "AdaptivePalette"]
 
+# Minimum contrast on white for print palettes (WCAG AA and AAA)
+_PRINT_CONTRAST = 4.5
+_PRINT_HIGH_CONTRAST = 7.0
+
+# Fields of SyntaxPalette that hold colors
+_COLOR_FIELDS = (
+    "background_highlight",
+    "control_flow",
+    "declaration",
+    "import_",
+    "string",
+    "number",
+    "boolean",
+    "type_",
+    "function",
+    "variable",
+    "constant",
+    "comment",
+    "docstring",
+    "error",
+    "warning",
+    "added",
+    "removed",
+    "muted",
+    "punctuation",
+    "operator",
+    "attribute",
+    "namespace",
+    "tag",
+    "regex",
+    "escape",
+)
+
+
+def _parse_hex(color: str) -> tuple[int, int, int] | None:
+    """Parse `#rgb`, `#rrggbb` or `#rrggbbaa` (alpha ignored)."""
+    digits = color.strip().removeprefix("#")
+    if not color.strip().startswith("#") or len(digits) not in (3, 4, 6, 8):
+        return None
+    if len(digits) in (3, 4):
+        digits = "".join(char * 2 for char in digits[:3])
+    try:
+        return int(digits[0:2], 16), int(digits[2:4], 16), int(digits[4:6], 16)
+    except ValueError:
+        return None
+
+
+def _luminance(rgb: tuple[int, int, int]) -> float:
+    """WCAG relative luminance."""
+    channels = []
+    for value in rgb:
+        c = value / 255
+        channels.append(c / 12.92 if c <= 0.04045 else ((c + 0.055) / 1.055) ** 2.4)
+    return 0.2126 * channels[0] + 0.7152 * channels[1] + 0.0722 * channels[2]
+
+
+def _contrast_ratio(foreground: tuple[int, int, int], background: tuple[int, int, int]) -> float:
+    """WCAG contrast ratio, from 1.0 to 21.0."""
+    lighter, darker = sorted((_luminance(foreground), _luminance(background)), reverse=True)
+    return (lighter + 0.05) / (darker + 0.05)
+
+
+def _print_color(color: str, contrast: float, fallback: str, *, invert: bool) -> str:
+    """Adapt a color for white paper.
+
+    With invert (colors from a dark palette), lightness is inverted first,
+    so near-white text becomes near-black. Lightness is then lowered,
+    keeping hue and saturation, until the color has `contrast` against
+    white. Colors that aren't hex (names, `rgb()`, variables) are
+    replaced by `fallback`.
+    """
+    rgb = _parse_hex(color)
+    if rgb is None:
+        return fallback
+    hue, lightness, saturation = colorsys.rgb_to_hls(*(value / 255 for value in rgb))
+    if invert:
+        lightness = 1 - lightness
+    while True:
+        scaled = tuple(
+            round(value * 255) for value in colorsys.hls_to_rgb(hue, lightness, saturation)
+        )
+        if lightness <= 0 or _contrast_ratio(scaled, (255, 255, 255)) >= contrast:
+            return "#{:02x}{:02x}{:02x}".format(*scaled)
+        lightness = max(lightness - 0.01, 0)
+
 
 @dataclass(frozen=True, slots=True)
 class SyntaxPalette:
@@ -242,7 +340,165 @@ class SyntaxPalette:
         ]
         return "\n".join(lines)
 
-    def generate_css(self, *, class_style: CssClassStyle = "semantic") -> str:
+    def for_print(self, *, high_contrast: bool = False) -> SyntaxPalette:
+        """Derive a light palette for printing.
+
+        The background becomes white. Colors of a dark palette have their
+        lightness inverted (light text becomes dark), then every color is
+        darkened, keeping its hue, until it has at least 4.5:1 contrast on
+        white, or 7:1 with high_contrast, which also makes the text black.
+        A light palette's colors that already have enough contrast are
+        kept; non-hex colors become the text color.
+
+        Args:
+            high_contrast: Use 7:1 contrast (WCAG AAA) and black text.
+
+        Returns:
+            A new palette named `{name}-print` (or `{name}-print-high-contrast`).
+
+        Example:
+            >>> get_palette("monokai").for_print().background
+            '#ffffff'
+        """
+        filled = self.with_defaults()
+        contrast = _PRINT_HIGH_CONTRAST if high_contrast else _PRINT_CONTRAST
+        background = _parse_hex(filled.background)
+        invert = background is not None and _luminance(background) < 0.5
+        text = "#000000"
+        if not high_contrast:
+            text = _print_color(filled.text, contrast, text, invert=invert)
+        colors = {
+            name: _print_color(getattr(filled, name), contrast, text, invert=invert)
+            for name in _COLOR_FIELDS
+        }
+        colors["background_highlight"] = "#ffffff"
+        suffix = "-print-high-contrast" if high_contrast else "-print"
+        return replace(
+            filled, name=f"{self.name}{suffix}", background="#ffffff", text=text, **colors
+        )
+
+    def _print_css(self, class_style: CssClassStyle, mode: PrintMode) -> list[str]:
+        """Build the `@media print` section for this palette."""
+        printed = self.for_print(high_contrast=mode == "high-contrast")
+        line_borders = {
+            ("hll",): printed.text,
+            ("line-added", "line-covered"): printed.added,
+            ("line-removed", "line-missed"): printed.removed,
+            ("line-warning", "line-partial"): printed.warning,
+            ("line-error",): printed.error,
+            ("line-heat",): (
+                f"color-mix(in srgb, {printed.error} calc(var(--line-heat, 0) * 100%), #ffffff)"
+            ),
+        }
+        rules: dict[str, list[str]] = {
+            ".rosettes, .highlight": ["background: none", f"color: {printed.text}"],
+            ".rosettes pre, .highlight pre": [
+                "background: none",
+                "overflow: visible",
+                "white-space: pre-wrap",
+                "overflow-wrap: anywhere",
+            ],
+            ".rosettes mark, .highlight mark": [
+                "background: none",
+                "color: inherit",
+                "text-decoration: underline 2px",
+            ],
+            ".rosettes .lineno, .highlight .lineno, .line-gutter": [f"color: {printed.muted}"],
+            ".rosettes-group": ["display: block"],
+            ".rosettes-group > input": ["display: none"],
+            ".rosettes-group > label": ["display: block", "border: none", "font-weight: bold"],
+            ".rosettes-group > .rosettes-panel": ["display: block"],
+        }
+        for class_names, color in line_borders.items():
+            selector = ", ".join(
+                f"{container} .{name}"
+                for name in class_names
+                for container in (".rosettes", ".highlight")
+            )
+            # The border sits in the gutter so code doesn't shift
+            rules[selector] = [
+                "background: none",
+                f"border-left: 3px solid {color}",
+                "margin-left: -3px",
+            ]
+
+        css_parts = ["@media print {", "  :root {", printed.to_css_vars(indent=4), "  }"]
+        for selector, props in rules.items():
+            css_parts.append(f"  {selector} {{")
+            for prop in props:
+                css_parts.append(f"    {prop};")
+            css_parts.append("  }")
+        for line in printed._role_css(class_style):
+            css_parts.append(f"  {line}")
+        css_parts.append("}")
+        return css_parts
+
+    def _role_styles(self) -> dict[SyntaxRole, tuple[str, bool, bool]]:
+        """Map each role to its (color, bold, italic), defaults filled in."""
+        from rosettes.themes._roles import SyntaxRole
+
+        filled = self.with_defaults()
+        return {
+            SyntaxRole.CONTROL_FLOW: (filled.control_flow, filled.bold_control, False),
+            SyntaxRole.DECLARATION: (filled.declaration, filled.bold_declaration, False),
+            SyntaxRole.IMPORT: (filled.import_, False, False),
+            SyntaxRole.STRING: (filled.string, False, False),
+            SyntaxRole.DOCSTRING: (filled.docstring, False, filled.italic_docstring),
+            SyntaxRole.NUMBER: (filled.number, False, False),
+            SyntaxRole.BOOLEAN: (filled.boolean, False, False),
+            SyntaxRole.TYPE: (filled.type_, False, False),
+            SyntaxRole.FUNCTION: (filled.function, False, False),
+            SyntaxRole.VARIABLE: (filled.variable, False, False),
+            SyntaxRole.CONSTANT: (filled.constant, False, False),
+            SyntaxRole.COMMENT: (filled.comment, False, filled.italic_comment),
+            SyntaxRole.ERROR: (filled.error, False, False),
+            SyntaxRole.WARNING: (filled.warning, False, False),
+            SyntaxRole.ADDED: (filled.added, False, False),
+            SyntaxRole.REMOVED: (filled.removed, False, False),
+            SyntaxRole.TEXT: (filled.text, False, False),
+            SyntaxRole.MUTED: (filled.muted, False, False),
+            SyntaxRole.PUNCTUATION: (filled.punctuation, False, False),
+            SyntaxRole.OPERATOR: (filled.operator, False, False),
+            SyntaxRole.ATTRIBUTE: (filled.attribute, False, False),
+            SyntaxRole.NAMESPACE: (filled.namespace, False, False),
+            SyntaxRole.TAG: (filled.tag, False, False),
+            SyntaxRole.REGEX: (filled.regex, False, False),
+            SyntaxRole.ESCAPE: (filled.escape, False, False),
+        }
+
+    def _role_css(self, class_style: CssClassStyle) -> list[str]:
+        """Build the color rule for each role's class."""
+        from rosettes.themes._mapping import PYGMENTS_CLASS_MAP
+
+        css_parts: list[str] = []
+        for role, (color, bold, italic) in self._role_styles().items():
+            if class_style == "semantic":
+                class_name = f".syntax-{role.value}"
+            else:
+                # Pygments style
+                pygments_class = PYGMENTS_CLASS_MAP.get(role, "")
+                if not pygments_class:
+                    continue  # Skip roles without Pygments mapping
+                class_name = f".{pygments_class}"
+
+            props = [f"color: {color}"]
+            if bold:
+                props.append("font-weight: bold")
+            if italic:
+                props.append("font-style: italic")
+
+            css_parts.append(f"{class_name} {{")
+            for prop in props:
+                css_parts.append(f"  {prop};")
+            css_parts.append("}")
+        return css_parts
+
+    def generate_css(
+        self,
+        *,
+        class_style: CssClassStyle = "semantic",
+        print: bool | PrintMode = False,
+    ) -> str:
         """Generate complete CSS stylesheet for syntax highlighting.
 
         Generates CSS rules for all semantic roles, suitable for use with
@@ -252,6 +508,10 @@ class SyntaxPalette:
             class_style: CSS class naming style:
                 - "semantic": Readable classes like .syntax-function
                 - "pygments": Pygments-compatible classes like .nf
+            print: Append an `@media print` section that switches to a
+                light palette from for_print() (True or "light") or a
+                high-contrast one ("high-contrast"), drops backgrounds,
+                marks highlighted lines with a border and wraps long lines.
 
         Returns:
             Complete CSS stylesheet as a string.
@@ -262,40 +522,8 @@ class SyntaxPalette:
             >>> ".syntax-function" in css
             True
         """
-        from rosettes.themes._roles import SyntaxRole
-        from rosettes.themes._mapping import PYGMENTS_CLASS_MAP
-
         filled = self.with_defaults()
 
-        # Map roles to their colors and CSS properties
-        role_colors: dict[SyntaxRole, tuple[str, list[str]]] = {
-            SyntaxRole.CONTROL_FLOW: (filled.control_flow, ["font-weight: bold"] if filled.bold_control else []),
-            SyntaxRole.DECLARATION: (filled.declaration, ["font-weight: bold"] if filled.bold_declaration else []),
-            SyntaxRole.IMPORT: (filled.import_, []),
-            SyntaxRole.STRING: (filled.string, []),
-            SyntaxRole.DOCSTRING: (filled.docstring, ["font-style: italic"] if filled.italic_docstring else []),
-            SyntaxRole.NUMBER: (filled.number, []),
-            SyntaxRole.BOOLEAN: (filled.boolean, []),
-            SyntaxRole.TYPE: (filled.type_, []),
-            SyntaxRole.FUNCTION: (filled.function, []),
-            SyntaxRole.VARIABLE: (filled.variable, []),
-            SyntaxRole.CONSTANT: (filled.constant, []),
-            SyntaxRole.COMMENT: (filled.comment, ["font-style: italic"] if filled.italic_comment else []),
-            SyntaxRole.ERROR: (filled.error, []),
-            SyntaxRole.WARNING: (filled.warning, []),
-            SyntaxRole.ADDED: (filled.added, []),
-            SyntaxRole.REMOVED: (filled.removed, []),
-            SyntaxRole.TEXT: (filled.text, []),
-            SyntaxRole.MUTED: (filled.muted, []),
-            SyntaxRole.PUNCTUATION: (filled.punctuation, []),
-            SyntaxRole.OPERATOR: (filled.operator, []),
-            SyntaxRole.ATTRIBUTE: (filled.attribute, []),
-            SyntaxRole.NAMESPACE: (filled.namespace, []),
-            SyntaxRole.TAG: (filled.tag, []),
-            SyntaxRole.REGEX: (filled.regex, []),
-            SyntaxRole.ESCAPE: (filled.escape, []),
-        }
-
         css_parts: list[str] = []
 
         # Add CSS custom properties block
@@ -313,24 +541,92 @@ class SyntaxPalette:
         css_parts.append("")
 
         # Add role-based styles
-        for role, (color, extra_props) in role_colors.items():
-            if class_style == "semantic":
-                class_name = f".syntax-{role.value}"
-            else:
-                # Pygments style
-                pygments_class = PYGMENTS_CLASS_MAP.get(role, "")
-                if not pygments_class:
-                    continue  # Skip roles without Pygments mapping
-                class_name = f".{pygments_class}"
+        css_parts.extend(self._role_css(class_style))
+
+        # Per-line data overlays (rosettes.linedata)
+        line_backgrounds = {
+            ".line-covered": f"color-mix(in srgb, {filled.added} 15%, transparent)",
+            ".line-missed": f"color-mix(in srgb, {filled.removed} 15%, transparent)",
+            ".line-partial": f"color-mix(in srgb, {filled.warning} 15%, transparent)",
+            ".line-heat": (
+                f"color-mix(in srgb, {filled.error} calc(var(--line-heat, 0) * 40%), transparent)"
+            ),
+        }
+        for class_name, background in line_backgrounds.items():
+            css_parts.append(f"{class_name} {{")
+            css_parts.append(f"  background-color: {background};")
+            css_parts.append("}")
+        css_parts.append(".line-gutter {")
+        css_parts.append(f"  color: {filled.muted};")
+        css_parts.append("  padding-right: 1ch;")
+        css_parts.append("  user-select: none;")
+        css_parts.append("}")
 
-            props = [f"color: {color}"]
-            props.extend(extra_props)
+        # Soft wrap (HtmlFormatter(wrap=True)): flex rows keep the gutter apart
+        wrap_rules = {
+            ".line-wrap": ["display: flex"],
+            ".line-wrap > .lineno": [
+                "flex: none",
+                "min-width: var(--lineno-width, 2ch)",
+                "padding-right: 1ch",
+                "text-align: right",
+                f"color: {filled.muted}",
+                "user-select: none",
+            ],
+            ".line-wrap > .line-gutter": ["flex: none"],
+            ".line-code": [
+                "flex: 1",
+                "min-width: 0",
+                "min-height: 1lh",
+                "white-space: pre-wrap",
+                "overflow-wrap: anywhere",
+                "padding-left: var(--hang, 2ch)",
+                "text-indent: calc(-1 * var(--hang, 2ch))",
+            ],
+        }
+        for selector, props in wrap_rules.items():
+            css_parts.append(f"{selector} {{")
+            for prop in props:
+                css_parts.append(f"  {prop};")
+            css_parts.append("}")
 
-            css_parts.append(f"{class_name} {{")
+        # Tab groups (rosettes.groups): radio + label + panel triples
+        tab_rules = {
+            ".rosettes-group": ["display: flex", "flex-wrap: wrap"],
+            ".rosettes-group > input": [
+                "position: absolute",
+                "opacity: 0",
+                "pointer-events: none",
+            ],
+            ".rosettes-group > label": [
+                "order: 0",
+                "padding: 0.4em 1em",
+                "cursor: pointer",
+                f"color: {filled.muted}",
+                f"background-color: {filled.background}",
+                "border-bottom: 2px solid transparent",
+            ],
+            ".rosettes-group > input:checked + label": [
+                f"color: {filled.text}",
+                f"border-bottom-color: {filled.function}",
+            ],
+            ".rosettes-group > input:focus-visible + label": [
+                f"outline: 2px solid {filled.function}",
+                "outline-offset: -2px",
+            ],
+            ".rosettes-group > .rosettes-panel": ["order: 1", "width: 100%", "display: none"],
+            ".rosettes-group > input:checked + label + .rosettes-panel": ["display: block"],
+        }
+        for selector, props in tab_rules.items():
+            css_parts.append(f"{selector} {{")
             for prop in props:
                 css_parts.append(f"  {prop};")
             css_parts.append("}")
 
+        if print:
+            css_parts.append("")
+            css_parts.extend(self._print_css(class_style, "light" if print is True else print))
+
         return "\n".join(css_parts)
 
 
@@ -376,7 +672,12 @@ class AdaptivePalette:
         if not self.name:
             raise ValueError("Palette name is required")
 
-    def generate_css(self, *, class_style: CssClassStyle = "semantic") -> str:
+    def generate_css(
+        self,
+        *,
+        class_style: CssClassStyle = "semantic",
+        print: bool | PrintMode = False,
+    ) -> str:
         """Generate adaptive CSS with light/dark mode support.
 
         Generates CSS with @media (prefers-color-scheme) queries for
@@ -386,6 +687,8 @@ class AdaptivePalette:
             class_style: CSS class naming style:
                 - "semantic": Readable classes like .syntax-function
                 - "pygments": Pygments-compatible classes like .nf
+            print: Append an `@media print` section derived from the light
+                palette; see SyntaxPalette.generate_css().
 
         Returns:
             Complete CSS stylesheet with media queries.
@@ -419,4 +722,11 @@ class AdaptivePalette:
                 css_parts.append(f"  {line}")
         css_parts.append("}")
 
+        # Print comes last so it overrides a dark scheme
+        if print:
+            css_parts.append("")
+            css_parts.extend(
+                self.light._print_css(class_style, "light" if print is True else print)
+            )
+
         return "\n".join(css_parts)
@@ -0,0 +1,233 @@
+"""Cross-reference links from identifiers to API documentation.
+
+`HtmlFormatter` can wrap names in `<a>` elements that point to their
+documentation. A resolver maps a name to a URL:
+
+```python
+>>> from rosettes import highlight
+>>> from rosettes.xref import load_inventory
+>>> links = load_inventory("python-objects.inv", "https://docs.python.org/3/")
+>>> html = highlight("os.path.join(a, b)", "python", resolver=links)
+```
+
+**Resolvers:**
+
+A resolver is either a callable that takes a name and returns a URL (or
+None), or a mapping from names to URLs, such as the one `load_inventory()`
+builds from a Sphinx `objects.inv` file. Merge several inventories with
+`{**python, **project}` or chain them in a callable.
+
+**Dotted Chains:**
+
+Names joined by `.` or `::` are resolved by their qualified prefix: in
+`os.path.join`, `os` is looked up as `os`, `path` as `os.path` and `join`
+as `os.path.join`, so each part links to its own page. A name after a
+`.` that doesn't follow a name (`f().x`) isn't looked up, since its
+qualified name is unknown.
+
+**Safety:**
+
+URLs are HTML-escaped, and only relative URLs and `http`/`https` URLs
+are linked. URLs with other schemes (`javascript:`, `data:`) or with
+whitespace or control characters are dropped.
+
+**Performance:**
+
+Without a resolver, formatting is unchanged. With one, each distinct
+qualified name is looked up once per `format()` call.
+
+**Thread-Safety:**
+
+Inventories are plain dicts built once; resolving uses only local
+state. Resolver callables must be thread-safe to be shared.
+
+**See Also:**
+
+- `rosettes.formatters.html.HtmlFormatter`: `resolver` field
+- `rosettes.highlight`: `resolver=` argument
+"""
+
+from __future__ import annotations
+
+import os
+import zlib
+from collections.abc import Callable, Collection, Iterable, Iterator, Mapping
+
+from rosettes._types import Token, TokenType
+
+__all__ = ["LINK_TYPES", "Resolver", "load_inventory", "parse_inventory", "resolve_links"]
+
+Resolver = Callable[[str], "str | None"] | Mapping[str, str]
+
+# Token types that are looked up
+LINK_TYPES = frozenset(
+    {
+        TokenType.NAME,
+        TokenType.NAME_FUNCTION,
+        TokenType.NAME_CLASS,
+        TokenType.NAME_BUILTIN,
+        TokenType.NAME_EXCEPTION,
+        TokenType.NAME_NAMESPACE,
+    }
+)
+
+# Operators and punctuation that join a dotted chain
+_CHAIN_SEPARATORS = frozenset({".", "::"})
+
+_SAFE_SCHEMES = frozenset({"http", "https"})
+
+
+def _safe_url(url: str) -> bool:
+    """Accept relative and http(s) URLs without whitespace or control chars."""
+    if not url or any(ord(char) <= 32 or ord(char) == 127 for char in url):
+        return False
+    scheme, colon, _ = url.partition(":")
+    if not colon or "/" in scheme or "?" in scheme or "#" in scheme:
+        return True
+    return scheme.lower() in _SAFE_SCHEMES
+
+
+def resolve_links(
+    tokens: Iterable[Token],
+    resolver: Resolver,
+) -> Iterator[tuple[Token, str | None]]:
+    """Pair each token with the URL its name resolves to.
+
+    Args:
+        tokens: Token stream.
+        resolver: Callable or mapping from qualified names to URLs.
+
+    Yields:
+        (token, url) pairs; url is None for tokens that aren't linked.
+
+    Example:
+        >>> from rosettes import tokenize
+        >>> pairs = resolve_links(tokenize("os.path", "python"), {"os.path": "p.html"})
+        >>> [(t.value, url) for t, url in pairs]
+        [('os', None), ('.', None), ('path', 'p.html')]
+    """
+    lookup = resolver.get if isinstance(resolver, Mapping) else resolver
+    cache: dict[str, str | None] = {}
+    # Qualified name of the chain so far; None when no chain is open
+    chain: str | None = None
+    separator: str | None = None
+
+    for token in tokens:
+        if token.type in LINK_TYPES:
+            if separator is None:
+                name = token.value
+            elif chain is not None:
+                name = f"{chain}{separator}{token.value}"
+            else:
+                name = None
+            separator = None
+            chain = name
+            url = None
+            if name is not None:
+                if name in cache:
+                    url = cache[name]
+                else:
+                    url = lookup(name)
+                    if url is not None and not _safe_url(url):
+                        url = None
+                    cache[name] = url
+            yield token, url
+            continue
+
+        if token.value in _CHAIN_SEPARATORS and separator is None:
+            # Keeps chain; a name after a separator with no chain is skipped
+            separator = token.value
+        else:
+            chain = None
+            separator = None
+        yield token, None
+
+
+def _is_int(text: str) -> bool:
+    return text.lstrip("-").isdigit()
+
+
+def parse_inventory(
+    data: bytes,
+    base_url: str = "",
+    *,
+    domains: Collection[str] | None = ("py",),
+) -> dict[str, str]:
+    """Parse a Sphinx inventory (`objects.inv`, version 2) into a mapping.
+
+    Args:
+        data: Contents of the inventory file.
+        base_url: Prefix for the inventory's relative URLs, usually the
+            documentation root the inventory was downloaded from.
+        domains: Sphinx domains to keep (`py`, `js`, `c`, `cpp`, ...), or
+            None for all.
+
+    Returns:
+        Mapping from object names to URLs.
+
+    Raises:
+        ValueError: If the data isn't a version 2 inventory.
+    """
+    header: list[bytes] = []
+    rest = data
+    for _ in range(4):
+        line, newline, rest = rest.partition(b"\n")
+        if not newline:
+            raise ValueError("Truncated inventory header")
+        header.append(line)
+    if header[0].strip() != b"# Sphinx inventory version 2":
+        raise ValueError(f"Unsupported inventory format: {header[0][:60]!r}")
+    try:
+        text = zlib.decompress(rest).decode("utf-8")
+    except (zlib.error, UnicodeDecodeError) as e:
+        raise ValueError(f"Corrupt inventory data: {e}") from None
+
+    if base_url and not base_url.endswith("/"):
+        base_url += "/"
+    objects: dict[str, str] = {}
+    for line in text.splitlines():
+        # name domain:role priority uri dispname; the name may contain spaces
+        parts = line.split()
+        for index in range(1, len(parts) - 2):
+            if ":" in parts[index] and _is_int(parts[index + 1]):
+                break
+        else:
+            continue
+        name = " ".join(parts[:index])
+        domain = parts[index].partition(":")[0]
+        if domains is not None and domain not in domains:
+            continue
+        uri = parts[index + 2]
+        if uri.endswith("$"):
+            uri = uri[:-1] + name
+        objects.setdefault(name, base_url + uri)
+    return objects
+
+
+def load_inventory(
+    path: str | os.PathLike[str],
+    base_url: str = "",
+    *,
+    domains: Collection[str] | None = ("py",),
+) -> dict[str, str]:
+    """Load a Sphinx inventory file into a name → URL mapping.
+
+    Args:
+        path: Path to an `objects.inv` file.
+        base_url: Prefix for the inventory's relative URLs.
+        domains: Sphinx domains to keep, or None for all.
+
+    Returns:
+        Mapping usable as a resolver.
+
+    Raises:
+        OSError: If the file can't be read.
+        ValueError: If the file isn't a version 2 inventory.
+
+    Example:
+        >>> links = load_inventory("objects.inv", "https://docs.python.org/3/")
+        >>> links["os.path.join"]
+        'https://docs.python.org/3/library/os.path.html#os.path.join'
+    """
+    with open(path, "rb") as f:
+        return parse_inventory(f.read(), base_url, domains=domains)
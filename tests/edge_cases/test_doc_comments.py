@@ -0,0 +1,261 @@
+"""Tests for doc comment detection and doc markup tokenization."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, get_lexer
+from rosettes.lexers._doc_markup import scan_doc_markup
+
+_MARKUP_TYPES = frozenset(
+    {
+        TokenType.NAME_DECORATOR,
+        TokenType.NAME_VARIABLE,
+        TokenType.KEYWORD_TYPE,
+        TokenType.NAME_ENTITY,
+        TokenType.STRING_BACKTICK,
+    }
+)
+
+
+def _markup(language: str, code: str) -> list[tuple[TokenType, str]]:
+    """Return (type, value) pairs for markup tokens."""
+    tokens = get_lexer(language).tokenize(code)
+    return [(t.type, t.value) for t in tokens if t.type in _MARKUP_TYPES]
+
+
+def _comment_types(language: str, code: str) -> set[TokenType]:
+    """Return the comment token types produced for code (ignoring PHP's `<?php`)."""
+    tokens = get_lexer(language).tokenize(code)
+    return {
+        t.type
+        for t in tokens
+        if t.type.name.startswith("COMMENT") and t.type != TokenType.COMMENT_PREPROC
+    }
+
+
+class TestDocCommentDetection:
+    """Doc comments are COMMENT_DOC; ordinary comments are not."""
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [
+            ("javascript", "/** Doc. */"),
+            ("typescript", "/** Doc. */"),
+            ("java", "/** Doc. */"),
+            ("kotlin", "/** Doc. */"),
+            ("rust", "/// Doc."),
+            ("rust", "//! Crate doc."),
+            ("rust", "/*! Inner doc. */"),
+            ("cpp", "/// Doxygen."),
+            ("swift", "/// Doc."),
+            ("dart", "/// Doc."),
+            ("zig", "//! Top-level doc."),
+            ("php", "<?php /** Doc. */"),
+        ],
+    )
+    def test_doc_comment(self, language: str, code: str) -> None:
+        """Doc comment openers should produce COMMENT_DOC."""
+        assert _comment_types(language, code) == {TokenType.COMMENT_DOC}
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [
+            ("javascript", "/**/"),
+            ("javascript", "/*** banner ***/"),
+            ("rust", "//// banner"),
+            ("java", "// Note."),
+            ("go", "/** Not special in Go. */"),
+        ],
+    )
+    def test_ordinary_comment(self, language: str, code: str) -> None:
+        """Banners, empty comments, and languages without doc syntax stay ordinary."""
+        assert TokenType.COMMENT_DOC not in _comment_types(language, code)
+
+    def test_gleam_module_doc(self) -> None:
+        """Gleam's //// module docs are doc comments."""
+        assert _comment_types("gleam", "//// Module doc.") == {TokenType.COMMENT_DOC}
+
+    def test_multiline_doc_positions(self) -> None:
+        """Tokens after a multi-line doc comment keep correct positions."""
+        tokens = list(get_lexer("java").tokenize("/**\n * @param x\n */\nint y;"))
+        keyword = next(t for t in tokens if t.value == "int")
+        assert (keyword.line, keyword.column) == (4, 1)
+
+
+class TestTags:
+    """Block tags, inline tags, and their arguments."""
+
+    def test_jsdoc_param(self) -> None:
+        """@param {Type} name splits tag, type, and name."""
+        assert _markup("javascript", "/** @param {string} name - The name. */") == [
+            (TokenType.NAME_DECORATOR, "@param"),
+            (TokenType.KEYWORD_TYPE, "{string}"),
+            (TokenType.NAME_VARIABLE, "name"),
+        ]
+
+    def test_javadoc_throws(self) -> None:
+        """@throws names an exception type."""
+        assert _markup("java", "/**\n * @throws IOException on failure\n */") == [
+            (TokenType.NAME_DECORATOR, "@throws"),
+            (TokenType.KEYWORD_TYPE, "IOException"),
+        ]
+
+    def test_inline_link(self) -> None:
+        """{@link Target label} marks the target as a link."""
+        assert _markup("java", "/** See {@link Foo#bar() the bar}. */") == [
+            (TokenType.NAME_DECORATOR, "{@link"),
+            (TokenType.NAME_ENTITY, "Foo#bar()"),
+            (TokenType.NAME_DECORATOR, "}"),
+        ]
+
+    def test_inline_code_tag(self) -> None:
+        """{@code ...} content is inline code."""
+        markup = _markup("java", "/** Returns {@code null} if absent. */")
+        assert (TokenType.STRING_BACKTICK, "null") in markup
+
+    def test_tag_mid_sentence_ignored(self) -> None:
+        """An @ inside prose (e.g., an email address) is not a tag."""
+        assert _markup("javascript", "/** Mail user@example.com. */") == []
+
+    def test_doxygen_backslash_tag(self) -> None:
+        """Doxygen \\param commands are recognized in comments."""
+        assert _markup("cpp", "/// \\param count Number of items.") == [
+            (TokenType.NAME_DECORATOR, "\\param"),
+            (TokenType.NAME_VARIABLE, "count"),
+        ]
+
+
+class TestRustdoc:
+    """Rustdoc intra-doc links and inline code."""
+
+    def test_intra_doc_links(self) -> None:
+        """[`Path`] and [Path] are links; `code` is inline code."""
+        assert _markup("rust", "/// Returns a [`Vec`] of `u8`, see [Self::new].") == [
+            (TokenType.NAME_ENTITY, "[`Vec`]"),
+            (TokenType.STRING_BACKTICK, "`u8`"),
+            (TokenType.NAME_ENTITY, "[Self::new]"),
+        ]
+
+    def test_markdown_link(self) -> None:
+        """[text](url) is a single link token."""
+        assert _markup("rust", "/// Read the [guide](https://example.com).") == [
+            (TokenType.NAME_ENTITY, "[guide](https://example.com)"),
+        ]
+
+    def test_index_not_link(self) -> None:
+        """Brackets directly after an identifier are indexing, not links."""
+        assert _markup("rust", "/// Reads buf[0] first.") == []
+
+
+class TestDocstrings:
+    """reST and Google-style markup in Python docstrings."""
+
+    def test_rest_fields(self) -> None:
+        """:param type name: splits marker, type, and name."""
+        code = 'def f(x):\n    """Do.\n\n    :param int x: Value.\n    """\n'
+        assert _markup("python", code) == [
+            (TokenType.NAME_DECORATOR, ":param"),
+            (TokenType.KEYWORD_TYPE, "int"),
+            (TokenType.NAME_VARIABLE, "x"),
+            (TokenType.NAME_DECORATOR, ":"),
+        ]
+
+    def test_sphinx_role(self) -> None:
+        """:class:`Foo` is a role followed by a link."""
+        code = '"""Return a :class:`Foo`."""'
+        assert _markup("python", code) == [
+            (TokenType.NAME_DECORATOR, ":class:"),
+            (TokenType.NAME_ENTITY, "`Foo`"),
+        ]
+
+    def test_google_sections(self) -> None:
+        """Args: entries mark names and types; Raises: entries mark types."""
+        code = (
+            'def f(x):\n    """Do.\n\n    Args:\n        x (int): Value.\n'
+            "            More text: here.\n\n    Raises:\n        KeyError: Missing.\n"
+            '    """\n'
+        )
+        assert _markup("python", code) == [
+            (TokenType.NAME_DECORATOR, "Args:"),
+            (TokenType.NAME_VARIABLE, "x"),
+            (TokenType.KEYWORD_TYPE, "int"),
+            (TokenType.NAME_DECORATOR, "Raises:"),
+            (TokenType.KEYWORD_TYPE, "KeyError"),
+        ]
+
+    def test_non_docstring_untouched(self) -> None:
+        """Triple-quoted strings in expressions are not docstrings."""
+        assert _markup("python", 'sql = """SELECT `name` FROM t"""') == []
+
+    @pytest.mark.parametrize(
+        "code",
+        [
+            'run(\n    """SELECT `name` FROM t"""\n)',
+            'x = 1\n"""Not `first`."""',
+            'if x:\n    """Not `a` body of a def."""',
+            'def f(x: dict[str, int] = {}) -> None:\n    pass\n    """After `pass`."""',
+        ],
+    )
+    def test_only_first_statement(self, code: str) -> None:
+        """Only the first statement of a module, class or def is a docstring."""
+        assert _markup("python", code) == []
+
+    @pytest.mark.parametrize(
+        "code",
+        [
+            'sql = """SELECT 1"""',
+            'x = 1\n"""Not first."""',
+            'def f():\n    pass\n    r"""After pass."""',
+            'f"""{x}"""',
+        ],
+    )
+    def test_other_strings_are_plain(self, code: str) -> None:
+        """Triple-quoted strings that aren't docstrings are STRING."""
+        types = {t.type for t in get_lexer("python").tokenize(code)}
+        assert TokenType.STRING in types
+        assert TokenType.STRING_DOC not in types
+
+    def test_class_and_def_bodies(self) -> None:
+        """Docstrings follow the header colon, on its line or the next."""
+        code = 'class A(B):\n    """`a`."""\n    def f(self): """`b`."""\n'
+        markup = [value for _, value in _markup("python", code)]
+        assert markup == ["`a`", "`b`"]
+
+    def test_bare_brackets_not_links(self) -> None:
+        """Docstrings don't treat bare [word] as an intra-doc link."""
+        assert _markup("python", '"""Value [optional]."""') == []
+
+    def test_escapes_still_split(self) -> None:
+        """Escape sequences are still split out of docstrings."""
+        tokens = list(get_lexer("python").tokenize('"""Tab\\t `x`."""'))
+        types = [t.type for t in tokens]
+        assert TokenType.STRING_ESCAPE in types
+        assert TokenType.STRING_BACKTICK in types
+
+
+class TestPositions:
+    """Split doc tokens keep exact text and positions."""
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [
+            ("java", "/**\n * @param x {@link Y}\n * @return `z`\n */\nclass A {}"),
+            ("rust", "//! [`a`]\n/// `b` [c](d)\nfn e() {}"),
+            ("python", 'def f():\n    """A.\n\n    Args:\n        a (int): B.\n    """\n'),
+        ],
+    )
+    def test_round_trip(self, language: str, code: str) -> None:
+        """Concatenated values reproduce the input."""
+        tokens = get_lexer(language).tokenize(code)
+        assert "".join(t.value for t in tokens) == code
+
+    def test_markup_columns(self) -> None:
+        """Markup tokens on later lines report their own line and column."""
+        tokens = list(get_lexer("java").tokenize("/**\n * @param name\n */"))
+        name = next(t for t in tokens if t.type == TokenType.NAME_VARIABLE)
+        assert (name.line, name.column) == (2, 11)
+
+    def test_scan_unclosed_markup(self) -> None:
+        """Unclosed backticks, brackets, and inline tags are plain text."""
+        assert scan_doc_markup("/// `open [link {@link x") == []
@@ -2,44 +2,171 @@
 
 from __future__ import annotations
 
+import pytest
+
 from rosettes import TokenType, get_lexer
 
 
+def _escapes(language: str, code: str) -> list[str]:
+    """Return the values of all STRING_ESCAPE tokens."""
+    tokens = get_lexer(language).tokenize(code)
+    return [t.value for t in tokens if t.type == TokenType.STRING_ESCAPE]
+
+
 class TestStandardEscapes:
     """Test standard escape sequences."""
 
     def test_all_standard_escapes(self) -> None:
-        """Escape sequences should be included in STRING token."""
-        lexer = get_lexer("python")
+        """Each escape sequence should be its own STRING_ESCAPE token."""
         # String containing: \n \t \r \\ \" \'
         code = r'"\n\t\r\\\"\'"'
-        tokens = list(lexer.tokenize(code))
-        # Escape sequences are part of the string token, not separate tokens
-        string_tokens = [t for t in tokens if t.type == TokenType.STRING]
-        assert len(string_tokens) > 0
-        # Verify escape sequences are in the string value
-        value = string_tokens[0].value
-        assert "\\n" in value or "\n" in value
+        assert _escapes("python", code) == ["\\n", "\\t", "\\r", "\\\\", '\\"', "\\'"]
 
     def test_newline_escape(self) -> None:
-        """Escape sequences should be included in STRING token."""
+        """Escape sequences should be split out of the STRING token."""
         lexer = get_lexer("python")
-        code = '"\\n"'
+        code = '"a\\nb"'
         tokens = list(lexer.tokenize(code))
-        string_tokens = [t for t in tokens if t.type == TokenType.STRING]
-        assert len(string_tokens) > 0
-        # Verify escape sequence is in the string value
-        assert "\\n" in string_tokens[0].value or "\n" in string_tokens[0].value
+        assert [(t.type, t.value) for t in tokens] == [
+            (TokenType.STRING, '"a'),
+            (TokenType.STRING_ESCAPE, "\\n"),
+            (TokenType.STRING, 'b"'),
+        ]
 
     def test_tab_escape(self) -> None:
-        """Escape sequences should be included in STRING token."""
+        """Escape token columns should point at the backslash."""
+        lexer = get_lexer("python")
+        code = 'x = "\\t"'
+        tokens = list(lexer.tokenize(code))
+        escape = next(t for t in tokens if t.type == TokenType.STRING_ESCAPE)
+        assert escape.value == "\\t"
+        assert escape.column == 6
+
+    def test_escape_position_in_multiline_string(self) -> None:
+        """Escapes after a newline should report the correct line and column."""
         lexer = get_lexer("python")
-        code = '"\\t"'
+        code = '"""first\n  \\tsecond"""'
         tokens = list(lexer.tokenize(code))
-        string_tokens = [t for t in tokens if t.type == TokenType.STRING]
-        assert len(string_tokens) > 0
-        # Verify escape sequence is in the string value
-        assert "\\t" in string_tokens[0].value or "\t" in string_tokens[0].value
+        escape = next(t for t in tokens if t.type == TokenType.STRING_ESCAPE)
+        assert (escape.line, escape.column) == (2, 3)
+
+
+class TestNumericEscapes:
+    """Test hex, octal, and Unicode escapes."""
+
+    @pytest.mark.parametrize(
+        ("language", "code", "expected"),
+        [
+            ("python", r'"\x41\101\u00e9\U0001F600"', ["\\x41", "\\101", "\\u00e9", "\\U0001F600"]),
+            ("python", r'"\N{EM DASH}"', ["\\N{EM DASH}"]),
+            ("rust", r'"\u{1F600}\x7f"', ["\\u{1F600}", "\\x7f"]),
+            ("javascript", r'"\u{1F600}\u0041"', ["\\u{1F600}", "\\u0041"]),
+            ("json", r'"\u0041\n"', ["\\u0041", "\\n"]),
+            ("go", r'"\u00e9\377"', ["\\u00e9", "\\377"]),
+            ("c", r'"\x1b[0m"', ["\\x1b"]),
+            ("css", r'"\26 \A9"', ["\\26", "\\A9"]),
+        ],
+    )
+    def test_numeric_escapes(self, language: str, code: str, expected: list[str]) -> None:
+        """Numeric escapes should consume exactly their digits."""
+        assert _escapes(language, code) == expected
+
+
+class TestLanguageEscapes:
+    """Test language-specific escape rules."""
+
+    def test_bash_double_quotes(self) -> None:
+        """Only $ ` \" \\ and newline are escapes inside bash double quotes."""
+        assert _escapes("bash", r'"\$HOME \n"') == ["\\$"]
+
+    def test_bash_ansi_c_quoting(self) -> None:
+        """$'...' strings use C-style escapes."""
+        assert _escapes("bash", r"$'\t\x41'") == ["\\t", "\\x41"]
+
+    def test_bash_single_quotes_literal(self) -> None:
+        """Single-quoted bash strings have no escapes."""
+        assert _escapes("bash", r"'\n'") == []
+
+    def test_php_single_quotes(self) -> None:
+        """PHP single-quoted strings only escape \\\\ and \\'."""
+        assert _escapes("php", r"<?php '\n\'\\';") == ["\\'", "\\\\"]
+
+    def test_powershell_backtick(self) -> None:
+        """PowerShell uses the backtick as escape character."""
+        assert _escapes("powershell", '"a`nb"') == ["`n"]
+
+    def test_swift_interpolation_not_escape(self) -> None:
+        """Swift interpolation is not an escape sequence."""
+        assert _escapes("swift", r'"\(x)\n"') == ["\\n"]
+
+    def test_char_literal(self) -> None:
+        """Character literal escapes keep the STRING_CHAR surroundings."""
+        tokens = list(get_lexer("c").tokenize(r"'\n'"))
+        assert [t.type for t in tokens] == [
+            TokenType.STRING_CHAR,
+            TokenType.STRING_ESCAPE,
+            TokenType.STRING_CHAR,
+        ]
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [
+            ("python", 'r"\\n"'),
+            ("rust", 'r"\\n"'),
+            ("go", "`\\n`"),
+            ("cpp", 'R"(\\n)"'),
+            ("toml", "'\\n'"),
+        ],
+    )
+    def test_raw_strings_have_no_escapes(self, language: str, code: str) -> None:
+        """Raw and literal strings should not emit STRING_ESCAPE."""
+        assert _escapes(language, code) == []
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [
+            ("python", '"a\\nb\\x41\\\n c"'),
+            ("bash", 'echo "a\\"b" $\'\\t\''),
+            ("rust", 'let s = "\\u{1F600}\\"";'),
+            ("json", '{"k": "\\u00e9\\\\"}'),
+            ("c", 'char *s = "unterminated\\'),
+        ],
+    )
+    def test_round_trip(self, language: str, code: str) -> None:
+        """Splitting escapes must preserve the original text."""
+        tokens = get_lexer(language).tokenize(code)
+        assert "".join(t.value for t in tokens) == code
+
+
+_ESCAPING_LANGUAGES = [
+    "bash", "c", "clojure", "cpp", "css", "cuda", "cue", "dart", "dockerfile", "elixir",
+    "gleam", "go", "graphql", "groovy", "haskell", "hcl", "java", "javascript", "jinja",
+    "json", "julia", "kida", "kotlin", "less", "lua", "makefile", "mojo", "nginx", "nim",
+    "perl", "php", "pkl", "powershell", "protobuf", "python", "r", "ruby", "rust", "sass",
+    "scala", "scss", "stan", "stylus", "swift", "toml", "triton", "typescript", "v", "yaml",
+    "zig",
+]
+
+
+class TestTrailingBackslash:
+    """Test unterminated literals that end in the escape character."""
+
+    @pytest.mark.parametrize("language", _ESCAPING_LANGUAGES)
+    @pytest.mark.parametrize("code", ['"a\\', "'a\\", "`a\\", "c'\\", '"\\'])
+    def test_round_trip(self, language: str, code: str) -> None:
+        """A backslash at the end of the code doesn't run past it."""
+        tokens = get_lexer(language).tokenize(code)
+        assert "".join(t.value for t in tokens) == code
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [("json", '"a\\'), ("julia", "c'\\"), ("nim", "c'\\"), ("typescript", "`a\\")],
+    )
+    def test_trailing_escape(self, language: str, code: str) -> None:
+        """The last token ends with the backslash, at the end of the code."""
+        tokens = list(get_lexer(language).tokenize(code))
+        assert tokens[-1].value.endswith("\\")
+        assert tokens[-1].column + len(tokens[-1].value) == len(code) + 1
 
 
 class TestInvalidEscapes:
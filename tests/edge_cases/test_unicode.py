@@ -50,15 +50,12 @@ class TestUnicodeInStrings:
         assert "日本語" in all_values
 
     def test_unicode_escape_sequences(self) -> None:
-        """Unicode escape sequences should be included in STRING token."""
+        """Unicode escape sequences should be STRING_ESCAPE tokens."""
         lexer = get_lexer("python")
         code = '"\\u0041"'  # Unicode escape
         tokens = list(lexer.tokenize(code))
-        # Escape sequences are part of the string token, not separate tokens
-        string_tokens = [t for t in tokens if t.type == TokenType.STRING]
-        assert len(string_tokens) > 0
-        # Verify escape sequence is in the string value
-        assert "\\u0041" in string_tokens[0].value
+        escape_tokens = [t for t in tokens if t.type == TokenType.STRING_ESCAPE]
+        assert [t.value for t in escape_tokens] == ["\\u0041"]
 
 
 class TestUnicodeBoundaries:
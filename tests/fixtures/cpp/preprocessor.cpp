@@ -0,0 +1,24 @@
+#include <stdio.h>
+#include "config.h"  // local
+#  define MAX(a, b) \
+    ((a) > (b) ? (a) : (b))
+#define STR(x) #x
+#define CAT(a, b) a##b
+#ifndef CONFIG_H
+#pragma once
+#if 0
+int disabled(void) { return "no"; }
+#  if FOO
+nested();
+#  endif
+#else
+int enabled;
+#endif
+#if 0 // reason
+dead();
+#endif
+auto s = R"sql(SELECT "x" FROM t)sql";
+auto u = u8R"(
+multi
+)";
+int after = 1;
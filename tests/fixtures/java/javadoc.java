@@ -0,0 +1,12 @@
+/**
+ * Fetches a resource. See {@link java.net.URL} and {@code fetch(url)}.
+ *
+ * @param <T> the result type
+ * @param url the address to fetch
+ * @return the response body
+ * @throws IOException if the request fails
+ * @see Client#send(Request)
+ */
+public <T> T fetch(String url) throws IOException {
+    return client.get(url);
+}
\ No newline at end of file
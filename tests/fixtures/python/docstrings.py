@@ -0,0 +1,23 @@
+def fetch(url, timeout=None, **kwargs):
+    """Fetch a URL. See :func:`urllib.request.urlopen`.
+
+    Args:
+        url (str): The address to fetch.
+        timeout (float): Seconds to wait, or ``None``.
+        **kwargs: Extra options.
+
+    Returns:
+        The response `body`.
+
+    Raises:
+        TimeoutError: If the request times out.
+    """
+
+
+def parse(text):
+    """Parse text.
+
+    :param str text: Input text.
+    :returns: The parsed value.
+    :raises ValueError: If the text is malformed.
+    """
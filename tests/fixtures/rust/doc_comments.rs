@@ -0,0 +1,8 @@
+//! Networking helpers built on [`std::net`].
+
+/// Fetches `url`, returning a [`Response`].
+///
+/// See [Client::send] and the [guide](https://example.com/guide).
+pub fn fetch(url: &str) -> Response {
+    Client::new().send(url)
+}
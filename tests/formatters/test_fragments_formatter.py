@@ -0,0 +1,67 @@
+"""Tests for StyleFragmentsFormatter."""
+
+from __future__ import annotations
+
+from rosettes import TokenType, tokenize, tokenize_lines
+from rosettes.config import RosettesConfig, configure
+from rosettes.formatters import StyleFragmentsFormatter
+from rosettes.themes import SyntaxPalette, get_palette
+
+_PALETTE = SyntaxPalette(
+    "test",
+    "#000000",
+    "#eeeeee",
+    control_flow="#ff0000",
+    string="#00ff00",
+    comment="#888888",
+)
+
+
+class TestStyleFragments:
+    """Test styles derived from a palette."""
+
+    def test_fragments(self) -> None:
+        """Each token becomes a (style, text) pair."""
+        formatter = StyleFragmentsFormatter(_PALETTE)
+        fragments = list(formatter.format_fragments(tokenize('if x: "s"  # c', "python")))
+        assert fragments[0] == ("#ff0000 bold", "if")
+        assert ("#00ff00", '"s"') in fragments
+        assert fragments[-1] == ("#888888 italic", "# c")
+        assert "".join(text for _, text in fragments) == 'if x: "s"  # c'
+
+    def test_flags(self) -> None:
+        """bold_control and italic_comment control the style words."""
+        palette = SyntaxPalette(
+            "plain", "#000000", "#eeeeee", bold_control=False, italic_comment=False
+        )
+        styles = StyleFragmentsFormatter(palette).styles()
+        assert styles[TokenType.KEYWORD] == "#eeeeee"
+        assert styles[TokenType.COMMENT_SINGLE] == "#eeeeee"
+        assert styles[TokenType.KEYWORD_DECLARATION] == "#eeeeee bold"
+
+    def test_every_token_type(self) -> None:
+        """Every token type has a style; text uses the text color."""
+        styles = StyleFragmentsFormatter(_PALETTE).styles()
+        assert set(styles) == set(TokenType)
+        assert styles[TokenType.TEXT] == styles[TokenType.WHITESPACE] == "#eeeeee"
+
+    def test_lines(self) -> None:
+        """format_lines() styles each line from tokenize_lines()."""
+        formatter = StyleFragmentsFormatter(_PALETTE)
+        lines = list(formatter.format_lines(tokenize_lines("/* a\nb */\n", "c")))
+        assert lines == [[("#888888 italic", "/* a")], [("#888888 italic", "b */")]]
+
+    def test_palette_names(self) -> None:
+        """Palettes can be names; adaptive palettes pick a variant."""
+        github = get_palette("github")
+        assert StyleFragmentsFormatter("github").background == github.dark.background
+        light = StyleFragmentsFormatter("github", dark=False)
+        assert light.background == github.light.background
+
+    def test_configured_default(self) -> None:
+        """Without a palette, the configured default is used."""
+        configure(RosettesConfig(palette="monokai"))
+        try:
+            assert StyleFragmentsFormatter().background == get_palette("monokai").background
+        finally:
+            configure(RosettesConfig())
@@ -2,8 +2,8 @@
 
 from __future__ import annotations
 
-from rosettes import Token, TokenType, highlight
-from rosettes._config import FormatConfig
+from rosettes import Token, TokenType, get_lexer, highlight
+from rosettes._config import FormatConfig, HighlightConfig
 from rosettes.formatters import HtmlFormatter
 
 
@@ -38,6 +38,12 @@ class TestHtmlFormatterBasics:
         html = highlight("def foo(): pass", "python", css_class_style="pygments")
         assert 'class="highlight"' in html
 
+    def test_hashable(self) -> None:
+        """Formatters and their configs can be hashed (e.g. as cache keys)."""
+        config = HighlightConfig(line_classes=((1, "line-added"),))
+        assert hash(HtmlFormatter()) == hash(HtmlFormatter())
+        assert hash(HtmlFormatter(config=config)) == hash(HtmlFormatter(config=config))
+
 
 class TestHtmlFormatterLineHighlighting:
     """Test line highlighting functionality."""
@@ -66,6 +72,23 @@ class TestHtmlFormatterLineHighlighting:
         # Should not crash, may or may not highlight
         assert isinstance(html, str)
 
+    def test_line_classes(self) -> None:
+        """line_classes add classes to the line span, after the hl class."""
+        config = HighlightConfig(
+            hl_lines=frozenset({1}), line_classes=((1, "line-added"), (2, "x"))
+        )
+        tokens = get_lexer("python").tokenize("a\nb\n")
+        html = HtmlFormatter(config=config).format_string(tokens)
+
+        assert '<span class="hll line-added">' in html
+        assert '<span class="x">' in html
+
+    def test_indent_starts_next_line(self) -> None:
+        """Indentation after a line break opens inside the next line's span."""
+        html = highlight("if x:\n    y\n", "python", hl_lines={2})
+
+        assert '<span class="hll">    <span class="syntax-variable">y' in html
+
 
 class TestHtmlFormatterLineNumbers:
     """Test line number functionality."""
@@ -78,6 +101,45 @@ class TestHtmlFormatterLineNumbers:
         # Should have line number markers
         assert "linenos" in html or "line" in html.lower()
 
+    def test_lineno_spans(self) -> None:
+        """Each line starts with its number; the empty last line has none."""
+        html = highlight("a = 1\nb = 2\n", "python", show_linenos=True)
+        assert html.count('<span class="lineno">') == 2
+        assert '<span class="lineno">2</span><span class="syntax-variable">b</span>' in html
+
+
+class TestHtmlFormatterWrap:
+    """Test soft-wrapped output."""
+
+    def test_wrap_rows(self) -> None:
+        """Each line is a row with the gutter outside the code column."""
+        html = highlight("a\n  b\n", "python", wrap=True, show_linenos=True)
+        assert '<pre style="--lineno-width: 1ch">' in html
+        assert (
+            '<span class="line-wrap"><span class="lineno">2</span>'
+            '<span class="line-code" style="--hang: 4ch">  <span class="syntax-variable">b'
+            "</span></span>\n</span>"
+        ) in html
+        assert html.endswith("</span>\n</span></code></pre></div>")
+
+    def test_wrap_formatter_field(self) -> None:
+        """A formatter with wrap=True wraps without the highlight() argument."""
+        from rosettes import HtmlFormatter
+
+        html = highlight("x = 1", "python", formatter=HtmlFormatter(wrap=True), hl_lines={1})
+        assert html.count('<span class="line-wrap">') == 1
+        assert '<span class="line-code"><span class="hll">' in html
+        assert html.endswith("</span></span></span></code></pre></div>")
+
+    def test_wrap_palette_css(self) -> None:
+        """Generated stylesheets wrap the code column with a hanging indent."""
+        from rosettes.themes import get_palette
+
+        css = get_palette("monokai").generate_css()
+        assert ".line-code {" in css
+        assert "white-space: pre-wrap;" in css
+        assert "text-indent: calc(-1 * var(--hang, 2ch));" in css
+
 
 class TestHtmlFormatterEmptyHandling:
     """Test empty/whitespace handling."""
@@ -17,6 +17,10 @@ def test_terminal_highlight():
     assert "\033[35mpass\033[0m" in output
 
 
+def test_terminal_formatter_hashable():
+    assert hash(TerminalFormatter()) == hash(TerminalFormatter())
+
+
 def test_terminal_formatter_direct():
     from rosettes import get_lexer
 
@@ -45,3 +49,65 @@ def test_terminal_highlight_many():
     assert len(results) == 2
     assert "\033[" in results[0]
     assert "\033[" in results[1]
+
+
+def test_terminal_align_columns():
+    import re
+
+    formatter = TerminalFormatter(align_columns=True)
+    output = highlight("name,age\nAlice,30\nBo,5\n", "csv", formatter=formatter)
+    plain = re.sub(r"\033\[\d+m", "", output)
+
+    assert plain == "name ,age\nAlice,30\nBo   ,5\n"
+
+
+def test_terminal_align_columns_with_positions():
+    from rosettes import get_lexer
+
+    tokens = get_lexer("csv").tokenize("a\tbb\nccc\td")
+    output = TerminalFormatter(align_columns=True).format_string(tokens)
+
+    assert output.count("\t") == 2
+    assert "a\033[0m  \033[37m\t" in output
+
+
+def test_terminal_line_styles():
+    from rosettes import Token, TokenType
+    from rosettes._config import HighlightConfig
+
+    tokens = [
+        Token(TokenType.NAME, "a", 1, 1),
+        Token(TokenType.WHITESPACE, "\n", 1, 2),
+        Token(TokenType.NAME, "b", 2, 1),
+    ]
+    config = HighlightConfig(line_classes=((2, "line-added"),))
+    output = TerminalFormatter(config=config).format_string(iter(tokens))
+
+    assert output == "\033[37ma\033[0m\n\033[48;5;22m\033[37mb\033[0m\033[48;5;22m\033[0m"
+
+
+def test_terminal_hl_lines_via_highlight():
+    output = highlight("a = 1\nb = 2\n", "python", formatter="terminal", hl_lines={2})
+
+    first, second, _ = output.split("\n")
+    assert "\033[48;5;236m" not in first
+    assert second.startswith("\033[48;5;236m") and second.endswith("\033[0m")
+
+
+def test_terminal_wrap():
+    formatter = TerminalFormatter(wrap=True, width=10)
+    output = highlight('s = "abcdefghijkl"\n', "python", formatter=formatter)
+
+    first, second, _ = output.split("\n")
+    assert first.endswith('\033[32m"abcde\033[0m')
+    assert second == '\033[90m↪\033[0m \033[32mfghijkl"\033[0m'
+
+
+def test_terminal_wrap_wide_characters():
+    formatter = TerminalFormatter(wrap=True, width=12)
+    output = highlight("漢字漢字漢字漢字\n", "text", formatter=formatter, show_linenos=True)
+
+    assert output.split("\n")[:2] == [
+        "\033[90m1 │\033[0m 漢字漢字",
+        "\033[90m  │\033[0m \033[90m↪\033[0m 漢字漢",
+    ]
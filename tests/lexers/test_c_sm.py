@@ -0,0 +1,136 @@
+"""Tests for C-family preprocessor directives and C++ raw strings."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, get_lexer
+
+C_FAMILY = ["c", "cpp", "cuda"]
+
+
+def _tokens(language: str, code: str) -> list[tuple[TokenType, str]]:
+    """Return (type, value) pairs, skipping whitespace."""
+    tokens = get_lexer(language).tokenize(code)
+    return [(t.type, t.value) for t in tokens if t.type != TokenType.WHITESPACE]
+
+
+@pytest.mark.parametrize("language", C_FAMILY)
+class TestDirectives:
+    """Test directive tokenization across the C family."""
+
+    def test_include_targets(self, language: str) -> None:
+        """Include targets are COMMENT_PREPROCFILE; trailing comments stay comments."""
+        code = '#include <stdio.h>\n#  include "local.h" // why\n'
+        assert _tokens(language, code) == [
+            (TokenType.COMMENT_PREPROC, "#include"),
+            (TokenType.COMMENT_PREPROCFILE, "<stdio.h>"),
+            (TokenType.COMMENT_PREPROC, "#  include"),
+            (TokenType.COMMENT_PREPROCFILE, '"local.h"'),
+            (TokenType.COMMENT_SINGLE, "// why"),
+        ]
+
+    def test_define_macro_name(self, language: str) -> None:
+        """Macro names are NAME_FUNCTION_MAGIC and the body is lexed as code."""
+        tokens = _tokens(language, "#define SQUARE(x) ((x) * (x))\nint y;")
+        assert tokens[:2] == [
+            (TokenType.COMMENT_PREPROC, "#define"),
+            (TokenType.NAME_FUNCTION_MAGIC, "SQUARE"),
+        ]
+        assert (TokenType.OPERATOR, "*") in tokens
+        assert (TokenType.KEYWORD_TYPE, "int") in tokens
+
+    @pytest.mark.parametrize("directive", ["ifdef", "ifndef", "undef"])
+    def test_conditional_macro_name(self, language: str, directive: str) -> None:
+        """#ifdef, #ifndef and #undef name a macro."""
+        assert _tokens(language, f"#{directive} DEBUG")[1] == (
+            TokenType.NAME_FUNCTION_MAGIC,
+            "DEBUG",
+        )
+
+    def test_stringize_and_paste(self, language: str) -> None:
+        """# and ## inside a macro body are operators."""
+        tokens = _tokens(language, "#define CAT(a, b) a##b #a")
+        assert (TokenType.OPERATOR, "##") in tokens
+        assert (TokenType.OPERATOR, "#") in tokens
+
+    def test_line_continuation(self, language: str) -> None:
+        """A backslash-newline continues the directive onto the next line."""
+        code = "#define MAX(a, b) \\\n    ((a) > (b) ? (a) : (b))\n#x\n"
+        tokens = list(get_lexer(language).tokenize(code))
+        continuation = next(t for t in tokens if t.value == "\\\n")
+        assert continuation.type == TokenType.WHITESPACE
+        # The continued line is still macro body, so the next real line is a directive
+        assert tokens[-2].type == TokenType.COMMENT_PREPROC
+        assert (tokens[-2].line, tokens[-2].column) == (3, 1)
+
+    def test_other_directives(self, language: str) -> None:
+        """Other directives keep their text as COMMENT_PREPROC up to a comment."""
+        assert _tokens(language, '#error "a // b" /* c */') == [
+            (TokenType.COMMENT_PREPROC, "#error"),
+            (TokenType.COMMENT_PREPROC, '"a // b"'),
+            (TokenType.COMMENT_MULTILINE, "/* c */"),
+        ]
+
+    def test_if_zero_block(self, language: str) -> None:
+        """#if 0 regions are comments; the #else branch is live code."""
+        code = "#if 0\nint a;\n#if X\nb();\n#endif\n#else\nint c;\n#endif\n"
+        assert _tokens(language, code) == [
+            (TokenType.COMMENT_PREPROC, "#if"),
+            (TokenType.COMMENT_PREPROC, "0"),
+            (TokenType.COMMENT, "int a;\n#if X\nb();\n#endif"),
+            (TokenType.COMMENT_PREPROC, "#else"),
+            (TokenType.KEYWORD_TYPE, "int"),
+            (TokenType.NAME, "c"),
+            (TokenType.PUNCTUATION, ";"),
+            (TokenType.COMMENT_PREPROC, "#endif"),
+        ]
+
+    def test_unterminated_if_zero(self, language: str) -> None:
+        """An unterminated #if 0 comments out the rest of the input."""
+        assert _tokens(language, "#if 0\nint a;\n")[-1] == (TokenType.COMMENT, "int a;")
+
+    def test_if_nonzero_is_not_disabled(self, language: str) -> None:
+        """Only a literal 0 disables a region."""
+        tokens = _tokens(language, "#if 00 || X\nint a;\n#endif\n")
+        assert TokenType.COMMENT not in {kind for kind, _ in tokens}
+
+
+class TestRawStrings:
+    """Test C++ raw string literals."""
+
+    @pytest.mark.parametrize("language", ["cpp", "cuda"])
+    @pytest.mark.parametrize(
+        "literal",
+        ['R"(a\\n"b)"', 'R"sql(x)" y)sql"', 'u8R"(z)"', 'LR"--(w)--"'],
+    )
+    def test_raw_string(self, language: str, literal: str) -> None:
+        """Raw strings are a single STRING token without escapes."""
+        assert _tokens(language, f"auto s = {literal};")[3] == (TokenType.STRING, literal)
+
+    def test_identifier_ending_in_r(self) -> None:
+        """An identifier ending in R before a string is not a raw string prefix."""
+        assert _tokens("cpp", 'FOOR"(x)"')[0] == (TokenType.NAME, "FOOR")
+
+    def test_multiline_positions(self) -> None:
+        """Tokens after a multi-line raw string report the right line."""
+        tokens = list(get_lexer("cpp").tokenize('auto s = R"(\na\n)";\nint x;'))
+        raw = next(t for t in tokens if t.type == TokenType.STRING)
+        keyword = next(t for t in tokens if t.value == "int")
+        assert (raw.line, raw.column) == (1, 10)
+        assert (keyword.line, keyword.column) == (4, 1)
+
+
+@pytest.mark.parametrize("language", C_FAMILY)
+def test_round_trip(language: str) -> None:
+    """Concatenated values reproduce the input with consistent positions."""
+    code = (
+        "#include <a.h>\n#define F(x) \\\n  x##1\n#if 0\n  /* x */\n#endif\n"
+        "#pragma omp \\\n  parallel\nint y;\n"
+    )
+    tokens = list(get_lexer(language).tokenize(code))
+    assert "".join(t.value for t in tokens) == code
+    lines = code.split("\n")
+    for token in tokens:
+        first_line = token.value.split("\n")[0]
+        assert lines[token.line - 1][token.column - 1 :].startswith(first_line), token
@@ -0,0 +1,103 @@
+"""Tests for the column-aware CSV lexer and dialect sniffing."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, get_lexer
+from rosettes.lexers.csv_sm import CsvStateMachineLexer, has_header, sniff_delimiter
+
+COLUMN_TYPES = CsvStateMachineLexer.COLUMN_TYPES
+
+
+def _tokens(code: str) -> list[tuple[TokenType, str]]:
+    """Return (type, value) pairs, skipping whitespace."""
+    tokens = get_lexer("csv").tokenize(code)
+    return [(t.type, t.value) for t in tokens if t.type != TokenType.WHITESPACE]
+
+
+class TestSniffing:
+    """Test delimiter and header detection."""
+
+    @pytest.mark.parametrize(
+        ("code", "delimiter"),
+        [
+            ("a,b,c\n1,2,3\n", ","),
+            ("a;b;c\n1;2,5;3\n", ";"),
+            ("a\tb\n1\t2\n", "\t"),
+            ("a|b|c\n1|2|3\n", "|"),
+            ('"x,y";z\n"1,2";3\n', ";"),
+            ("single column\n", ","),
+        ],
+    )
+    def test_delimiter(self, code: str, delimiter: str) -> None:
+        """The most consistent delimiter wins; quoted text is ignored."""
+        assert sniff_delimiter(code) == delimiter
+
+    @pytest.mark.parametrize(
+        ("code", "expected"),
+        [
+            ("name,age\nAlice,30\n", True),
+            ('"name","city"\nAlice,Paris\n', True),
+            ("1,2\n3,4\n", False),
+            ("a,a\n1,2\n", False),
+            ("a,,c\n1,2,3\n", False),
+            ("name,age\n", False),
+        ],
+    )
+    def test_header(self, code: str, expected: bool) -> None:
+        """A header is distinct, non-empty, non-numeric and followed by data."""
+        assert has_header(code, ",") is expected
+
+
+class TestColumns:
+    """Test column-indexed token types."""
+
+    def test_header_and_rainbow(self) -> None:
+        """Header cells are GENERIC_HEADING; data cells are typed by column."""
+        assert _tokens("a,b\n1,x\n") == [
+            (TokenType.GENERIC_HEADING, "a"),
+            (TokenType.PUNCTUATION, ","),
+            (TokenType.GENERIC_HEADING, "b"),
+            (COLUMN_TYPES[0], "1"),
+            (TokenType.PUNCTUATION, ","),
+            (COLUMN_TYPES[1], "x"),
+        ]
+
+    def test_columns_cycle(self) -> None:
+        """Columns past the palette wrap around."""
+        count = len(COLUMN_TYPES) + 1
+        row = ",".join(str(i) for i in range(count))
+        values = [kind for kind, _ in _tokens(f"{row}\n{row}\n") if kind != TokenType.PUNCTUATION]
+        assert values[-1] == COLUMN_TYPES[0]
+
+    def test_only_sniffed_delimiter_splits(self) -> None:
+        """Other delimiter characters stay inside the field."""
+        assert _tokens("1;2,5;a\n3;4,0;b\n")[2] == (COLUMN_TYPES[1], "2,5")
+
+    def test_quoted_field_spanning_lines(self) -> None:
+        """A quoted newline doesn't start a new record."""
+        code = '1,"a\n""b""",x\n2,y,z\n'
+        tokens = list(get_lexer("csv").tokenize(code))
+        quoted = next(t for t in tokens if t.value.startswith('"'))
+        after = next(t for t in tokens if t.value == "x")
+        assert (quoted.type, quoted.line, quoted.column) == (COLUMN_TYPES[1], 1, 3)
+        assert (after.type, after.line, after.column) == (COLUMN_TYPES[2], 2, 8)
+
+    def test_blank_line_resets_columns(self) -> None:
+        """Each record starts at column zero."""
+        assert _tokens("1,2\n\n3,4\n")[3] == (COLUMN_TYPES[0], "3")
+
+
+@pytest.mark.parametrize(
+    "code",
+    ["a;b\r\n1; 2 \r\n", 'x,"y\nz"\n1,2', "\n\n1|2|3\n|\n"],
+)
+def test_round_trip(code: str) -> None:
+    """Concatenated values reproduce the input with consistent positions."""
+    tokens = list(get_lexer("csv").tokenize(code))
+    assert "".join(t.value for t in tokens) == code
+    lines = code.replace("\r\n", "\n").split("\n")
+    for token in tokens:
+        first_line = token.value.replace("\r\n", "\n").split("\n")[0]
+        assert lines[token.line - 1][token.column - 1 :].startswith(first_line), token
@@ -0,0 +1,62 @@
+"""Tests for hunk tracking in the diff lexer.
+
+Header, binary, combined, word-diff and stat output are covered by the
+fixtures in tests/fixtures/diff.
+"""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, get_lexer
+
+
+def _tokens(code: str) -> list[tuple[TokenType, str]]:
+    """Return (type, value) pairs, skipping whitespace."""
+    tokens = get_lexer("diff").tokenize(code)
+    return [(t.type, t.value) for t in tokens if t.type != TokenType.WHITESPACE]
+
+
+class TestHunks:
+    """Test line classification driven by hunk header counts."""
+
+    def test_dashes_inside_hunk_are_deletions(self) -> None:
+        """`--- x` inside a hunk is a removed line, not a file header."""
+        code = "@@ -1,2 +1 @@\n--- x\n-y\n+z\n--- a/next\n"
+        assert _tokens(code)[1:] == [
+            (TokenType.GENERIC_DELETED, "--- x"),
+            (TokenType.GENERIC_DELETED, "-y"),
+            (TokenType.GENERIC_INSERTED, "+z"),
+            (TokenType.GENERIC_HEADING, "--- a/next"),
+        ]
+
+    def test_no_newline_marker_after_hunk(self) -> None:
+        """The marker after a hunk's last line is COMMENT_SPECIAL."""
+        code = "@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+a\n"
+        assert _tokens(code)[2] == (TokenType.COMMENT_SPECIAL, "\\ No newline at end of file")
+        assert _tokens(code)[3] == (TokenType.GENERIC_INSERTED, "+a")
+
+    def test_combined_trailing_deletions(self) -> None:
+        """Combined hunks keep deletions that follow the last result line."""
+        code = "@@@ -1,2 -1,1 +1 @@@\n  a\n- b\n--- a/next\n"
+        assert [kind for kind, _ in _tokens(code)][1:] == [
+            TokenType.TEXT,
+            TokenType.GENERIC_DELETED,
+            TokenType.GENERIC_HEADING,
+        ]
+
+    @pytest.mark.parametrize(
+        ("line", "token_type"),
+        [("+x = a[-1]", TokenType.GENERIC_INSERTED), (" y = {+1}", TokenType.TEXT)],
+    )
+    def test_brackets_do_not_enable_word_diff(self, line: str, token_type: TokenType) -> None:
+        """Incomplete markers in ordinary lines don't switch to word-diff mode."""
+        assert _tokens(f"@@ -2 +2 @@\n{line}\n-z\n+w\n")[1:] == [
+            (token_type, line),
+            (TokenType.GENERIC_DELETED, "-z"),
+            (TokenType.GENERIC_INSERTED, "+w"),
+        ]
+
+    def test_unparsed_hunk_header(self) -> None:
+        """Without ranges, lines are classified by their first characters."""
+        assert _tokens("@@ @@\n--- a/x\n")[1] == (TokenType.GENERIC_HEADING, "--- a/x")
@@ -0,0 +1,77 @@
+"""Tests for file-type roles in the directory tree lexer.
+
+Guide styles, metadata, symlinks and the summary line are covered by the
+fixtures in tests/fixtures/tree-listing.
+"""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, get_lexer
+
+
+def _names(code: str) -> dict[str, TokenType]:
+    """Map each entry name to its token type."""
+    skip = (TokenType.WHITESPACE, TokenType.PUNCTUATION, TokenType.COMMENT)
+    tokens = get_lexer("tree-listing").tokenize(code)
+    return {t.value: t.type for t in tokens if t.type not in skip}
+
+
+class TestRoles:
+    """Test entry typing by detected language."""
+
+    @pytest.mark.parametrize(
+        ("name", "token_type"),
+        [
+            ("main.py", TokenType.NAME_FUNCTION),
+            ("lib.rs", TokenType.NAME_FUNCTION),
+            ("pyproject.toml", TokenType.NAME_ATTRIBUTE),
+            ("Dockerfile", TokenType.NAME_ATTRIBUTE),
+            ("README.md", TokenType.STRING_DOC),
+            ("LICENSE", TokenType.STRING_DOC),
+            ("Authors.rst", TokenType.STRING_DOC),
+            ("noticeboard.py", TokenType.NAME_FUNCTION),
+            ("authors_test.go", TokenType.NAME_FUNCTION),
+            ("CHANGELOG_parser.py", TokenType.NAME_FUNCTION),
+            ("README_gen.sh", TokenType.NAME_FUNCTION),
+            ("notes.txt", TokenType.STRING_DOC),
+            ("image.png", TokenType.TEXT),
+            ("build.sh*", TokenType.NAME_FUNCTION),
+            ("assets/", TokenType.NAME_NAMESPACE),
+        ],
+    )
+    def test_file_roles(self, name: str, token_type: TokenType) -> None:
+        """Files are typed as source, config, docs or plain entries."""
+        assert _names(f".\n└── {name}\n")[name] == token_type
+
+    def test_directory_detected_from_children(self) -> None:
+        """An entry without a slash is a directory if deeper entries follow."""
+        code = "root\n├── src\n│   └── app.py\n└── Makefile\n"
+        names = _names(code)
+        assert names["root"] == TokenType.NAME_NAMESPACE
+        assert names["src"] == TokenType.NAME_NAMESPACE
+        assert names["Makefile"] == TokenType.NAME_ATTRIBUTE
+
+    def test_non_breaking_space_guides(self) -> None:
+        """Guides written with non-breaking spaces are still recognized."""
+        code = ".\n├──\xa0main.go\n└──\xa0go.mod\n"
+        tokens = list(get_lexer("tree-listing").tokenize(code))
+        assert tokens[2] == (TokenType.PUNCTUATION, "├──\xa0", 2, 1)
+        assert tokens[3].value == "main.go"
+
+
+@pytest.mark.parametrize(
+    "code",
+    [
+        ".\n├── a\n\n└── b",
+        "x\r\n└── y.py\r\n",
+        "x\r\r\n└── y\r\r",
+        "   \n",
+        "├── -> \n",
+        "[oops\n",
+    ],
+)
+def test_round_trip(code: str) -> None:
+    """Token values reassemble the input, including odd lines."""
+    assert "".join(t.value for t in get_lexer("tree-listing").tokenize(code)) == code
@@ -40,7 +40,8 @@ def discover_fixtures():
                 ".py", ".js", ".ts", ".rs", ".go", ".java", ".kt", ".swift",
                 ".rb", ".pl", ".lua", ".scala", ".ex", ".hs", ".nim", ".zig",
                 ".v", ".dart", ".gleam", ".yaml", ".json", ".php", ".sh",
-                ".sql", ".toml", ".xml", ".html", ".css", ".md", ".kida",
+                ".sql", ".toml", ".xml", ".html", ".css", ".scss", ".sass",
+                ".less", ".styl", ".md", ".kida",
                 ".c", ".cpp", ".h", ".hpp", ".dockerfile", ".graphql", ".tf",
                 ".groovy", ".r", ".jl", ".ini", ".csv", ".diff", ".makefile",
                 ".nginx", ".proto", ".mojo", ".triton", ".cu", ".stan", ".pkl",
@@ -0,0 +1,165 @@
+"""Tests for GitHub-flavored Markdown extensions in the Markdown lexer."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, get_lexer
+
+
+def _tokens(code: str) -> list[tuple[TokenType, str]]:
+    """Return (type, value) pairs, skipping whitespace."""
+    tokens = get_lexer("markdown").tokenize(code)
+    return [(t.type, t.value) for t in tokens if t.type != TokenType.WHITESPACE]
+
+
+def _values(code: str, token_type: TokenType) -> list[str]:
+    """Return the values of tokens of one type."""
+    return [value for kind, value in _tokens(code) if kind == token_type]
+
+
+class TestTables:
+    """Test GFM tables."""
+
+    def test_header_delimiter_body(self) -> None:
+        """Header cells are strong, the delimiter row is punctuation."""
+        code = "| a | b |\n| :-- | --: |\n| 1 | 2 |\n"
+        tokens = _tokens(code)
+        assert (TokenType.GENERIC_STRONG, "a") in tokens
+        assert (TokenType.GENERIC_STRONG, "b") in tokens
+        assert (TokenType.PUNCTUATION, "| :-- | --: |") in tokens
+        assert _values(code, TokenType.PUNCTUATION).count("|") == 6
+
+    def test_inline_markup_in_cells(self) -> None:
+        """Body cells keep inline tokenization."""
+        code = "a | b\n--- | ---\n`x` | [y](z)\n"
+        tokens = _tokens(code)
+        assert (TokenType.STRING, "`x`") in tokens
+        assert (TokenType.NAME_LABEL, "[y](z)") in tokens
+
+    def test_escaped_pipe(self) -> None:
+        """An escaped pipe does not split a cell."""
+        code = "| a |\n| - |\n| x \\| y |\n"
+        assert _values(code, TokenType.PUNCTUATION).count("|") == 4
+
+    def test_table_ends_at_blank_line(self) -> None:
+        """Pipes after the table are plain text."""
+        code = "| a |\n| - |\n| 1 |\n\nx | y\n"
+        assert _values(code, TokenType.PUNCTUATION).count("|") == 4
+
+    def test_pipe_without_delimiter_is_text(self) -> None:
+        """A pipe in a paragraph does not start a table."""
+        assert _values("a | b\nnext line\n", TokenType.PUNCTUATION) == []
+
+    def test_mismatched_delimiter_is_not_table(self) -> None:
+        """The delimiter row must have as many cells as the header."""
+        assert _values("| a | b |\n| --- |\n", TokenType.GENERIC_STRONG) == []
+
+
+class TestTaskLists:
+    """Test GFM task list items."""
+
+    @pytest.mark.parametrize(
+        ("code", "box"),
+        [("- [ ] todo", "[ ]"), ("* [x] done", "[x]"), ("1. [X] done", "[X]")],
+    )
+    def test_checkbox(self, code: str, box: str) -> None:
+        """Checkboxes after a list marker are KEYWORD_CONSTANT."""
+        assert _values(code, TokenType.KEYWORD_CONSTANT) == [box]
+
+    def test_link_is_not_checkbox(self) -> None:
+        """Brackets with other content are not checkboxes."""
+        assert _values("- [a](b)", TokenType.KEYWORD_CONSTANT) == []
+
+
+class TestFootnotes:
+    """Test footnote references and definitions."""
+
+    def test_reference(self) -> None:
+        """[^label] is a footnote reference."""
+        assert _values("Text[^1] here.", TokenType.NAME_DECORATOR) == ["[^1]"]
+
+    def test_definition(self) -> None:
+        """[^label]: starts a footnote definition."""
+        tokens = _tokens("[^note]: The note.")
+        assert tokens[:2] == [
+            (TokenType.NAME_DECORATOR, "[^note]"),
+            (TokenType.PUNCTUATION, ":"),
+        ]
+
+
+class TestAutolinks:
+    """Test angle-bracket and bare autolinks."""
+
+    @pytest.mark.parametrize(
+        ("code", "link"),
+        [
+            ("See <https://example.com>.", "<https://example.com>"),
+            ("Mail <me@example.com>.", "<me@example.com>"),
+            ("See https://example.com/a.", "https://example.com/a"),
+            ("See www.example.com, ok", "www.example.com"),
+            ("(https://example.com/x_(y))", "https://example.com/x_(y)"),
+        ],
+    )
+    def test_autolink(self, code: str, link: str) -> None:
+        """Autolinks are NAME_LABEL without trailing punctuation."""
+        assert _values(code, TokenType.NAME_LABEL) == [link]
+
+    def test_word_containing_http_is_text(self) -> None:
+        """Prefixes inside a word are not autolinks."""
+        assert _values("xhttps://example.com", TokenType.NAME_LABEL) == []
+
+
+class TestEmbedded:
+    """Test HTML blocks and frontmatter delegated to other lexers."""
+
+    def test_html_block(self) -> None:
+        """HTML blocks are tokenized by the HTML lexer until a blank line."""
+        code = '<div class="x">\n  *not emphasis*\n</div>\n\n*emphasis*\n'
+        tokens = _tokens(code)
+        assert (TokenType.NAME_TAG, "div") in tokens
+        assert (TokenType.NAME_ATTRIBUTE, "class") in tokens
+        assert _values(code, TokenType.GENERIC_EMPH) == ["*", "*"]
+
+    def test_html_comment_spans_blank_lines(self) -> None:
+        """HTML comments run to --> even across blank lines."""
+        code = "<!-- a\n\nb -->\ntext\n"
+        assert _values(code, TokenType.COMMENT_MULTILINE) == ["<!-- a\n\nb -->"]
+
+    def test_yaml_frontmatter(self) -> None:
+        """YAML frontmatter is tokenized by the YAML lexer."""
+        code = "---\ntitle: Hi\n---\n# Heading\n"
+        tokens = _tokens(code)
+        assert tokens[0] == (TokenType.PUNCTUATION, "---")
+        assert (TokenType.NAME_ATTRIBUTE, "title") in tokens
+        assert (TokenType.GENERIC_HEADING, "# Heading") in tokens
+
+    def test_toml_frontmatter(self) -> None:
+        """TOML frontmatter is tokenized by the TOML lexer."""
+        code = '+++\ntitle = "Hi"\n+++\n'
+        assert (TokenType.STRING, '"Hi"') in _tokens(code)
+
+    def test_frontmatter_must_open_document(self) -> None:
+        """A --- later in the document is a horizontal rule."""
+        code = "Text\n\n---\ntitle: Hi\n---\n"
+        assert (TokenType.NAME_ATTRIBUTE, "title") not in _tokens(code)
+
+    def test_unterminated_frontmatter(self) -> None:
+        """Frontmatter without a closing fence is not delegated."""
+        assert (TokenType.NAME_ATTRIBUTE, "title") not in _tokens("---\ntitle: Hi\n")
+
+    @pytest.mark.parametrize(
+        "code",
+        [
+            "---\na: 1\nb: [x, y]\n---\n\n<p>\n  <b>hi</b>\n</p>\n",
+            "Text\n\n  <div id=a\n   class=b>\n  x\n  </div>\n",
+        ],
+    )
+    def test_positions(self, code: str) -> None:
+        """Delegated tokens report positions in the Markdown document."""
+        tokens = list(get_lexer("markdown").tokenize(code))
+        assert "".join(t.value for t in tokens) == code
+        lines = code.split("\n")
+        for token in tokens:
+            first_line = token.value.split("\n")[0]
+            assert lines[token.line - 1][token.column - 1 :].startswith(first_line), token
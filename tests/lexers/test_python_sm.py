@@ -99,14 +99,11 @@ class TestPythonStrings:
         assert len(tokens) > 0
 
     def test_escape_sequences(self, python_lexer) -> None:
-        """Escape sequences should be included in STRING token."""
+        """Escape sequences should be STRING_ESCAPE tokens."""
         code = '"\\n\\t\\r"'
         tokens = list(python_lexer.tokenize(code))
-        # Escape sequences are part of the string token, not separate tokens
-        string_tokens = [t for t in tokens if t.type == TokenType.STRING]
-        assert len(string_tokens) > 0
-        # Verify escape sequences are in the string value
-        assert "\\n" in string_tokens[0].value or "\n" in string_tokens[0].value
+        escape_tokens = [t for t in tokens if t.type == TokenType.STRING_ESCAPE]
+        assert [t.value for t in escape_tokens] == ["\\n", "\\t", "\\r"]
 
 
 class TestPythonNumbers:
@@ -0,0 +1,216 @@
+"""Tests for the SCSS, Sass, Less and Stylus lexers (and the shared CSS scanner)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import LexerConfig, TokenType, get_lexer
+
+
+def _tokens(language: str, code: str) -> list[tuple[TokenType, str]]:
+    """Return (type, value) pairs, skipping whitespace."""
+    tokens = get_lexer(language).tokenize(code)
+    return [(t.type, t.value) for t in tokens if t.type != TokenType.WHITESPACE]
+
+
+def _values(language: str, code: str, token_type: TokenType) -> list[str]:
+    """Return the values of tokens of one type."""
+    return [value for kind, value in _tokens(language, code) if kind == token_type]
+
+
+class TestRegistry:
+    """Test lexer lookup."""
+
+    @pytest.mark.parametrize(
+        ("alias", "name"),
+        [
+            ("scss", "scss"),
+            ("sass", "sass"),
+            ("less", "less"),
+            ("stylus", "stylus"),
+            ("styl", "stylus"),
+        ],
+    )
+    def test_lookup(self, alias: str, name: str) -> None:
+        """Each dialect has its own lexer."""
+        assert get_lexer(alias).name == name
+
+
+class TestScss:
+    """Test SCSS-specific constructs."""
+
+    def test_variables_and_flags(self) -> None:
+        """$variables are NAME_VARIABLE; !default is a keyword."""
+        tokens = _tokens("scss", "$gap: 4px !default;")
+        assert tokens[0] == (TokenType.NAME_VARIABLE, "$gap")
+        assert (TokenType.KEYWORD, "!default") in tokens
+
+    def test_mixins(self) -> None:
+        """@mixin and @include name a mixin."""
+        code = "@mixin pad($n) { padding: $n; }\n.a { @include pad(2px); }"
+        assert _values("scss", code, TokenType.NAME_FUNCTION) == ["pad", "pad"]
+
+    def test_interpolation(self) -> None:
+        """#{} delimiters are STRING_INTERPOL, with the expression tokenized inside."""
+        tokens = _tokens("scss", ".icon-#{$name} { }")
+        assert tokens[:4] == [
+            (TokenType.NAME_CLASS, ".icon-"),
+            (TokenType.STRING_INTERPOL, "#{"),
+            (TokenType.NAME_VARIABLE, "$name"),
+            (TokenType.STRING_INTERPOL, "}"),
+        ]
+
+    def test_interpolation_in_string(self) -> None:
+        """Interpolation inside strings is split out of the string."""
+        assert _tokens("scss", 'a { content: "x-#{$y}"; }')[4:7] == [
+            (TokenType.STRING, '"x-'),
+            (TokenType.STRING_INTERPOL, "#{$y}"),
+            (TokenType.STRING, '"'),
+        ]
+
+    def test_line_comment(self) -> None:
+        """// starts a comment, but not inside url()."""
+        code = "// note\na { background: url(//cdn.example.com/x.png); }"
+        assert _values("scss", code, TokenType.COMMENT_SINGLE) == ["// note"]
+        assert (TokenType.STRING, "//cdn.example.com/x.png") in _tokens("scss", code)
+
+    def test_map(self) -> None:
+        """Map keys are attributes."""
+        code = "$bp: (small: 576px, large: 992px);"
+        assert _values("scss", code, TokenType.NAME_ATTRIBUTE) == ["small", "large"]
+
+    def test_control_flow(self) -> None:
+        """Control words are keywords only inside conditions."""
+        code = "@each $k in $list { a { background: linear-gradient(to right, red, blue); } }"
+        keywords = _values("scss", code, TokenType.KEYWORD)
+        assert keywords == ["@each", "in"]
+
+    def test_placeholder_and_parent(self) -> None:
+        """%placeholders are selectors; & is the parent selector."""
+        tokens = _tokens("scss", "%base { &:hover { } }")
+        assert tokens[0] == (TokenType.NAME_CLASS, "%base")
+        assert (TokenType.NAME_BUILTIN_PSEUDO, "&") in tokens
+        assert (TokenType.NAME_DECORATOR, ":hover") in tokens
+
+    def test_module_function(self) -> None:
+        """math.div() marks the namespace and function."""
+        tokens = _tokens("scss", "a { width: math.div($w, 2); }")
+        assert tokens[4:7] == [
+            (TokenType.NAME_NAMESPACE, "math"),
+            (TokenType.PUNCTUATION, "."),
+            (TokenType.NAME_FUNCTION, "div"),
+        ]
+
+
+class TestSelectorsVsDeclarations:
+    """Test the property/pseudo-class decision shared by all dialects."""
+
+    @pytest.mark.parametrize("language", ["css", "scss", "less"])
+    def test_pseudo_class_selector(self, language: str) -> None:
+        """tag:hover before { is a selector, not a property."""
+        tokens = _tokens(language, "a:hover { color:red; }")
+        assert tokens[:2] == [(TokenType.NAME, "a"), (TokenType.NAME_DECORATOR, ":hover")]
+        assert (TokenType.NAME_ATTRIBUTE, "color") in tokens
+        assert (TokenType.NAME, "red") in tokens
+
+    def test_sass_indentation_decides(self) -> None:
+        """In Sass, a line with indented children is a selector."""
+        code = "nav\n  a:hover\n    color:red\n"
+        tokens = _tokens("sass", code)
+        assert (TokenType.NAME_DECORATOR, ":hover") in tokens
+        assert (TokenType.NAME_ATTRIBUTE, "color") in tokens
+
+    @pytest.mark.parametrize(("tab_size", "selector"), [(4, True), (8, False)])
+    def test_tab_size(self, tab_size: int, selector: bool) -> None:
+        """Tabs in indentation count as LexerConfig.tab_size columns."""
+        code = "nav\n\ta:hover\n      color:red\n"
+        tokens = get_lexer("sass").tokenize(code, LexerConfig(tab_size=tab_size))
+        pseudo = (TokenType.NAME_DECORATOR, ":hover")
+        assert (pseudo in [(t.type, t.value) for t in tokens]) == selector
+
+
+class TestSass:
+    """Test the indented syntax."""
+
+    def test_mixin_shorthands(self) -> None:
+        """=name defines and +name includes a mixin."""
+        code = "=pad($n)\n  padding: $n\n.a\n  +pad(2px)\n"
+        assert _values("sass", code, TokenType.NAME_FUNCTION) == ["pad", "pad"]
+
+    def test_newline_ends_condition(self) -> None:
+        """Condition keywords end with the line."""
+        code = "@if $a and $b\n  a\n    margin: to\n"
+        assert _values("sass", code, TokenType.KEYWORD) == ["@if", "and"]
+
+
+class TestLess:
+    """Test Less-specific constructs."""
+
+    def test_variables_vs_at_rules(self) -> None:
+        """@name is a variable unless it's a CSS at-rule."""
+        code = "@gap: 4px;\n@media (min-width: @bp) { a { margin: @gap; } }"
+        assert _values("less", code, TokenType.NAME_VARIABLE) == ["@gap", "@bp", "@gap"]
+        assert _values("less", code, TokenType.KEYWORD) == ["@media"]
+
+    def test_guards(self) -> None:
+        """when/and/not are keywords in guards."""
+        code = ".m(@a) when (iscolor(@a)) and not (@a = red) { }"
+        assert _values("less", code, TokenType.KEYWORD) == ["when", "and", "not"]
+        assert _values("less", code, TokenType.NAME_FUNCTION) == ["iscolor"]
+
+    def test_interpolation(self) -> None:
+        """@{name} is interpolation in selectors and strings."""
+        code = '.@{prefix}-btn { content: "@{label}"; }'
+        assert _values("less", code, TokenType.STRING_INTERPOL) == ["@{", "}", "@{label}"]
+
+    def test_mixin_call(self) -> None:
+        """Mixin calls keep the class selector type."""
+        assert _tokens("less", ".a { .bordered(2px); }")[2] == (TokenType.NAME_CLASS, ".bordered")
+
+
+class TestStylus:
+    """Test Stylus-specific constructs."""
+
+    def test_assignments(self) -> None:
+        """name = value and name ?= value assign variables."""
+        code = "gap = 4px\nbase ?= 2px\n"
+        assert _values("stylus", code, TokenType.NAME_VARIABLE) == ["gap", "base"]
+
+    def test_colonless_properties(self) -> None:
+        """Indented `name value` lines without children are properties."""
+        code = "body\n  color red\n  a span\n    margin 0\n"
+        assert _values("stylus", code, TokenType.NAME_ATTRIBUTE) == ["color", "margin"]
+
+    def test_function_body_expression(self) -> None:
+        """An expression line in a function is not a property."""
+        code = "add(a, b)\n  a + b\n"
+        assert _values("stylus", code, TokenType.NAME_ATTRIBUTE) == []
+
+    def test_keywords_and_ranges(self) -> None:
+        """Control keywords and range operators."""
+        tokens = _tokens("stylus", "for i in 1..3\n  unless x\n    y 1\n")
+        assert (TokenType.KEYWORD, "for") in tokens
+        assert (TokenType.KEYWORD, "unless") in tokens
+        assert (TokenType.OPERATOR, "..") in tokens
+
+
+class TestPositions:
+    """Tokens reconstruct the input with correct positions."""
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [
+            ("scss", '$a: 1;\n.b-#{$c} {\n  d: "#{$e}f";\n}\n'),
+            ("sass", "=m\n  a: b\n.c\n  +m\n"),
+            ("less", '@a: 1;\n.@{b} {\n  c: ~"@{d}";\n}\n'),
+            ("stylus", "a = 1\nb\n  c a\n"),
+        ],
+    )
+    def test_round_trip(self, language: str, code: str) -> None:
+        """Concatenated values reproduce the input and columns point at the values."""
+        tokens = list(get_lexer(language).tokenize(code))
+        assert "".join(t.value for t in tokens) == code
+        lines = code.split("\n")
+        for token in tokens:
+            first_line = token.value.split("\n")[0]
+            assert lines[token.line - 1][token.column - 1 :].startswith(first_line), token
@@ -0,0 +1,39 @@
+"""Tests for the rosettes command line (rosettes.cli)."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from rosettes.cli import main
+
+
+class TestTree:
+    """Test `rosettes tree`."""
+
+    def test_plain_output(self, tmp_path: Path, capsys: pytest.CaptureFixture) -> None:
+        """Without a tty, the listing is printed as plain text."""
+        (tmp_path / "keep.py").write_text("", encoding="utf-8")
+        (tmp_path / "skip.pyc").write_text("", encoding="utf-8")
+        (tmp_path / "sub").mkdir()
+        assert main(["tree", str(tmp_path), "-I", "*.pyc", "--dirs-first"]) == 0
+        assert capsys.readouterr().out.splitlines()[1:] == [
+            "├── sub/",
+            "└── keep.py",
+            "",
+            "1 directory, 1 file",
+        ]
+
+    def test_html_output(self, tmp_path: Path, capsys: pytest.CaptureFixture) -> None:
+        """-f html highlights the listing with the tree-listing lexer."""
+        (tmp_path / "a.py").write_text("", encoding="utf-8")
+        main(["tree", str(tmp_path), "-f", "html"])
+        assert 'data-language="tree-listing"' in capsys.readouterr().out
+
+    def test_not_a_directory(self, tmp_path: Path) -> None:
+        """A file path exits with status 1."""
+        (tmp_path / "a.py").write_text("", encoding="utf-8")
+        with pytest.raises(SystemExit) as exc_info:
+            main(["tree", str(tmp_path / "a.py")])
+        assert exc_info.value.code == 1
@@ -0,0 +1,187 @@
+"""Tests for project configuration (rosettes.config)."""
+
+from __future__ import annotations
+
+import sys
+from collections.abc import Iterator
+from pathlib import Path
+
+import pytest
+
+import rosettes
+from rosettes import (
+    UnknownLanguageError,
+    get_formatter,
+    get_lexer,
+    highlight,
+    supports_language,
+    themes,
+)
+from rosettes.cli import main
+from rosettes.config import RosettesConfig, configure, find_config, get_config, load_config
+from rosettes.themes import get_palette
+
+_PYPROJECT = """\
+[project]
+name = "example"
+
+[tool.rosettes]
+palette = "monokai"
+css_class_style = "pygments"
+fallback = "plaintext"
+
+[tool.rosettes.aliases]
+console = "bash"
+
+[tool.rosettes.formatters.terminal]
+wrap = true
+width = 20
+"""
+
+
+@pytest.fixture(autouse=True)
+def _reset_config() -> Iterator[None]:
+    yield
+    configure(RosettesConfig())
+
+
+class TestLoad:
+    """Test reading and finding configuration files."""
+
+    def test_pyproject(self, tmp_path: Path) -> None:
+        """[tool.rosettes] is read from pyproject.toml."""
+        path = tmp_path / "pyproject.toml"
+        path.write_text(_PYPROJECT, encoding="utf-8")
+        config = load_config(path)
+        assert config.palette == "monokai"
+        assert config.aliases == (("console", "bash"),)
+        assert config.formatters == (("terminal", (("wrap", True), ("width", 20))),)
+        assert hash(config) == hash(load_config(path))
+
+    def test_rosettes_toml(self, tmp_path: Path) -> None:
+        """rosettes.toml is read from the top level."""
+        path = tmp_path / "rosettes.toml"
+        path.write_text('palette = "dracula"\nplugins = ["json"]\n', encoding="utf-8")
+        assert load_config(path) == RosettesConfig(palette="dracula", plugins=("json",))
+
+    @pytest.mark.parametrize(
+        "text",
+        ["colour = 1", "palette = 1", 'css_class_style = "fancy"', 'plugins = "x"', "= x"],
+    )
+    def test_invalid(self, tmp_path: Path, text: str) -> None:
+        """Unknown keys, wrong types and invalid TOML raise ValueError."""
+        path = tmp_path / "rosettes.toml"
+        path.write_text(text, encoding="utf-8")
+        with pytest.raises(ValueError, match="rosettes.toml"):
+            load_config(path)
+
+    def test_find(self, tmp_path: Path) -> None:
+        """The nearest file wins; pyproject.toml needs a [tool.rosettes] table."""
+        (tmp_path / "pyproject.toml").write_text(_PYPROJECT, encoding="utf-8")
+        nested = tmp_path / "docs" / "api"
+        nested.mkdir(parents=True)
+        (tmp_path / "docs" / "pyproject.toml").write_text("[project]\n", encoding="utf-8")
+        assert find_config(nested) == tmp_path / "pyproject.toml"
+        (tmp_path / "docs" / "rosettes.toml").write_text("", encoding="utf-8")
+        assert find_config(nested) == tmp_path / "docs" / "rosettes.toml"
+
+
+class TestConfigure:
+    """Test applying a configuration."""
+
+    def test_apply(self, tmp_path: Path) -> None:
+        """Settings reach the palette, highlight(), lexer and formatter registries."""
+        path = tmp_path / "pyproject.toml"
+        path.write_text(_PYPROJECT, encoding="utf-8")
+        assert rosettes.configure(path) is get_config()
+        assert get_palette().name == "monokai"
+        assert highlight("x", "python").startswith('<div class="highlight"')
+        assert get_lexer("console") is get_lexer("bash")
+        assert supports_language("Console")
+        assert get_lexer("no-such-language").name == "plaintext"
+        assert not supports_language("no-such-language")
+        assert get_formatter("ansi").width == 20
+        assert highlight("x", "python", css_class_style="semantic").startswith(
+            '<div class="rosettes"'
+        )
+
+    def test_builtin_names_win(self) -> None:
+        """An alias can't redefine a built-in language name."""
+        configure(RosettesConfig(aliases=(("py", "bash"),)))
+        assert get_lexer("py").name == "python"
+
+    def test_explicit_fallback_wins(self) -> None:
+        """A fallback policy passed by the caller overrides the configured language."""
+        configure(RosettesConfig(fallback="text"))
+        assert get_lexer("no-such-language").name == "plaintext"
+        with pytest.raises(UnknownLanguageError):
+            get_lexer("no-such-language", fallback="raise")
+        with pytest.raises(UnknownLanguageError):
+            highlight("x", "no-such-language", fallback="raise")
+        assert get_lexer("pyhton", fallback="guess").name == "python"
+
+    def test_reset(self) -> None:
+        """The default configuration restores the built-in behavior."""
+        configure(RosettesConfig(aliases=(("console", "bash"),), fallback="text"))
+        configure(RosettesConfig())
+        with pytest.raises(LookupError):
+            get_lexer("console")
+        assert get_formatter("terminal").width is None
+
+    @pytest.mark.parametrize(
+        ("config", "error"),
+        [
+            (RosettesConfig(palette="missing"), LookupError),
+            (RosettesConfig(aliases=(("console", "missing"),)), LookupError),
+            (RosettesConfig(fallback="missing"), LookupError),
+            (RosettesConfig(formatters=(("pdf", ()),)), LookupError),
+            (RosettesConfig(formatters=(("html", (("colour", True),)),)), ValueError),
+            (RosettesConfig(plugins=("rosettes_missing_plugin",)), ImportError),
+        ],
+    )
+    def test_invalid_leaves_config(self, config: RosettesConfig, error: type) -> None:
+        """Invalid settings raise before anything is applied."""
+        configure(RosettesConfig(aliases=(("console", "bash"),)))
+        with pytest.raises(error):
+            configure(config)
+        assert get_lexer("console").name == "bash"
+        assert get_config().aliases == (("console", "bash"),)
+
+    def test_plugins_register_palettes(self, tmp_path: Path, monkeypatch) -> None:
+        """Plugins are imported before the palette is looked up."""
+        (tmp_path / "rosettes_test_plugin.py").write_text(
+            "from rosettes.themes import SyntaxPalette, register_palette\n"
+            'register_palette(SyntaxPalette("plugin-palette", "#000000", "#ffffff"))\n',
+            encoding="utf-8",
+        )
+        monkeypatch.syspath_prepend(str(tmp_path))
+        try:
+            configure(RosettesConfig(palette="plugin-palette", plugins=("rosettes_test_plugin",)))
+            assert get_palette().name == "plugin-palette"
+            assert get_palette("monokai").name == "monokai"
+        finally:
+            sys.modules.pop("rosettes_test_plugin", None)
+            themes._PALETTES.pop("plugin-palette", None)
+
+
+class TestCli:
+    """Test configuration on the command line."""
+
+    def test_invalid_config(self, tmp_path: Path) -> None:
+        """An invalid configuration exits with status 1."""
+        config = tmp_path / "rosettes.toml"
+        config.write_text('palette = "missing"\n', encoding="utf-8")
+        with pytest.raises(SystemExit) as excinfo:
+            main(["--config", str(config), "css"])
+        assert excinfo.value.code == 1
+
+    def test_css(self, tmp_path: Path, capsys: pytest.CaptureFixture) -> None:
+        """`rosettes css` prints the configured palette's stylesheet."""
+        config = tmp_path / "rosettes.toml"
+        config.write_text('palette = "dracula"\n', encoding="utf-8")
+        main(["--config", str(config), "css", "--print"])
+        css = capsys.readouterr().out
+        assert css.startswith("/* dracula")
+        assert "@media print" in css
+        main(["--no-config", "css", "--palette", "github-light", "--class-style", "pygments"])
+        assert "\n.nf {" in capsys.readouterr().out
@@ -0,0 +1,112 @@
+"""Tests for lexer instances and lexer_config in the high-level API."""
+
+from __future__ import annotations
+
+from collections.abc import Iterator
+from dataclasses import dataclass, field
+
+from rosettes import (
+    Lexer,
+    LexerConfig,
+    Token,
+    TokenType,
+    get_lexer,
+    highlight,
+    highlight_many,
+    tokenize,
+    tokenize_many,
+)
+
+
+@dataclass
+class ShoutLexer:
+    """Marks uppercase words as keywords; records the configs it was given."""
+
+    name: str = "shout"
+    aliases: tuple[str, ...] = ()
+    filenames: tuple[str, ...] = ()
+    mimetypes: tuple[str, ...] = ()
+    configs: list[LexerConfig | None] = field(default_factory=list)
+
+    def tokenize(
+        self,
+        code: str,
+        config: LexerConfig | None = None,
+        start: int = 0,
+        end: int | None = None,
+    ) -> Iterator[Token]:
+        self.configs.append(config)
+        line, column = 1, 1
+        for part in code[start:end].split(" "):
+            if column > 1 or line > 1:
+                yield Token(TokenType.WHITESPACE, " ", line, column - 1)
+            kind = TokenType.KEYWORD if part.isupper() else TokenType.TEXT
+            yield Token(kind, part, line, column)
+            if "\n" in part:
+                line += part.count("\n")
+                column = len(part) - part.rindex("\n") + 1
+            else:
+                column += len(part) + 1
+
+    def tokenize_fast(
+        self, code: str, start: int = 0, end: int | None = None
+    ) -> Iterator[tuple[TokenType, str]]:
+        for token in self.tokenize(code, start=start, end=end):
+            yield token.type, token.value
+
+
+class TestLexerInstances:
+    """Test passing lexer instances instead of names."""
+
+    def test_protocol(self) -> None:
+        """The test lexer satisfies the Lexer protocol."""
+        assert isinstance(ShoutLexer(), Lexer)
+
+    def test_highlight(self) -> None:
+        """Instances go through the fast path and use their name."""
+        html = highlight("say HELLO", ShoutLexer())
+        assert 'data-language="shout"' in html
+        assert '<span class="syntax-control">HELLO</span>' in html
+
+    def test_highlight_slow_path(self) -> None:
+        """Line highlighting and class styles work with instances."""
+        html = highlight(
+            "A b", ShoutLexer(), hl_lines={1}, show_linenos=True, css_class_style="pygments"
+        )
+        assert '<div class="highlight"' in html
+        assert '<span class="hll">' in html
+        assert '<span class="k">A</span>' in html
+
+    def test_builtin_instance(self) -> None:
+        """A registry lexer instance works like its name."""
+        lexer = get_lexer("python")
+        assert highlight("x = 1", lexer) == highlight("x = 1", "python")
+        assert tokenize("x = 1", lexer) == tokenize("x = 1", "python")
+
+    def test_many(self) -> None:
+        """highlight_many() and tokenize_many() items can carry instances."""
+        results = highlight_many([("A b", ShoutLexer()), ("x = 1", "python")])
+        assert 'data-language="shout"' in results[0]
+        assert 'data-language="python"' in results[1]
+        tokens = tokenize_many([("A b", ShoutLexer())])
+        assert tokens[0][0] == Token(TokenType.KEYWORD, "A", 1, 1)
+
+
+class TestLexerConfig:
+    """Test that lexer_config reaches the lexer."""
+
+    def test_highlight_and_tokenize(self) -> None:
+        """The config is passed on the fast path, slow path and tokenize()."""
+        config = LexerConfig(tab_size=2)
+        lexer = ShoutLexer()
+        highlight("A b", lexer, lexer_config=config)
+        highlight("A b", lexer, show_linenos=True, lexer_config=config)
+        tokenize("A b", lexer, lexer_config=config)
+        highlight_many([("A b", lexer)], lexer_config=config)
+        assert lexer.configs == [config] * 4
+
+    def test_default(self) -> None:
+        """Without lexer_config the lexer gets None."""
+        lexer = ShoutLexer()
+        tokenize("A", lexer)
+        assert lexer.configs == [None]
@@ -0,0 +1,109 @@
+"""Tests for dedent and blank-line trimming (dedent=, trim=)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import LexerConfig, dedent_code, highlight, tokenize
+from rosettes.delegate import RosettesDelegate
+
+
+class TestDedentCode:
+    """Test the pre-lexing pass."""
+
+    @pytest.mark.parametrize(
+        ("code", "expected"),
+        [
+            ("    a\n      b\n", "a\n  b\n"),
+            ("\ta\n\t\tb\n", "a\n\tb\n"),
+            ("\ta\n    b\n", "a\nb\n"),
+            ("    a\n\n    b", "a\n\nb"),
+            ("  a\nb\n", "  a\nb\n"),
+        ],
+    )
+    def test_dedent(self, code: str, expected: str) -> None:
+        """Common indentation is removed, counting tabs as columns."""
+        assert dedent_code(code, trim=False).code == expected
+
+    def test_tab_size(self) -> None:
+        """A tab wider than the common indent leaves spaces behind."""
+        block = dedent_code("\tx\n  y\n", LexerConfig(tab_size=4), trim=False)
+        assert block.code == "  x\ny\n"
+        assert block.column_offsets == (-1, 2)
+
+    def test_trim(self) -> None:
+        """Blank edges are removed and the final line break is kept."""
+        block = dedent_code("\n  \n  a\n  b\n\n \n", dedent=False)
+        assert block.code == "  a\n  b\n"
+        assert block.line_offset == 2
+
+    def test_all_blank(self) -> None:
+        """Code with only blank lines trims to nothing."""
+        assert dedent_code("\n  \n\n").code == ""
+
+    def test_range(self) -> None:
+        """start and end select the block to dedent."""
+        source = "text\n    a = 1\n    b = 2\nmore"
+        block = dedent_code(source, start=5, end=source.index("more"))
+        assert block.code == "a = 1\nb = 2\n"
+
+    def test_to_original(self) -> None:
+        """Positions map back through the trimmed lines and indent."""
+        block = dedent_code("\n\n    a\n      b\n")
+        assert block.to_original(1, 1) == (3, 5)
+        assert block.to_original(2, 3) == (4, 7)
+
+
+class TestTokenize:
+    """Test positions reported by tokenize() and the delegate."""
+
+    def test_positions_refer_to_original(self) -> None:
+        """Token positions point at the token in the original code."""
+        code = "\n    if x:\n\ty = 1\n"
+        source_lines = code.split("\n")
+        for token in tokenize(code, "python", dedent=True, trim=True):
+            if token.value.strip():
+                line = source_lines[token.line - 1]
+                assert line[token.column - 1 :].startswith(token.value)
+
+    def test_delegate(self) -> None:
+        """tokenize_range() maps positions relative to start."""
+        source = "- item\n\n  ```py\n  x = 1\n  ```\n"
+        start = source.index("  x")
+        end = source.index("  ```\n", start)
+        tokens = list(RosettesDelegate().tokenize_range(source, start, end, "py", dedent=True))
+        assert tokens[0].value == "x"
+        assert (tokens[0].line, tokens[0].column) == (1, 3)
+
+
+class TestHighlight:
+    """Test dedent and trim through highlight()."""
+
+    _CODE = "\n\n    def f():\n        return 1\n\n"
+
+    def test_output(self) -> None:
+        """The highlighted code has no indentation or blank edges."""
+        text = highlight(self._CODE, "python", "null", dedent=True, trim=True)
+        assert text == "def f():\n    return 1\n"
+
+    def test_hl_lines_use_original_lines(self) -> None:
+        """hl_lines and line numbers refer to the original lines."""
+        html = highlight(
+            self._CODE, "python", dedent=True, trim=True, hl_lines={4}, show_linenos=True
+        )
+        assert '<span class="lineno">3</span><span class="syntax-declaration">' in html
+        assert '<span class="lineno">4</span><span class="hll">' in html
+
+    def test_lexer_config_tab_size(self) -> None:
+        """lexer_config sets the tab width for dedent."""
+        code = "\tx = 1\n  y = 2\n"
+        assert highlight(code, "python", "null", dedent=True) == "  x = 1\ny = 2\n"
+        text = highlight(code, "python", "null", dedent=True, lexer_config=LexerConfig(tab_size=2))
+        assert text == "x = 1\ny = 2\n"
+        tokens = tokenize(code, "python", dedent=True, lexer_config=LexerConfig(tab_size=2))
+        assert (tokens[0].line, tokens[0].column) == (1, 2)
+
+    def test_lines_use_original_lines(self) -> None:
+        """lines ranges refer to the original lines."""
+        text = highlight(self._CODE, "python", "null", trim=True, lines=(4, 4))
+        assert text == "        return 1\n"
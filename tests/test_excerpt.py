@@ -0,0 +1,122 @@
+"""Tests for line-range excerpts (lines=...)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, highlight, tokenize
+from rosettes._excerpt import excerpt, normalize_ranges
+
+_SOURCE = '''def f():
+    """Summary.
+
+    Details that span
+    several lines.
+    """
+    x = 1
+    y = 2
+    return x + y
+
+
+z = f()
+'''
+
+
+def _text(part) -> str:
+    return "".join(t.value for t in part.tokens)
+
+
+class TestNormalizeRanges:
+    """Test range parsing."""
+
+    @pytest.mark.parametrize(
+        ("lines", "expected"),
+        [
+            ((3, 5), [(3, 5)]),
+            ([(8, 9), (1, 2)], [(1, 2), (8, 9)]),
+            ([(1, 4), (3, 6)], [(1, 6)]),
+            ([(1, 2), (3, 4)], [(1, 4)]),
+            ([(5, 5)], [(5, 5)]),
+        ],
+    )
+    def test_ranges(self, lines, expected) -> None:
+        """Ranges are sorted, and overlapping or adjacent ranges merge."""
+        assert normalize_ranges(lines) == expected
+
+    @pytest.mark.parametrize("lines", [(0, 2), (5, 4), []])
+    def test_invalid(self, lines) -> None:
+        """Empty, reversed and zero-based ranges raise ValueError."""
+        with pytest.raises(ValueError):
+            normalize_ranges(lines)
+
+
+class TestExcerpt:
+    """Test token selection."""
+
+    def test_keeps_context(self) -> None:
+        """Lines inside a docstring keep the docstring type."""
+        part = excerpt(tokenize(_SOURCE, "python"), (4, 5))
+        assert _text(part) == "    Details that span\n    several lines.\n"
+        assert {t.type for t in part.tokens} == {TokenType.STRING_DOC}
+        assert part.line_numbers == (4, 5)
+
+    def test_positions(self) -> None:
+        """Tokens are renumbered from line 1 and keep their columns."""
+        part = excerpt(tokenize(_SOURCE, "python"), (8, 9))
+        ret = next(t for t in part.tokens if t.value == "return")
+        assert (ret.line, ret.column) == (2, 5)
+
+    def test_multiple_ranges(self) -> None:
+        """Non-adjacent ranges are separated by an elision line."""
+        part = excerpt(tokenize(_SOURCE, "python"), [(1, 1), (12, 12)])
+        assert _text(part) == "def f():\n⋯\nz = f()\n"
+        assert part.line_numbers == (1, None, 12)
+        assert part.line_classes == {2: "line-elided"}
+        assert (TokenType.GENERIC_OUTPUT, "⋯", 2, 1) in part.tokens
+
+    def test_custom_and_no_elision(self) -> None:
+        """The elision text is configurable and can be turned off."""
+        tokens = tokenize(_SOURCE, "python")
+        assert "# ..." in _text(excerpt(tokens, [(1, 1), (7, 7)], elision="# ..."))
+        assert _text(excerpt(tokens, [(1, 1), (7, 7)], elision=None)) == "def f():\n    x = 1\n"
+
+    def test_range_past_end(self) -> None:
+        """A range past the last line adds no elision line."""
+        part = excerpt(tokenize(_SOURCE, "python"), [(12, 12), (40, 50)])
+        assert _text(part) == "z = f()\n"
+
+    def test_removed_lines(self) -> None:
+        """removed_lines maps token lines back to real line numbers."""
+        tokens = tokenize("a\nc\nd\n", "python")
+        part = excerpt(tokens, (3, 4), removed_lines=(2,))
+        assert _text(part) == "c\nd\n"
+        assert part.line_numbers == (3, 4)
+        assert part.source_lines == (2, 3)
+
+
+class TestHighlight:
+    """Test excerpts through highlight()."""
+
+    def test_real_line_numbers(self) -> None:
+        """Line numbers and hl_lines use the file's numbering."""
+        html = highlight(_SOURCE, "python", lines=(7, 8), hl_lines={8}, show_linenos=True)
+        assert '<span class="lineno">7</span>' in html
+        assert '<span class="lineno">8</span><span class="hll">' in html
+        assert '<span class="lineno">1</span>' not in html
+
+    def test_elision_line(self) -> None:
+        """The elision line has no number and the line-elided class."""
+        html = highlight(_SOURCE, "python", lines=[(1, 1), (12, 12)], show_linenos=True)
+        assert '<span class="lineno"></span><span class="line-elided">' in html
+
+    def test_with_notation(self) -> None:
+        """Notation marker lines don't shift real line numbers."""
+        code = "a = 1\n# [!code ++]\nb = 2\nc = 3\n"
+        html = highlight(code, "python", lines=(3, 3), notation=True, show_linenos=True)
+        assert '<span class="lineno">3</span><span class="line-added">' in html
+
+    def test_terminal(self) -> None:
+        """The terminal formatter shows real line numbers in the gutter."""
+        ansi = highlight(_SOURCE, "python", "terminal", lines=(9, 12), show_linenos=True)
+        assert ansi.startswith("\033[90m 9 │\033[0m ")
+        assert "\033[90m12 │\033[0m " in ansi
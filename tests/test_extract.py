@@ -0,0 +1,140 @@
+"""Tests for comment, docstring and string extraction (rosettes.extract)."""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from rosettes import UnknownLanguageError
+from rosettes.extract import Segment, extract
+
+FIXTURES_DIR = Path(__file__).parent / "fixtures"
+
+_FIXTURES = sorted(
+    path for path in FIXTURES_DIR.glob("*/*") if not path.name.endswith(".tokens.json")
+)
+
+
+def _texts(segments: list[Segment]) -> list[tuple[str, str]]:
+    return [(segment.kind, segment.text) for segment in segments]
+
+
+class TestMarkers:
+    """Test marker stripping across languages."""
+
+    def test_block_comment_stars(self) -> None:
+        """Block delimiters and leading stars are stripped; blank edges trimmed."""
+        code = "/**\n * Adds two numbers.\n *\n * @param a the first\n */\nint x; /* b */\n"
+        assert _texts(extract(code, "c")) == [
+            ("docstring", "Adds two numbers.\n\n@param a the first"),
+            ("comment", "b"),
+        ]
+
+    def test_doc_markup_kept(self) -> None:
+        """Markup tokens inside doc comments stay part of the text."""
+        code = "/** See {@link Foo} and `bar`. */\n@Override\nvoid f() {}\n"
+        assert _texts(extract(code, "java")) == [("docstring", "See {@link Foo} and `bar`.")]
+
+    @pytest.mark.parametrize(
+        ("code", "language", "expected"),
+        [
+            ("// note\n", "go", "note"),
+            ("/// outer doc\n", "rust", "outer doc"),
+            ("//! inner doc\n", "rust", "inner doc"),
+            ("## heading\n", "python", "heading"),
+            ("-- note\n", "sql", "note"),
+            ("<!-- note -->\n", "html", "note"),
+            ("{- note -}\n", "haskell", "note"),
+            ("--[[ long\n note ]]\n", "lua", "long\nnote"),
+            ("=begin\nnote\n=end\n", "ruby", "note"),
+            ("<?php\n# hash\n", "php", "hash"),
+        ],
+    )
+    def test_comment_markers(self, code: str, language: str, expected: str) -> None:
+        """Line and block markers come from the language catalog or common markers."""
+        assert [segment.text for segment in extract(code, language)] == [expected]
+
+    def test_docstring(self) -> None:
+        """Docstring quotes and indentation are stripped."""
+        code = 'def f():\n    """Summary.\n\n    Details here.\n    """\n'
+        assert _texts(extract(code, "python")) == [("docstring", "Summary.\n\nDetails here.")]
+
+    def test_strings(self) -> None:
+        """Strings keep their text between quotes and prefixes."""
+        code = "a = r'raw' + \"esc\\\"aped\"\n"
+        assert [s.text for s in extract(code, "python", {"string"})] == ["raw", 'esc\\"aped']
+        code = "const s = `multi\n  line`;"
+        assert [s.text for s in extract(code, "javascript", ["string"])] == ["multi\n  line"]
+        assert [s.text for s in extract('let s = r#"hash"#;', "rust", ["string"])] == ["hash"]
+
+
+class TestSegments:
+    """Test kinds, merging and positions."""
+
+    def test_kinds(self) -> None:
+        """Only the requested kinds are returned; unknown kinds raise."""
+        code = 'def f():\n    """Doc."""\n    return "s"  # c\n'
+        assert _texts(extract(code, "python")) == [("docstring", "Doc."), ("comment", "c")]
+        assert _texts(extract(code, "python", ["string"])) == [("string", "s")]
+        with pytest.raises(ValueError, match="prose"):
+            extract(code, "python", ["prose"])
+
+    @pytest.mark.parametrize(
+        ("code", "language"),
+        [
+            ('q = """SELECT name\n    FROM users"""\n', "python"),
+            ('String q = """\n    SELECT name\n    FROM users""";\n', "java"),
+        ],
+    )
+    def test_triple_quoted_strings(self, code: str, language: str) -> None:
+        """Triple-quoted strings that aren't docstrings are strings."""
+        assert extract(code, language) == []
+        (segment,) = extract(code, language, ["string"])
+        assert segment.kind == "string"
+        assert "SELECT name" in segment.text
+
+    def test_merge_line_comments(self) -> None:
+        """Full-line comments on consecutive lines form one segment."""
+        code = "# First line\n#\n# second.\nx = 1  # trailing\n# next\n\n# apart\n"
+        assert [segment.text for segment in extract(code, "python")] == [
+            "First line\n\nsecond.",
+            "trailing",
+            "next",
+            "apart",
+        ]
+
+    def test_empty_skipped(self) -> None:
+        """Comments and strings without text are skipped."""
+        assert extract('//\nx = "";\n/* */\n', "javascript", ["comment", "string"]) == []
+
+    def test_positions(self) -> None:
+        """start/end span the comment; to_original() maps text indexes."""
+        code = "int x;\n/*\n * teh value\n */\n"
+        (segment,) = extract(code, "c")
+        assert (segment.start, segment.end) == (7, len(code) - 1)
+        assert (segment.line, segment.column) == (2, 1)
+        assert segment.to_original(segment.text.index("teh")) == (code.index("teh"), 3, 4)
+        assert segment.to_original(len(segment.text)) == (code.index(" */") - 1, 3, 13)
+        with pytest.raises(IndexError):
+            segment.to_original(len(segment.text) + 1)
+
+    def test_fallback(self) -> None:
+        """Unknown languages raise unless a fallback is given."""
+        with pytest.raises(UnknownLanguageError):
+            extract("# x", "no-such-language")
+        assert extract("# x", "no-such-language", fallback="plaintext") == []
+
+    @pytest.mark.parametrize("path", _FIXTURES, ids=lambda path: f"{path.parent.name}/{path.name}")
+    def test_positions_map_back(self, path: Path) -> None:
+        """Every character of the text is at its mapped position in the source."""
+        code = path.read_text(encoding="utf-8")
+        lines = code.split("\n")
+        for segment in extract(code, path.parent.name, ["comment", "docstring", "string"]):
+            assert code[segment.start : segment.end].strip()
+            for index, char in enumerate(segment.text):
+                if char == "\n":
+                    continue
+                offset, line, column = segment.to_original(index)
+                assert code[offset] == char
+                assert lines[line - 1][column - 1] == char
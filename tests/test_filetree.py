@@ -0,0 +1,160 @@
+"""Tests for rendering directories on disk (rosettes.filetree)."""
+
+from __future__ import annotations
+
+import os
+from pathlib import Path
+
+import pytest
+
+from rosettes.filetree import parse_excludes, render_tree
+
+
+def _make(root: Path, *paths: str) -> None:
+    """Create files (and directories for paths ending in /)."""
+    for path in paths:
+        target = root / path
+        if path.endswith("/"):
+            target.mkdir(parents=True, exist_ok=True)
+        else:
+            target.parent.mkdir(parents=True, exist_ok=True)
+            target.write_text("x" * len(path), encoding="utf-8")
+
+
+def _entries(text: str) -> list[str]:
+    """Entry lines without the root, guides or summary."""
+    lines = text.splitlines()[1:]
+    return [line.split("── ", 1)[1] for line in lines if "── " in line]
+
+
+class TestRenderTree:
+    """Test the listing layout."""
+
+    def test_layout(self, tmp_path: Path) -> None:
+        """Output matches `tree -F`, with a summary line."""
+        _make(tmp_path, "src/main.py", "src/util.py", "README.md", "empty/")
+        text = render_tree(tmp_path)
+        assert text.splitlines()[1:] == [
+            "├── empty/",
+            "├── README.md",
+            "└── src/",
+            "    ├── main.py",
+            "    └── util.py",
+            "",
+            "2 directories, 3 files",
+        ]
+
+    def test_singular_summary(self, tmp_path: Path) -> None:
+        """The summary uses singular forms for one entry."""
+        _make(tmp_path, "a/b.txt")
+        assert render_tree(tmp_path).endswith("\n1 directory, 1 file")
+
+    def test_no_summary(self, tmp_path: Path) -> None:
+        """summary=False drops the summary line."""
+        _make(tmp_path, "a.txt")
+        assert render_tree(tmp_path, summary=False).splitlines()[1:] == ["└── a.txt"]
+
+    def test_max_depth(self, tmp_path: Path) -> None:
+        """max_depth limits how deep the walk goes."""
+        _make(tmp_path, "a/b/c.txt")
+        assert _entries(render_tree(tmp_path, max_depth=1)) == ["a/"]
+        assert _entries(render_tree(tmp_path, max_depth=2)) == ["a/", "b/"]
+
+    def test_hidden(self, tmp_path: Path) -> None:
+        """Dotfiles are listed only with show_hidden=True."""
+        _make(tmp_path, ".env", "app.py")
+        assert _entries(render_tree(tmp_path)) == ["app.py"]
+        assert _entries(render_tree(tmp_path, show_hidden=True)) == [".env", "app.py"]
+
+    def test_symlink(self, tmp_path: Path) -> None:
+        """Symlinks show their target and are not followed."""
+        _make(tmp_path, "real/file.txt")
+        os.symlink("real", tmp_path / "alias")
+        assert _entries(render_tree(tmp_path)) == ["alias -> real", "real/", "file.txt"]
+
+    def test_symlink_loop(self, tmp_path: Path) -> None:
+        """A symlink that can't be resolved is listed as a file."""
+        os.symlink("loop", tmp_path / "loop")
+        text = render_tree(tmp_path)
+        assert text.splitlines()[1:] == ["└── loop -> loop", "", "0 directories, 1 file"]
+
+    def test_not_a_directory(self, tmp_path: Path) -> None:
+        """A file path raises NotADirectoryError."""
+        _make(tmp_path, "a.txt")
+        with pytest.raises(NotADirectoryError):
+            render_tree(tmp_path / "a.txt")
+
+
+class TestSorting:
+    """Test entry order."""
+
+    def test_name_is_case_insensitive(self, tmp_path: Path) -> None:
+        """Name sorting ignores case."""
+        _make(tmp_path, "b.txt", "A.txt", "c.txt")
+        assert _entries(render_tree(tmp_path)) == ["A.txt", "b.txt", "c.txt"]
+
+    def test_size_largest_first(self, tmp_path: Path) -> None:
+        """Size sorting puts the largest file first."""
+        _make(tmp_path, "s.txt", "longest.txt", "mid.txt")
+        assert _entries(render_tree(tmp_path, sort="size")) == [
+            "longest.txt",
+            "mid.txt",
+            "s.txt",
+        ]
+
+    def test_mtime_newest_first(self, tmp_path: Path) -> None:
+        """mtime sorting puts the newest file first."""
+        _make(tmp_path, "old.txt", "new.txt")
+        os.utime(tmp_path / "old.txt", (1_000_000, 1_000_000))
+        os.utime(tmp_path / "new.txt", (2_000_000, 2_000_000))
+        assert _entries(render_tree(tmp_path, sort="mtime")) == ["new.txt", "old.txt"]
+
+    def test_dirs_first(self, tmp_path: Path) -> None:
+        """dirs_first lists directories before files, each group sorted."""
+        _make(tmp_path, "a.txt", "z/", "b/")
+        assert _entries(render_tree(tmp_path, dirs_first=True)) == ["b/", "z/", "a.txt"]
+
+
+class TestExcludes:
+    """Test gitignore-style excludes."""
+
+    @pytest.mark.parametrize(
+        ("patterns", "expected"),
+        [
+            (["*.pyc"], ["mod.py", "pkg/", "mod.py", "mod.txt"]),
+            (["pkg/"], ["mod.py"]),
+            (["/mod.py"], ["pkg/", "mod.py", "mod.pyc", "mod.txt"]),
+            (["pkg/*.py"], ["mod.py", "pkg/", "mod.pyc", "mod.txt"]),
+            (["**/mod.txt"], ["mod.py", "pkg/", "mod.py", "mod.pyc"]),
+            (["pkg/**"], ["mod.py", "pkg/"]),
+            (["mod.*", "!*.py"], ["mod.py", "pkg/", "mod.py"]),
+            (["# comment", "", "mod.py/"], ["mod.py", "pkg/", "mod.py", "mod.pyc", "mod.txt"]),
+        ],
+    )
+    def test_patterns(self, tmp_path: Path, patterns: list[str], expected: list[str]) -> None:
+        """Patterns follow gitignore semantics."""
+        _make(tmp_path, "pkg/mod.py", "pkg/mod.pyc", "pkg/mod.txt", "mod.py")
+        assert _entries(render_tree(tmp_path, exclude=patterns)) == expected
+
+    def test_gitignore_files(self, tmp_path: Path) -> None:
+        """.gitignore files apply to their own directory, and .git is skipped."""
+        _make(tmp_path, ".git/HEAD", "build/out.o", "src/gen.py", "src/app.py")
+        (tmp_path / ".gitignore").write_text("build/\n", encoding="utf-8")
+        (tmp_path / "src" / ".gitignore").write_text("/gen.py\n", encoding="utf-8")
+        text = render_tree(tmp_path, show_hidden=True)
+        assert _entries(text) == [".gitignore", "src/", ".gitignore", "app.py"]
+
+    def test_gitignore_disabled(self, tmp_path: Path) -> None:
+        """gitignore=False ignores .gitignore files and lists .git."""
+        _make(tmp_path, ".git/", "build/")
+        (tmp_path / ".gitignore").write_text("build/\n", encoding="utf-8")
+        text = render_tree(tmp_path, show_hidden=True, gitignore=False)
+        assert _entries(text) == [".git/", ".gitignore", "build/"]
+
+    def test_parse_excludes(self) -> None:
+        """Parsing records negation, directory-only and anchoring."""
+        rule = parse_excludes(["!/docs/build/"])[0]
+        assert rule.segments == ("docs", "build")
+        assert rule.negated
+        assert rule.directory_only
+        assert rule.anchored
@@ -0,0 +1,77 @@
+"""Tests for the theme gallery (rosettes.gallery)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes.gallery import contrast_scores, render_gallery
+from rosettes.themes import SyntaxPalette, get_palette, list_palettes
+
+_SAMPLES = [("def f():\n    return 1\n", "python"), ('{"a": 1}\n', "json")]
+
+
+class TestContrastScores:
+    """Test per-role contrast ratios."""
+
+    def test_ratios(self) -> None:
+        """Ratios are WCAG contrast against the background."""
+        palette = SyntaxPalette("bw", background="#ffffff", text="#000000", string="#777777")
+        scores = contrast_scores(palette)
+        assert scores["text"] == ("#000000", 21.0)
+        assert scores["string"] == ("#777777", 4.48)
+
+    def test_role_names(self) -> None:
+        """Roles are named without trailing underscores; empty roles use defaults."""
+        scores = contrast_scores(SyntaxPalette("bw", background="#ffffff", text="#000000"))
+        assert scores["import"] == ("#000000", 21.0)
+        assert "background_highlight" not in scores
+
+    def test_non_hex(self) -> None:
+        """Colors that aren't hex have no ratio."""
+        scores = contrast_scores(SyntaxPalette("x", background="#fff", text="rebeccapurple"))
+        assert scores["text"] == ("rebeccapurple", None)
+
+
+class TestRenderGallery:
+    """Test the gallery page."""
+
+    def test_all_palettes(self) -> None:
+        """Every registered palette gets a section and a nav link."""
+        html = render_gallery(_SAMPLES)
+        assert html.startswith("<!DOCTYPE html>")
+        for name in list_palettes():
+            assert f'<section class="palette" id="palette-{name}">' in html
+            assert f'<a href="#palette-{name}">{name}</a>' in html
+
+    def test_previews_side_by_side(self) -> None:
+        """Each variant shows every sample."""
+        html = render_gallery(_SAMPLES, ["monokai"])
+        assert html.count("<figure><figcaption>python</figcaption>") == 1
+        assert '<div class="rosettes" data-language="json">' in html
+
+    def test_scoped_css(self) -> None:
+        """Each palette's stylesheet is scoped to its section."""
+        html = render_gallery(_SAMPLES, ["monokai"])
+        assert "#gallery-monokai {\n  --syntax-bg: #272822;" in html
+        assert "#gallery-monokai .rosettes, #gallery-monokai .highlight {" in html
+        assert "\n.syntax-string {" not in html.split("</style>")[0]
+
+    def test_adaptive_variants(self) -> None:
+        """Adaptive palettes show light and dark variants."""
+        html = render_gallery(_SAMPLES, ["github"])
+        assert '<div class="variant" id="gallery-github-light"><h3>light</h3>' in html
+        assert '<div class="variant" id="gallery-github-dark"><h3>dark</h3>' in html
+
+    def test_contrast_table_and_css(self) -> None:
+        """The contrast table and copyable CSS are included."""
+        html = render_gallery(_SAMPLES, ["monokai"])
+        color, ratio = contrast_scores(get_palette("monokai"))["comment"]
+        assert "<td>comment</td>" in html
+        assert f"<code>{color}</code></td><td>{ratio:.2f}</td>" in html
+        assert "<details><summary>CSS</summary><pre><code>/* monokai - Generated" in html
+
+    @pytest.mark.parametrize(("samples", "palettes"), [([], None), (_SAMPLES, ["missing"])])
+    def test_invalid(self, samples, palettes) -> None:
+        """No samples raise ValueError; unknown palettes LookupError."""
+        with pytest.raises((ValueError, LookupError)):
+            render_gallery(samples, palettes)
@@ -0,0 +1,66 @@
+"""Tests for tabbed code groups (rosettes.groups)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes.groups import highlight_group
+from rosettes.themes import get_palette
+
+_ITEMS = [
+    ('print("hi")\n', "py", "Python"),
+    ('console.log("hi");\n', "javascript", "JavaScript"),
+    ('fmt.Println("hi")\n', "go", "Go"),
+]
+
+
+class TestHighlightGroup:
+    """Test the tab group markup."""
+
+    def test_structure(self) -> None:
+        """Each tab is an input, a label and a panel, in order."""
+        html = highlight_group(_ITEMS, group_id="hello")
+        assert html.startswith('<div class="rosettes-group" role="group">')
+        assert html.count('<input type="radio" name="hello"') == 3
+        assert (
+            '<input type="radio" name="hello" id="hello-1">'
+            '<label for="hello-1" id="hello-1-label">JavaScript</label>'
+            '<div class="rosettes-panel" data-language="javascript"'
+            ' aria-labelledby="hello-1-label"><div class="rosettes"'
+        ) in html
+
+    def test_selected(self) -> None:
+        """Only the selected tab's input is checked."""
+        html = highlight_group(_ITEMS, group_id="g", selected=2)
+        assert html.count(" checked>") == 1
+        assert 'id="g-2" checked>' in html
+
+    def test_shared_options(self) -> None:
+        """Line options apply to every block."""
+        html = highlight_group(_ITEMS, show_linenos=True, hl_lines={1})
+        assert html.count('<span class="lineno">1</span><span class="hll">') == 3
+
+    def test_default_group_id(self) -> None:
+        """The default id is stable and differs between groups."""
+        first = highlight_group(_ITEMS)
+        assert first == highlight_group(_ITEMS)
+        assert 'name="rosettes-group-' in first
+        assert first != highlight_group(_ITEMS[:2])
+
+    def test_escaping(self) -> None:
+        """Labels and group ids are escaped."""
+        html = highlight_group([("x", "python", "<b>")], group_id='a"b')
+        assert "<label for=\"a&quot;b-0\" id=\"a&quot;b-0-label\">&lt;b&gt;</label>" in html
+
+    @pytest.mark.parametrize(("items", "selected"), [([], 0), (_ITEMS, 3), (_ITEMS, -1)])
+    def test_invalid(self, items, selected: int) -> None:
+        """An empty group or out-of-range selection raises ValueError."""
+        with pytest.raises(ValueError):
+            highlight_group(items, selected=selected)
+
+    def test_palette_css(self) -> None:
+        """Generated stylesheets include tab rules with palette colors."""
+        palette = get_palette("monokai").with_defaults()
+        css = get_palette("monokai").generate_css()
+        assert ".rosettes-group > input:checked + label + .rosettes-panel {" in css
+        assert f"border-bottom-color: {palette.function};" in css
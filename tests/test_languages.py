@@ -0,0 +1,96 @@
+"""Tests for the language metadata catalog (rosettes.languages)."""
+
+from __future__ import annotations
+
+import json
+
+import pytest
+
+from rosettes import UnknownLanguageError, _registry, get_lexer, list_languages, tokenize
+from rosettes.cli import main
+from rosettes.languages import CATEGORIES, get_language_info, iter_languages, languages_json
+
+# Code that has to come before a comment for the lexer to see one
+_PREFIX = {"php": "<?php\n"}
+
+
+class TestCatalog:
+    """Test lookups and consistency with the lexers."""
+
+    def test_lookup_by_alias(self) -> None:
+        """Aliases resolve to the canonical entry."""
+        info = get_language_info("C++")
+        assert info.name == "cpp"
+        assert info.display_name == "C++"
+        assert info.category == "systems"
+        assert "cxx" in info.aliases
+        assert info.line_comment == "//"
+        assert info.block_comment == ("/*", "*/")
+
+    def test_unknown(self) -> None:
+        """Unknown names raise UnknownLanguageError."""
+        with pytest.raises(UnknownLanguageError):
+            get_language_info("cobol")
+
+    def test_every_language(self) -> None:
+        """Every language has an entry in a known category, sorted by name."""
+        infos = list(iter_languages())
+        assert [info.name for info in infos] == list_languages()
+        assert {info.category for info in infos} <= set(CATEGORIES)
+
+    def test_category_filter(self) -> None:
+        """iter_languages() can filter by category."""
+        assert [info.name for info in iter_languages(category="template")] == ["jinja", "kida"]
+        with pytest.raises(ValueError):
+            list(iter_languages(category="esoteric"))
+
+    @pytest.mark.parametrize("name", list_languages())
+    def test_matches_lexer(self, name: str) -> None:
+        """Patterns and MIME types match the lexer; comment markers lex as comments."""
+        info = get_language_info(name)
+        lexer = get_lexer(name)
+        assert info.filenames == lexer.filenames
+        assert info.mimetypes == lexer.mimetypes
+        prefix = _PREFIX.get(name, "")
+        if info.line_comment:
+            tokens = tokenize(f"{prefix}{info.line_comment} note\n", name)
+            comment = next(t for t in tokens if "note" in t.value)
+            assert comment.type.name.startswith("COMMENT")
+        if info.block_comment:
+            start, end = info.block_comment
+            tokens = tokenize(f"{prefix}{start} note {end}\n", name)
+            comment = next(t for t in tokens if "note" in t.value)
+            assert comment.type.name.startswith("COMMENT")
+            assert comment.value.rstrip("\n").endswith(end)
+
+    def test_no_lexer_imports(self, monkeypatch: pytest.MonkeyPatch) -> None:
+        """The catalog doesn't import lexer modules."""
+
+        def fail(module: str) -> None:
+            raise AssertionError(f"imported {module}")
+
+        monkeypatch.setattr(_registry, "import_module", fail)
+        assert len(list(iter_languages())) == len(list_languages())
+        assert get_language_info("rs").display_name == "Rust"
+
+
+class TestJson:
+    """Test the JSON export."""
+
+    def test_export(self) -> None:
+        """The export has the categories and one object per language."""
+        data = json.loads(languages_json())
+        assert data["categories"] == CATEGORIES
+        python = next(entry for entry in data["languages"] if entry["name"] == "python")
+        assert python["display_name"] == "Python"
+        assert python["aliases"] == ["py", "python3", "py3"]
+        assert python["block_comment"] is None
+
+    def test_cli(self, capsys: pytest.CaptureFixture) -> None:
+        """`rosettes languages` lists languages or prints the JSON export."""
+        main(["--no-config", "languages", "--category", "jvm"])
+        lines = capsys.readouterr().out.splitlines()
+        assert lines[0].split()[:2] == ["clojure", "Clojure"]
+        assert len(lines) == 5
+        main(["--no-config", "languages", "--json"])
+        assert json.loads(capsys.readouterr().out) == json.loads(languages_json())
@@ -0,0 +1,120 @@
+"""Tests for per-line data overlays (rosettes.linedata)."""
+
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+
+from rosettes import LineData, highlight
+from rosettes.linedata import coverage_line_data, gutter_cells, load_coverage_json
+from rosettes.themes import get_palette
+
+_CODE = "def f(x):\n    if x:\n        return 1\n    return 2\n"
+
+_REPORT = {
+    "meta": {"version": "7.6.1", "branch_coverage": True},
+    "files": {
+        "src/app.py": {
+            "executed_lines": [1, 2, 3],
+            "missing_lines": [4],
+            "excluded_lines": [],
+            "missing_branches": [[2, 4]],
+        },
+        "src/other/app_utils.py": {"executed_lines": [1], "missing_lines": []},
+    },
+}
+
+
+class TestLineData:
+    """Test LineData validation and gutter layout."""
+
+    @pytest.mark.parametrize("kwargs", [{"status": "skipped"}, {"intensity": 1.5}])
+    def test_invalid(self, kwargs) -> None:
+        """Unknown statuses and out-of-range intensities raise ValueError."""
+        with pytest.raises(ValueError):
+            LineData(**kwargs)
+
+    def test_gutter_cells(self) -> None:
+        """Cells are right-aligned per column; other lines get blanks."""
+        cells, blank = gutter_cells(
+            {1: LineData(columns=("3", "abc")), 2: LineData(columns=("120",)), 3: LineData()}
+        )
+        assert cells == {1: ("  3", "abc"), 2: ("120", "   ")}
+        assert blank == ("   ", "   ")
+
+
+class TestCoverage:
+    """Test the coverage.py JSON adapter."""
+
+    def test_statuses(self) -> None:
+        """Executed, missing and partially executed lines get a status."""
+        data = coverage_line_data(_REPORT, "src/app.py")
+        assert {line: d.status for line, d in data.items()} == {
+            1: "covered",
+            2: "partial",
+            3: "covered",
+            4: "missed",
+        }
+
+    def test_path_suffix(self) -> None:
+        """A file can be named by a path suffix."""
+        assert coverage_line_data(_REPORT, "app.py") == coverage_line_data(_REPORT, "src/app.py")
+
+    def test_missing_file(self) -> None:
+        """A file that isn't in the report raises LookupError."""
+        with pytest.raises(LookupError):
+            coverage_line_data(_REPORT, "missing.py")
+
+    def test_load(self, tmp_path: Path) -> None:
+        """load_coverage_json() reads the report from disk."""
+        path = tmp_path / "coverage.json"
+        path.write_text(json.dumps(_REPORT), encoding="utf-8")
+        assert load_coverage_json(path, "app.py")[4].status == "missed"
+
+
+class TestHighlight:
+    """Test line data in formatted output."""
+
+    def test_html_status_and_heat(self) -> None:
+        """Statuses become line classes and intensity a custom property."""
+        data = {1: LineData(status="covered"), 4: LineData(intensity=0.25)}
+        html = highlight(_CODE, "python", line_data=data)
+        assert '<span class="line-covered">' in html
+        assert '<span class="line-heat" style="--line-heat: 0.25">' in html
+
+    def test_html_gutter(self) -> None:
+        """Gutter cells come before the line number on every line."""
+        data = {2: LineData(columns=("12", "a1b2c3d"))}
+        html = highlight(_CODE, "python", line_data=data, show_linenos=True)
+        assert (
+            '<span class="line-gutter">12</span><span class="line-gutter">a1b2c3d</span>'
+            '<span class="lineno">2</span>'
+        ) in html
+        assert html.count('<span class="line-gutter">  </span>') == 3
+
+    def test_html_gutter_escaped(self) -> None:
+        """Gutter text is escaped."""
+        html = highlight("x\n", "python", line_data={1: LineData(columns=("<b>",))})
+        assert '<span class="line-gutter">&lt;b&gt;</span>' in html
+
+    def test_excerpt_uses_real_lines(self) -> None:
+        """With lines=, keys are the file's line numbers."""
+        html = highlight(_CODE, "python", lines=(3, 4), line_data={4: LineData(status="missed")})
+        assert html.count("line-missed") == 1
+        assert '<span class="line-missed">    <span class="syntax-control">return' in html
+
+    def test_terminal(self) -> None:
+        """The terminal shows backgrounds and gray gutter cells."""
+        data = {1: LineData(status="covered", columns=("7",)), 4: LineData(intensity=1.0)}
+        ansi = highlight(_CODE, "python", "terminal", line_data=data)
+        assert ansi.startswith("\033[90m7 \033[0m\033[48;5;22m")
+        assert "\033[48;5;160m" in ansi
+
+    def test_palette_css(self) -> None:
+        """Generated stylesheets color the overlay classes."""
+        css = get_palette("monokai").generate_css()
+        for class_name in (".line-covered", ".line-missed", ".line-partial", ".line-heat"):
+            assert f"{class_name} {{" in css
+        assert "var(--line-heat, 0)" in css
@@ -0,0 +1,67 @@
+"""Tests for per-line tokenization (tokenize_lines)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import Token, TokenType, UnknownLanguageError, tokenize, tokenize_lines
+from rosettes._spans import split_lines
+
+
+def _values(lines: list[list[Token]]) -> list[list[str]]:
+    return [[token.value for token in line] for line in lines]
+
+
+class TestTokenizeLines:
+    """Test grouping tokens by line."""
+
+    def test_multiline_tokens_split(self) -> None:
+        """Docstrings keep their type on every line, without line breaks."""
+        code = 'def f():\n    """Doc\n\n    more."""\n'
+        lines = list(tokenize_lines(code, "python"))
+        assert _values(lines)[1:] == [["    ", '"""Doc'], [], ['    more."""']]
+        assert lines[1][1] == Token(TokenType.STRING_DOC, '"""Doc', 2, 5)
+        assert lines[3][0] == Token(TokenType.STRING_DOC, '    more."""', 4, 1)
+
+    def test_positions(self) -> None:
+        """Columns and lines match the text on each line."""
+        code = "/* a\n * b */ int x;\nx = 1;"
+        for number, (line, text) in enumerate(
+            zip(tokenize_lines(code, "c"), code.split("\n"), strict=True), start=1
+        ):
+            assert "".join(token.value for token in line) == text
+            for token in line:
+                assert token.line == number
+                assert text[token.column - 1 :].startswith(token.value)
+
+    def test_matches_tokenize(self) -> None:
+        """Joined per line, the fragments are the tokenize() text."""
+        code = 'x = """a\nb"""\r\ny = 2\n'
+        lines = list(tokenize_lines(code, "python"))
+        assert ["".join(t.value for t in line) for line in lines] == ['x = """a', 'b"""', "y = 2"]
+        types = {t.type for line in lines for t in line}
+        assert types <= {t.type for t in tokenize(code, "python")}
+
+    @pytest.mark.parametrize(
+        ("code", "expected"),
+        [("", []), ("a", [["a"]]), ("a\n", [["a"]]), ("a\n\n", [["a"], []]), ("\n", [[]])],
+    )
+    def test_line_count(self, code: str, expected: list[list[str]]) -> None:
+        """A final line break doesn't start a line; blank lines are empty."""
+        assert _values(list(tokenize_lines(code, "text"))) == expected
+
+    def test_options(self) -> None:
+        """Placeholders and fallback work as in tokenize()."""
+        lines = list(tokenize_lines('printf("%d", x);', "c", format_placeholders=True))
+        assert Token(TokenType.STRING_INTERPOL, "%d", 1, 9) in lines[0]
+        with pytest.raises(UnknownLanguageError):
+            tokenize_lines("x", "no-such-language")
+        assert list(tokenize_lines("x", "no-such-language", fallback="plaintext"))
+
+    def test_pairs(self) -> None:
+        """split_lines() accepts (type, value) pairs."""
+        lines = list(split_lines([(TokenType.COMMENT, "# a\n# b")]))
+        assert lines == [
+            [Token(TokenType.COMMENT, "# a", 1, 1)],
+            [Token(TokenType.COMMENT, "# b", 2, 1)],
+        ]
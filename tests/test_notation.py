@@ -0,0 +1,125 @@
+"""Tests for notation comments (notation=True)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import highlight, tokenize
+from rosettes._notation import apply_notation
+
+
+def _apply(code: str, language: str = "python"):
+    """Apply notation and return (text, hl_lines, line_classes)."""
+    notation = apply_notation(tokenize(code, language))
+    text = "".join(t.value for t in notation.tokens)
+    return text, notation.hl_lines, notation.line_classes
+
+
+class TestMarkers:
+    """Markers are found in each language's comments."""
+
+    @pytest.mark.parametrize(
+        ("language", "code", "expected"),
+        [
+            ("python", "x = 1  # [!code ++]\n", "x = 1\n"),
+            ("javascript", "let x; // [!code ++]\n", "let x;\n"),
+            ("c", "int x; /* [!code ++] */\n", "int x;\n"),
+            ("sql", "SELECT 1; -- [!code ++]\n", "SELECT 1;\n"),
+            ("html", "<p>x</p> <!-- [!code ++] -->\n", "<p>x</p>\n"),
+            ("bash", "echo hi # [!code ++]\n", "echo hi\n"),
+        ],
+    )
+    def test_trailing_marker(self, language: str, code: str, expected: str) -> None:
+        """A marker after code marks that line and is removed."""
+        text, _, line_classes = _apply(code, language)
+        assert text == expected
+        assert line_classes == {1: "line-added"}
+
+    @pytest.mark.parametrize(
+        ("directive", "line_class"),
+        [
+            ("--", "line-removed"),
+            ("error", "line-error"),
+            ("warning", "line-warning"),
+        ],
+    )
+    def test_directives(self, directive: str, line_class: str) -> None:
+        """Each directive maps to its line class."""
+        assert _apply(f"x = 1  # [!code {directive}]")[2] == {1: line_class}
+
+    @pytest.mark.parametrize("directive", ["highlight", "hl"])
+    def test_highlight(self, directive: str) -> None:
+        """highlight marks lines through hl_lines instead of a class."""
+        _, hl_lines, line_classes = _apply(f"a = 1\nb = 2  # [!code {directive}]\n")
+        assert hl_lines == {2}
+        assert line_classes == {}
+
+    def test_comment_text_is_kept(self) -> None:
+        """Only the marker is removed from a comment with other text."""
+        text, _, line_classes = _apply("x = 1  # note [!code --]\n")
+        assert text == "x = 1  # note\n"
+        assert line_classes == {1: "line-removed"}
+
+    def test_unknown_directive_is_kept(self) -> None:
+        """Unknown directives and markers outside comments are left alone."""
+        code = 'x = "[!code ++]"  # [!code shout]\n'
+        assert _apply(code) == (code, frozenset(), {})
+
+
+class TestPlacement:
+    """Test marker lines and line ranges."""
+
+    def test_own_line_marks_next_line(self) -> None:
+        """A marker alone on its line marks the next line and is removed."""
+        code = "def f():\n    # [!code ++]\n    return 1\n"
+        text, _, line_classes = _apply(code)
+        assert text == "def f():\n    return 1\n"
+        assert line_classes == {2: "line-added"}
+
+    def test_positions_after_removed_line(self) -> None:
+        """Tokens after a removed line get updated positions."""
+        notation = apply_notation(tokenize("a = 1\n# [!code ++]\nb = 2\n", "python"))
+        b = next(t for t in notation.tokens if t.value == "b")
+        assert (b.line, b.column) == (2, 1)
+
+    def test_count(self) -> None:
+        """`:N` marks N lines starting at the marked line."""
+        code = "# [!code ++:2]\na = 1\nb = 2\nc = 3\n"
+        assert _apply(code)[2] == {1: "line-added", 2: "line-added"}
+
+    def test_focus_dims_other_lines(self) -> None:
+        """Focused lines make every other line dimmed."""
+        code = "a = 1\nb = 2  # [!code focus]\nc = 3  # [!code ++]\n"
+        assert _apply(code)[2] == {
+            1: "line-dimmed",
+            2: "line-focus",
+            3: "line-added line-dimmed",
+        }
+
+
+class TestHighlight:
+    """Test notation through highlight()."""
+
+    def test_html_line_classes(self) -> None:
+        """Marked lines get classes and markers don't appear in output."""
+        code = "a = 1  # [!code highlight]\nb = 2  # [!code ++]\n"
+        html = highlight(code, "python", notation=True)
+        assert '<span class="hll">' in html
+        assert '<span class="line-added">' in html
+        assert "[!code" not in html
+
+    def test_combined_with_hl_lines(self) -> None:
+        """hl_lines and highlight markers are merged."""
+        code = "a = 1  # [!code hl]\nb = 2\nc = 3\n"
+        html = highlight(code, "python", hl_lines={3}, notation=True)
+        assert html.count('<span class="hll">') == 2
+
+    def test_terminal(self) -> None:
+        """The terminal formatter styles marked lines."""
+        ansi = highlight("a = 1  # [!code --]\n", "python", "terminal", notation=True)
+        assert ansi.startswith("\033[48;5;52m")
+        assert "[!code" not in ansi
+
+    def test_off_by_default(self) -> None:
+        """Without notation=True, markers are ordinary comments."""
+        assert "[!code ++]" in highlight("a = 1  # [!code ++]\n", "python")
@@ -49,6 +49,13 @@ class TestHighlightMany:
         for i, result in enumerate(results):
             assert str(i) in result
 
+    def test_highlight_many_shared_options(self) -> None:
+        """Line options apply to every block, in both batch sizes."""
+        for count in (2, 10):
+            items = [("a = 1\nb = 2\n", "python")] * count
+            results = highlight_many(items, show_linenos=True, hl_lines={2})
+            assert all('<span class="lineno">2</span><span class="hll">' in r for r in results)
+
 
 class TestTokenizeMany:
     """Test tokenize_many() parallel API."""
@@ -0,0 +1,142 @@
+"""Tests for format-string placeholder highlighting (format_placeholders=True)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import TokenType, highlight, tokenize
+from rosettes._placeholders import scan_placeholders
+
+
+def _placeholders(code: str, language: str) -> list[str]:
+    """Return the values of all STRING_INTERPOL tokens."""
+    tokens = tokenize(code, language, format_placeholders=True)
+    return [t.value for t in tokens if t.type == TokenType.STRING_INTERPOL]
+
+
+class TestContexts:
+    """Placeholders are only highlighted in format-string contexts."""
+
+    @pytest.mark.parametrize(
+        ("language", "code", "expected"),
+        [
+            ("python", '"{name!r:>10} {}".format(x, y)', ["{name!r:>10}", "{}"]),
+            ("python", '"%(key)s is %5.2f" % data', ["%(key)s", "%5.2f"]),
+            ("python", 'f"{value:{width}}"', ["{value:{width}}"]),
+            ("rust", 'println!("{:?} {0}", x);', ["{:?}", "{0}"]),
+            ("rust", 'write!(f, "{name:>8}", name = n)', ["{name:>8}"]),
+            ("go", 'fmt.Printf("%v %+v %[1]d", x)', ["%v", "%+v", "%[1]d"]),
+            ("go", 'fmt.Fprintf(os.Stderr, "%s", err)', ["%s"]),
+            ("c", 'printf("%-5ld %08.3f", a, b);', ["%-5ld", "%08.3f"]),
+            ("cpp", 'std::format("{0:N2}", x);', ["{0:N2}"]),
+            ("java", 'String.format("%d items", n)', ["%d"]),
+            ("kotlin", 'val s = "%.2f".format(x)', ["%.2f"]),
+            ("bash", 'printf "%s\\n" "$name"', ["%s"]),
+            ("ruby", '"%05d" % n', ["%05d"]),
+            ("python", '"%s: 50% true" % x', ["%s"]),
+            ("c", 'printf("%d: 50% true", n);', ["%d"]),
+            ("go", 'fmt.Printf("%d: 50% ready", n)', ["%d"]),
+            ("c", 'snprintf(buf, sizeof(buf), "%d", n);', ["%d"]),
+            ("c", 'fprintf(stderr, "%d", "%s literal");', ["%d"]),
+            ("rust", 'write!(f, "{}", "{literal}")', ["{}"]),
+        ],
+    )
+    def test_format_context(self, language: str, code: str, expected: list[str]) -> None:
+        """Strings in a format context should have placeholders split out."""
+        assert _placeholders(code, language) == expected
+
+    @pytest.mark.parametrize(
+        ("language", "code"),
+        [
+            ("python", 's = "{not} a format %d string"'),
+            ("rust", 'let s = "{}";'),
+            ("go", 'x := "%v"'),
+            ("c", 'puts("%d");'),
+            ("c", 'printf(fmt, "%d");'),
+            ("c", 'sscanf("%d", fmt, &n);'),
+            ("ruby", 'printf fmt, "%d"'),
+            ("bash", 'printf "%s"\necho "%d"'),
+        ],
+    )
+    def test_plain_strings_untouched(self, language: str, code: str) -> None:
+        """Strings outside a format context should not be scanned."""
+        placeholders = _placeholders(code, language)
+        assert "%d" not in placeholders
+        assert "{}" not in placeholders
+        assert "{not}" not in placeholders
+
+    def test_disabled_by_default(self) -> None:
+        """The pass is opt-in."""
+        tokens = tokenize('printf("%d", x);', "c")
+        assert TokenType.STRING_INTERPOL not in {t.type for t in tokens}
+
+    def test_language_without_rules(self) -> None:
+        """Languages without rules are returned unchanged."""
+        code = '{"a": "%d"}'
+        assert tokenize(code, "json", format_placeholders=True) == tokenize(code, "json")
+
+
+class TestScanning:
+    """Test the placeholder scanners directly."""
+
+    def test_doubled_braces_are_escapes(self) -> None:
+        """`{{` and `}}` are literal braces."""
+        assert scan_placeholders('"{{x}} {y}"', "brace") == [
+            (1, 3, TokenType.STRING_ESCAPE),
+            (4, 6, TokenType.STRING_ESCAPE),
+            (7, 10, TokenType.STRING_INTERPOL),
+        ]
+
+    def test_percent_literal(self) -> None:
+        """`%%` is an escaped percent sign."""
+        assert scan_placeholders('"100%%"', "printf") == [(4, 6, TokenType.STRING_ESCAPE)]
+
+    def test_invalid_conversion_ignored(self) -> None:
+        """A `%` without a conversion character is plain text."""
+        assert scan_placeholders('"50%!"', "printf") == []
+
+    def test_unclosed_brace_ignored(self) -> None:
+        """An unclosed `{` is plain text."""
+        assert scan_placeholders('"{name"', "brace") == []
+
+    def test_unclosed_braces_linear(self) -> None:
+        """Many unclosed braces should not cause quadratic rescans."""
+        text = '"{{' + "x{" * 50_000 + '"'
+        assert scan_placeholders(text, "brace") == [(1, 3, TokenType.STRING_ESCAPE)]
+
+
+class TestPositions:
+    """Split tokens keep exact positions and text."""
+
+    def test_round_trip(self) -> None:
+        """Concatenated values reproduce the input."""
+        code = 'x = "{a}\\n{b}".format(a, b)\nprint("%d" % 3)\n'
+        tokens = tokenize(code, "python", format_placeholders=True)
+        assert "".join(t.value for t in tokens) == code
+
+    def test_columns(self) -> None:
+        """Placeholder tokens report their own column."""
+        tokens = tokenize('printf("a %d", x);', "c", format_placeholders=True)
+        placeholder = next(t for t in tokens if t.type == TokenType.STRING_INTERPOL)
+        assert (placeholder.line, placeholder.column) == (1, 11)
+
+    def test_multiline_string(self) -> None:
+        """Placeholders after a newline report the correct line."""
+        code = '"""first\n  {second}""".format(second=1)'
+        tokens = tokenize(code, "python", format_placeholders=True)
+        placeholder = next(t for t in tokens if t.type == TokenType.STRING_INTERPOL)
+        assert (placeholder.line, placeholder.column) == (2, 3)
+
+
+class TestHighlight:
+    """Test the highlight() integration."""
+
+    def test_html_fast_path(self) -> None:
+        """Placeholders get their own span in HTML output."""
+        html = highlight('printf("%d", x);', "c", format_placeholders=True)
+        assert ">%d</span>" in html
+
+    def test_html_slow_path(self) -> None:
+        """Placeholders also work with highlighted lines."""
+        html = highlight('printf("%d", x);', "c", hl_lines={1}, format_placeholders=True)
+        assert ">%d</span>" in html
@@ -1,10 +1,19 @@
-"""Tests for Rosettes registry (get_lexer, list_languages, supports_language)."""
+"""Tests for Rosettes registry (get_lexer, list_languages, supports_language, guess_language)."""
 
 from __future__ import annotations
 
 import pytest
 
-from rosettes import get_lexer, list_languages, supports_language
+from rosettes import (
+    UnknownLanguageError,
+    get_lexer,
+    guess_language,
+    highlight,
+    highlight_many,
+    list_languages,
+    supports_language,
+    tokenize,
+)
 
 
 class TestRegistryBasics:
@@ -104,3 +113,82 @@ class TestRegistryErrors:
         """get_lexer() should raise error for empty string."""
         with pytest.raises(LookupError):
             get_lexer("")
+
+    @pytest.mark.parametrize(
+        ("name", "suggestion"),
+        [("typscript", "typescript"), ("pyhton", "python"), ("Rustt", "rust"), ("ymal", "yaml")],
+    )
+    def test_suggestions(self, name: str, suggestion: str) -> None:
+        """Unknown names suggest close names and aliases."""
+        with pytest.raises(UnknownLanguageError) as exc_info:
+            get_lexer(name)
+        assert exc_info.value.name == name
+        assert exc_info.value.suggestions[0] == suggestion
+        assert f"Did you mean {suggestion!r}" in str(exc_info.value)
+
+    def test_no_suggestions(self) -> None:
+        """Distant names get no suggestions and no list of every language."""
+        with pytest.raises(UnknownLanguageError) as exc_info:
+            get_lexer("nonexistent-language-xyz")
+        assert exc_info.value.suggestions == ()
+        assert "python" not in str(exc_info.value)
+
+    def test_suggestions_one_per_language(self) -> None:
+        """Several aliases of one language give one suggestion."""
+        with pytest.raises(UnknownLanguageError) as exc_info:
+            get_lexer("pythn")
+        suggested = [get_lexer(name).name for name in exc_info.value.suggestions]
+        assert len(suggested) == len(set(suggested))
+
+
+class TestFallback:
+    """Test the fallback policy for unknown languages."""
+
+    def test_plaintext(self) -> None:
+        """fallback="plaintext" uses the plaintext lexer."""
+        assert get_lexer("klingon", fallback="plaintext").name == "plaintext"
+        assert 'data-language="plaintext"' in highlight("x", "klingon", fallback="plaintext")
+
+    @pytest.mark.parametrize(
+        ("name", "language"),
+        [("typscript", "typescript"), ("app.tsx", "typescript"), ("klingon", "plaintext")],
+    )
+    def test_guess(self, name: str, language: str) -> None:
+        """fallback="guess" tries the name as a file name, then the closest match."""
+        assert get_lexer(name, fallback="guess").name == language
+
+    def test_tokenize_and_many(self) -> None:
+        """tokenize() and highlight_many() take the policy too."""
+        assert tokenize("a b", "klingon", fallback="plaintext")
+        results = highlight_many([("x = 1", "pyhton")], fallback="guess")
+        assert 'data-language="python"' in results[0]
+        with pytest.raises(UnknownLanguageError):
+            highlight_many([("x = 1", "pyhton")])
+
+    def test_invalid_policy(self) -> None:
+        """An unknown policy raises ValueError, even for known languages."""
+        with pytest.raises(ValueError):
+            get_lexer("python", fallback="ignore")  # type: ignore[arg-type]
+
+
+class TestGuessLanguage:
+    """Test filename-based language detection."""
+
+    @pytest.mark.parametrize(
+        ("filename", "expected"),
+        [
+            ("app.py", "python"),
+            ("src/lib/main.rs", "rust"),
+            ("C:\\code\\index.ts", "typescript"),
+            ("Dockerfile", "dockerfile"),
+            ("settings.yaml", "yaml"),
+            ("queries/highlights.scm", "tree"),
+        ],
+    )
+    def test_known_names(self, filename: str, expected: str) -> None:
+        """File names matching a lexer's patterns resolve to that lexer."""
+        assert guess_language(filename) == expected
+
+    def test_unknown_name(self) -> None:
+        """Unclaimed names return None."""
+        assert guess_language("data.unknown-extension") is None
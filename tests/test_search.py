@@ -0,0 +1,107 @@
+"""Tests for search-term overlays (search=...)."""
+
+from __future__ import annotations
+
+import pytest
+
+from rosettes import SearchQuery, find_matches, highlight
+from rosettes._search import split_marks
+
+_CODE = 'def open_file(path):\n    """Open it."""\n    return open(path)\n'
+
+
+def _texts(search) -> list[str]:
+    return [match.text for match in find_matches(_CODE, search)]
+
+
+class TestFindMatches:
+    """Test matching modes and reported positions."""
+
+    def test_literal(self) -> None:
+        """Terms match literally and case-sensitively by default."""
+        assert _texts(["open"]) == ["open", "open"]
+
+    def test_ignore_case(self) -> None:
+        """ignore_case matches regardless of case."""
+        assert _texts(SearchQuery(["OPEN"], ignore_case=True)) == ["open", "Open", "open"]
+
+    def test_whole_word(self) -> None:
+        """whole_word skips matches inside identifiers."""
+        assert _texts(SearchQuery(["open"], whole_word=True)) == ["open"]
+        assert _texts(SearchQuery(["open_file"], whole_word=True)) == ["open_file"]
+
+    def test_no_regex(self) -> None:
+        """Regex metacharacters are literal."""
+        matches = find_matches("a.b axb (a+)", ["a.b", "(a+)"])
+        assert [m.text for m in matches] == ["a.b", "(a+)"]
+
+    def test_positions(self) -> None:
+        """Matches report offsets, lines and columns."""
+        matches = find_matches(_CODE, SearchQuery(["open"], ignore_case=True))
+        assert [(m.start, m.line, m.column) for m in matches] == [
+            (4, 1, 5),
+            (28, 2, 8),
+            (51, 3, 12),
+        ]
+
+    def test_overlapping_merged(self) -> None:
+        """Overlapping matches of several terms become one match."""
+        matches = find_matches("abcdef", ["abc", "cde", "f"])
+        assert [(m.start, m.end) for m in matches] == [(0, 5), (5, 6)]
+
+    def test_empty_terms(self) -> None:
+        """Empty terms are ignored."""
+        assert find_matches("abc", ["", "b"])[0].text == "b"
+
+    def test_case_folding_keeps_offsets(self) -> None:
+        """Characters with longer lowercase forms don't shift offsets."""
+        match = find_matches("İx abc", SearchQuery(["ABC"], ignore_case=True))[0]
+        assert (match.start, match.column) == (3, 4)
+
+
+class TestSplitMarks:
+    """Test splitting text at mark boundaries."""
+
+    @pytest.mark.parametrize(
+        ("offset", "expected"),
+        [
+            (0, [("ab", None)]),
+            (10, [("fo", 0), ("ob", None), ("a", 1), ("r", None)]),
+            (20, [("foobar", None)]),
+        ],
+    )
+    def test_segments(self, offset: int, expected) -> None:
+        """Text is split into marked and unmarked segments."""
+        value = "ab" if offset == 0 else "foobar"
+        assert split_marks(value, offset, [(8, 12), (14, 15)])[0] == expected
+
+
+class TestHighlight:
+    """Test marks in formatted output."""
+
+    def test_html_mark_inside_span(self) -> None:
+        """Marks sit inside token spans."""
+        html = highlight(_CODE, "python", search=["open"])
+        assert '<span class="syntax-variable"><mark data-match="0">open</mark>_file</span>' in html
+        assert '<mark data-match="1">open</mark>' in html
+
+    def test_html_across_tokens_and_lines(self) -> None:
+        """A match spanning tokens is split without breaking nesting."""
+        html = highlight("a = b\nc\n", "python", search=["= b\nc"])
+        assert html.count('<mark data-match="0">') == 4
+        assert '<span class="syntax-operator"><mark data-match="0">=</mark></span>' in html
+        assert '<mark data-match="0">\n' not in html
+
+    def test_html_with_line_features(self) -> None:
+        """Marks work with line numbers and highlighted lines."""
+        html = highlight("x = 1\n", "python", search=["x"], hl_lines={1}, show_linenos=True)
+        assert '<span class="hll"><span class="syntax-variable"><mark data-match="0">x' in html
+
+    def test_terminal_reverse_video(self) -> None:
+        """Terminal matches are shown in reverse video."""
+        ansi = highlight("x = 1\n", "python", "terminal", search=["1"])
+        assert "\033[7m1\033[0m" in ansi
+
+    def test_no_matches(self) -> None:
+        """A search without matches leaves the output unchanged."""
+        assert highlight(_CODE, "python", search=["zzz"]) == highlight(_CODE, "python")
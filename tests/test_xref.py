@@ -0,0 +1,145 @@
+"""Tests for cross-reference links (rosettes.xref)."""
+
+from __future__ import annotations
+
+import zlib
+from pathlib import Path
+
+import pytest
+
+from rosettes import HtmlFormatter, highlight, tokenize
+from rosettes.xref import load_inventory, parse_inventory, resolve_links
+
+_INVENTORY_HEADER = (
+    b"# Sphinx inventory version 2\n"
+    b"# Project: Python\n"
+    b"# Version: 3.13\n"
+    b"# The remainder of this file is compressed using zlib.\n"
+)
+
+_INVENTORY_BODY = """\
+os py:module 0 library/os.html#module-$ -
+os.path.join py:function 1 library/os.path.html#$ -
+str.join py:method 1 library/stdtypes.html#$ -
+pathlib.Path py:class 1 library/pathlib.html#$ -
+format string std:label -1 library/string.html#formatstrings Format String Syntax
+"""
+
+
+def _inventory() -> bytes:
+    return _INVENTORY_HEADER + zlib.compress(_INVENTORY_BODY.encode())
+
+
+def _links(code: str, resolver, language: str = "python") -> list[tuple[str, str]]:
+    pairs = resolve_links(tokenize(code, language), resolver)
+    return [(token.value, url) for token, url in pairs if url is not None]
+
+
+class TestResolveLinks:
+    """Test which names are looked up."""
+
+    def test_dotted_chain(self) -> None:
+        """Each part of a chain is looked up by its qualified name."""
+        resolver = {"os": "os.html", "os.path.join": "join.html"}
+        assert _links("os.path.join(a, b)", resolver) == [("os", "os.html"), ("join", "join.html")]
+
+    def test_scope_separator(self) -> None:
+        """`::` joins a chain like `.` does."""
+        assert _links("std::fs::read(p);", {"std::fs::read": "r.html"}, "rust") == [
+            ("read", "r.html")
+        ]
+
+    def test_chain_after_expression(self) -> None:
+        """A name after `.` that doesn't follow a name isn't looked up."""
+        assert _links("f().join", {"join": "j.html", "f": "f.html"}) == [("f", "f.html")]
+
+    def test_callable_resolver(self) -> None:
+        """A callable is called once per distinct name."""
+        calls: list[str] = []
+
+        def resolver(name: str) -> str | None:
+            calls.append(name)
+            return f"/api/{name}.html" if name == "Path" else None
+
+        assert _links("Path(Path(x))", resolver) == [
+            ("Path", "/api/Path.html"),
+            ("Path", "/api/Path.html"),
+        ]
+        assert calls == ["Path", "x"]
+
+    @pytest.mark.parametrize(
+        "url",
+        ["javascript:alert(1)", "JavaScript:alert(1)", "data:text/html,x", "java\tscript:x", ""],
+    )
+    def test_unsafe_urls_dropped(self, url: str) -> None:
+        """Only relative and http(s) URLs are linked."""
+        assert _links("x", {"x": url}) == []
+
+    @pytest.mark.parametrize("url", ["https://e.com/x", "HTTP://e.com", "api/x.html#a:b", "#x"])
+    def test_safe_urls_kept(self, url: str) -> None:
+        """Relative and http(s) URLs are linked."""
+        assert _links("x", {"x": url}) == [("x", url)]
+
+
+class TestInventory:
+    """Test Sphinx inventory parsing."""
+
+    def test_parse(self) -> None:
+        """Names map to URLs with `$` expanded and the base URL prepended."""
+        links = parse_inventory(_inventory(), "https://docs.python.org/3")
+        assert links["os"] == "https://docs.python.org/3/library/os.html#module-os"
+        assert links["os.path.join"] == (
+            "https://docs.python.org/3/library/os.path.html#os.path.join"
+        )
+        assert "format string" not in links
+
+    def test_all_domains(self) -> None:
+        """domains=None keeps every domain; names may contain spaces."""
+        links = parse_inventory(_inventory(), domains=None)
+        assert links["format string"] == "library/string.html#formatstrings"
+
+    def test_load(self, tmp_path: Path) -> None:
+        """load_inventory() reads the file."""
+        path = tmp_path / "objects.inv"
+        path.write_bytes(_inventory())
+        assert load_inventory(path)["pathlib.Path"] == "library/pathlib.html#pathlib.Path"
+
+    @pytest.mark.parametrize(
+        "data",
+        [
+            b"# Sphinx inventory version 1\n# a\n# b\nx\n",
+            _INVENTORY_HEADER + b"not zlib",
+            b"# Sphinx inventory version 2\n",
+        ],
+    )
+    def test_invalid(self, data: bytes) -> None:
+        """Other versions, corrupt data and short headers raise ValueError."""
+        with pytest.raises(ValueError):
+            parse_inventory(data)
+
+
+class TestHighlight:
+    """Test links in HTML output."""
+
+    def test_links(self) -> None:
+        """Resolved names are wrapped in escaped anchors."""
+        html = highlight("os.getcwd()", "python", resolver={"os": 'os.html?a=1&b="2"'})
+        assert (
+            '<a class="xref" href="os.html?a=1&amp;b=&quot;2&quot;">'
+            '<span class="syntax-variable">os</span></a>'
+        ) in html
+
+    def test_formatter_resolver(self) -> None:
+        """An HtmlFormatter's own resolver is used, with line highlighting too."""
+        formatter = HtmlFormatter(resolver={"x": "x.html"})
+        assert 'href="x.html"' in highlight("x", "python", formatter)
+        assert 'href="x.html"' in highlight("x", "python", formatter, hl_lines={1})
+
+    def test_no_resolver(self) -> None:
+        """Without a resolver there are no links."""
+        assert "<a " not in highlight("os.path.join(a)", "python")
+
+    def test_terminal_ignores_resolver(self) -> None:
+        """Links are HTML-only."""
+        ansi = highlight("x", "python", "terminal", resolver={"x": "x.html"})
+        assert "x.html" not in ansi
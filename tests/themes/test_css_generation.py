@@ -166,3 +166,62 @@ class TestAdaptivePalette:
 
         assert "@media (prefers-color-scheme: light)" in css
         assert "@media (prefers-color-scheme: dark)" in css
+
+
+def _contrast_on_white(color: str) -> float:
+    from rosettes.themes._palette import _luminance, _parse_hex
+
+    rgb = _parse_hex(color)
+    assert rgb is not None
+    return 1.05 / (_luminance(rgb) + 0.05)
+
+
+class TestPrintCss:
+    """Test print palettes and the @media print section."""
+
+    @pytest.mark.parametrize(
+        "palette_name", [n for n in list_palettes() if hasattr(get_palette(n), "for_print")]
+    )
+    @pytest.mark.parametrize(("high_contrast", "minimum"), [(False, 4.5), (True, 7.0)])
+    def test_for_print_contrast(
+        self, palette_name: str, high_contrast: bool, minimum: float
+    ) -> None:
+        """Every color of a print palette is readable on white."""
+        printed = get_palette(palette_name).for_print(high_contrast=high_contrast)
+        assert printed.background == "#ffffff"
+        for name in ("text", "string", "comment", "function", "warning", "punctuation"):
+            assert _contrast_on_white(getattr(printed, name)) >= minimum, name
+
+    def test_dark_text_becomes_dark(self) -> None:
+        """Light text of a dark palette is printed dark."""
+        printed = get_palette("monokai").for_print()
+        assert printed.name == "monokai-print"
+        assert _contrast_on_white(printed.text) > 15
+
+    def test_light_palette_kept(self) -> None:
+        """Colors of a light palette that already contrast are kept."""
+        light = get_palette("github-light")
+        assert light.for_print().string == light.with_defaults().string
+
+    def test_high_contrast_text_is_black(self) -> None:
+        """High contrast prints black text."""
+        assert get_palette("dracula").for_print(high_contrast=True).text == "#000000"
+
+    def test_print_section(self) -> None:
+        """print=True appends an @media print section after the screen rules."""
+        palette = get_palette("monokai")
+        css = palette.generate_css(print=True)
+        screen, printed = css.split("@media print {")
+        assert screen.rstrip() == palette.generate_css()
+        assert "--syntax-bg: #ffffff;" in printed
+        assert ".rosettes .hll, .highlight .hll {" in printed
+        assert "border-left: 3px solid" in printed
+        assert "white-space: pre-wrap;" in printed
+        assert f"color: {palette.for_print().function};" in printed
+        assert css.count("{") == css.count("}")
+
+    def test_adaptive_print_section_last(self) -> None:
+        """Adaptive palettes print from the light palette, after the dark scheme."""
+        css = get_palette("github").generate_css(print="high-contrast")
+        assert css.index("@media print") > css.index("prefers-color-scheme: dark")
+        assert "color: #000000;" in css.split("@media print")[1]